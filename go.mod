@@ -5,9 +5,11 @@ go 1.23
 require (
 	github.com/jedib0t/go-pretty/v6 v6.7.10
 	github.com/vektah/gqlparser/v2 v2.5.32
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/agnivade/levenshtein v1.2.1 // indirect
 	github.com/mattn/go-runewidth v0.0.16 // indirect
 	github.com/rivo/uniseg v0.4.7 // indirect
 	golang.org/x/sys v0.30.0 // indirect
@@ -1,15 +1,32 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/vektah/gqlparser/v2/ast"
-	"github.com/vektah/gqlparser/v2/parser"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/coverage"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/diff"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/generator"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/lint"
 	schemaParser "github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/serve"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/validate"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/watch"
 )
 
 var (
@@ -24,6 +41,24 @@ func init() {
 }
 
 func main() {
+	// Subcommands take their own flags
+	if len(os.Args) > 1 && os.Args[1] == "diff" {
+		runDiff(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "coverage" {
+		runCoverage(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "lint" {
+		runLint(os.Args[2:])
+		return
+	}
+
 	// Parse configuration
 	cfg := config.ParseFlags()
 
@@ -42,71 +77,400 @@ func main() {
 		config.PrintError(err.Error())
 		os.Exit(1)
 	}
+	if err := generator.CheckFormats(cfg); err != nil {
+		config.PrintError(err.Error())
+		os.Exit(1)
+	}
 
-	// Read schema content (single file or multiple files)
-	var schemaContent string
-	if cfg.SchemaPattern != "" {
-		// Multi-file mode using pattern
-		files, err := schemaParser.FindSchemaFiles(cfg.SchemaPattern)
-		if err != nil {
-			log.Fatalf("Failed to find schema files with pattern '%s': %v", cfg.SchemaPattern, err)
+	if cfg.Watch {
+		runWatch(cfg)
+		return
+	}
+
+	if err := generate(cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// loadSchema reads and parses the schema from a single file, multiple files
+// or an introspection result and, in strict mode, validates it
+func loadSchema(cfg *config.Config) (*ast.Schema, error) {
+	// Read and parse the schema (single file or multiple files), merging any
+	// `extend type` extensions into their base definitions, or convert a
+	// saved introspection result into the same schema shape
+	var loaded *schemaParser.LoadedSchema
+	var err error
+	if cfg.IntrospectionFile != "" {
+		loaded, err = schemaParser.LoadIntrospection(cfg.IntrospectionFile)
+	} else {
+		loaded, err = schemaParser.LoadSchema(cfg.SchemaFile, cfg.SchemaPattern)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Verbose {
+		if len(loaded.Files) > 1 {
+			log.Printf("Combined %d schema files: %v", len(loaded.Files), loaded.Files)
+		}
+		if loaded.RemovedFragments {
+			log.Printf("Removed fragment definitions from schema")
 		}
+	}
 
-		// Validate files are accessible and have correct extensions
-		if err := schemaParser.ValidateSchemaFiles(files); err != nil {
-			log.Fatalf("Schema file validation failed: %v", err)
+	// In strict mode, refuse to document a schema that does not validate
+	if cfg.Strict {
+		if errs := validate.Sources(loaded.Sources); len(errs) > 0 {
+			lines := make([]string, len(errs))
+			for i, e := range errs {
+				lines[i] = e.Error()
+			}
+			return nil, fmt.Errorf("schema validation failed with %d error(s):\n%s", len(errs), strings.Join(lines, "\n"))
 		}
+		if cfg.Verbose {
+			log.Printf("Schema validation passed")
+		}
+	}
+	return loaded.Schema, nil
+}
 
-		// Combine multiple schema files
-		schemaContent, err = schemaParser.CombineSchemaFiles(files)
-		if err != nil {
-			log.Fatalf("Failed to combine schema files: %v", err)
+// generate reads and parses the schema and writes the documentation once
+func generate(cfg *config.Config) error {
+	schema, err := loadSchema(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Get output writer
+	outputWriter, shouldClose, err := cfg.GetOutputWriter()
+	if err != nil {
+		return fmt.Errorf("failed to setup output: %v", err)
+	}
+	// Generate AsciiDoc documentation
+	gen := generator.New(cfg, schema, outputWriter)
+	err = gen.Generate()
+
+	if shouldClose {
+		if closeErr := outputWriter.Close(); closeErr != nil {
+			return fmt.Errorf("failed to close output file: %v", closeErr)
 		}
+	}
 
-		if cfg.Verbose {
-			log.Printf("Combined %d schema files: %v", len(files), files)
+	if err != nil {
+		return fmt.Errorf("failed to generate documentation: %v", err)
+	}
+	return nil
+}
+
+// runWatch generates the documentation, then regenerates it whenever the
+// schema files change until interrupted. Errors, such as a schema that no
+// longer parses, are reported and watching carries on, so the next save can
+// fix them.
+func runWatch(cfg *config.Config) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	w := watchSchema(cfg)
+	regenerate := func() {
+		start := time.Now()
+		if err := generate(cfg); err != nil {
+			log.Printf("Error: %v", err)
+			return
 		}
-	} else {
-		// Single file mode
-		schemaBytes, err := os.ReadFile(cfg.SchemaFile)
+		log.Printf("Wrote %s in %v", watchTarget(cfg), time.Since(start).Round(time.Millisecond))
+	}
+
+	regenerate()
+	log.Printf("Watching %d file(s) for changes, press Ctrl+C to stop", len(w.Files()))
+	w.Run(ctx, func(changed []string) {
+		log.Printf("Changed: %s", strings.Join(changed, ", "))
+		cfg = reloadConfig(cfg, changed, func() (*config.Config, error) {
+			return config.ParseArgs(os.Args[1:])
+		})
+		regenerate()
+	})
+}
+
+// runCoverage implements the coverage subcommand: it reports how much of
+// the schema is documented and fails below the minimum coverage
+func runCoverage(args []string) {
+	cfg, err := config.ParseCoverageFlags(args)
+	if err != nil {
+		config.PrintError(err.Error())
+	}
+	if cfg.ShowHelp {
+		config.PrintCoverageUsage()
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		config.PrintError(err.Error())
+	}
+
+	schema, err := loadSchema(&config.Config{
+		SchemaFile:        cfg.SchemaFile,
+		SchemaPattern:     cfg.SchemaPattern,
+		IntrospectionFile: cfg.IntrospectionFile,
+		Verbose:           cfg.Verbose,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	report := coverage.Analyse(schema)
+	if cfg.Verbose {
+		total := report.Total()
+		log.Printf("Documented %d of %d elements in %s", total.Described, total.Total, cfg.SchemaSource())
+	}
+
+	outputWriter, shouldClose, err := cfg.GetOutputWriter()
+	if err != nil {
+		log.Fatalf("Failed to setup output: %v", err)
+	}
+	err = report.Write(outputWriter, cfg.Format)
+
+	if shouldClose {
+		if closeErr := outputWriter.Close(); closeErr != nil {
+			log.Fatalf("Failed to close output file: %v", closeErr)
+		}
+	}
+
+	if err != nil {
+		log.Fatalf("Failed to write coverage report: %v", err)
+	}
+	if cfg.MinCoverage > 0 && report.Coverage() < cfg.MinCoverage {
+		log.Fatalf("Documentation coverage %.1f%% is below the minimum of %g%%", report.Coverage(), cfg.MinCoverage)
+	}
+}
+
+// runLint implements the lint subcommand: it checks the schema against the
+// configured rules and fails when a rule set to error reports an issue
+func runLint(args []string) {
+	cfg, err := config.ParseLintFlags(args)
+	if err != nil {
+		config.PrintError(err.Error())
+	}
+	if cfg.ShowHelp {
+		config.PrintLintUsage()
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		config.PrintError(err.Error())
+	}
+
+	linter, err := lint.New(cfg.Rules)
+	if err != nil {
+		config.PrintError(err.Error())
+	}
+
+	schema, err := loadSchema(&config.Config{
+		SchemaFile:        cfg.SchemaFile,
+		SchemaPattern:     cfg.SchemaPattern,
+		IntrospectionFile: cfg.IntrospectionFile,
+		Verbose:           cfg.Verbose,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	issues := linter.Run(schema)
+
+	outputWriter, shouldClose, err := cfg.GetOutputWriter()
+	if err != nil {
+		log.Fatalf("Failed to setup output: %v", err)
+	}
+	for _, issue := range issues {
+		fmt.Fprintln(outputWriter, issue)
+	}
+	errorCount, warningCount := lint.Count(issues, lint.Error), lint.Count(issues, lint.Warn)
+	fmt.Fprintf(outputWriter, "%d errors, %d warnings\n", errorCount, warningCount)
+
+	if shouldClose {
+		if closeErr := outputWriter.Close(); closeErr != nil {
+			log.Fatalf("Failed to close output file: %v", closeErr)
+		}
+	}
+	if errorCount > 0 {
+		os.Exit(1)
+	}
+}
+
+// runServe implements the serve subcommand: it renders the documentation as
+// HTML in memory, serves it and regenerates it whenever the schema changes,
+// reloading open browsers
+func runServe(args []string) {
+	cfg, err := config.ParseServeFlags(args)
+	if err != nil {
+		config.PrintError(err.Error())
+	}
+	if cfg.ShowHelp {
+		config.PrintServeUsage()
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		config.PrintError(err.Error())
+	}
+
+	server := serve.New(func() ([]byte, error) {
+		schema, err := loadSchema(cfg)
 		if err != nil {
-			log.Fatalf("Failed to read schema file %s: %v", cfg.SchemaFile, err)
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := generator.New(cfg, schema, &buf).Generate(); err != nil {
+			return nil, fmt.Errorf("failed to generate documentation: %v", err)
+		}
+		return buf.Bytes(), nil
+	})
+	if err := server.Rebuild(); err != nil {
+		log.Printf("Error: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", cfg.ServeAddr)
+	if err != nil {
+		log.Fatalf("Failed to listen on %s: %v", cfg.ServeAddr, err)
+	}
+	httpServer := &http.Server{Handler: server, ReadHeaderTimeout: 10 * time.Second}
+	httpServer.RegisterOnShutdown(server.Close)
+	go func() {
+		if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Failed to serve: %v", err)
 		}
-		schemaContent = string(schemaBytes)
+	}()
+
+	if !cfg.IsLoopback() {
+		log.Printf("Warning: %s is not a loopback address, so the preview is reachable from other machines",
+			cfg.ServeAddr)
 	}
+	log.Printf("Serving http://%s/, press Ctrl+C to stop", listener.Addr())
 
-	// Remove fragments from schema content before parsing
-	// Fragments are client-side constructs and don't belong in schema files
-	cleanedSchema := schemaParser.RemoveFragments(schemaContent)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	watchSchema(cfg).Run(ctx, func(changed []string) {
+		log.Printf("Changed: %s", strings.Join(changed, ", "))
+		cfg = reloadConfig(cfg, changed, func() (*config.Config, error) {
+			return config.ParseServeFlags(args)
+		})
+		start := time.Now()
+		if err := server.Rebuild(); err != nil {
+			log.Printf("Error: %v", err)
+			return
+		}
+		log.Printf("Rebuilt in %v", time.Since(start).Round(time.Millisecond))
+	})
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down: %v", err)
+	}
+}
 
-	if cfg.Verbose && cleanedSchema != schemaContent {
-		log.Printf("Removed fragment definitions from schema")
+// watchSchema returns a watcher for the schema files of cfg: the schema
+// file, the files matching the pattern or the introspection file. An archive
+// is watched as a single file whatever the pattern applied inside it. The
+// configuration file and the files of the template directory are watched
+// too, since they change the output as much as the schema does.
+func watchSchema(cfg *config.Config) *watch.Watcher {
+	var extra []string
+	for _, path := range []string{cfg.ConfigFile, cfg.TemplatesDir} {
+		if path != "" {
+			extra = append(extra, path)
+		}
 	}
 
-	// Parse GraphQL schema directly - code blocks in descriptions are safe
-	// because they're inside triple-quoted strings
-	source := &ast.Source{
-		Name:  "GraphQL schema",
-		Input: cleanedSchema,
+	if cfg.IntrospectionFile != "" {
+		return watch.New(cfg.IntrospectionFile, "", extra...)
+	}
+	if schemafile.IsArchive(cfg.SchemaFile) {
+		return watch.New(cfg.SchemaFile, "", extra...)
 	}
+	return watch.New(cfg.SchemaFile, cfg.SchemaPattern, extra...)
+}
 
-	doc, gqlErr := parser.ParseSchema(source)
-	if gqlErr != nil {
-		log.Fatalf("Failed to parse GraphQL schema: %v", gqlErr)
+// reloadConfig reads the configuration again with parse when the
+// configuration file is among the changed files, so that watch mode and
+// serve pick up the new settings. An invalid configuration is reported and
+// the current one kept. The watched paths stay those given at startup.
+func reloadConfig(cfg *config.Config, changed []string, parse func() (*config.Config, error)) *config.Config {
+	if cfg.ConfigFile == "" || !slices.Contains(changed, cfg.ConfigFile) {
+		return cfg
+	}
+	reloaded, err := parse()
+	if err == nil {
+		err = reloaded.Validate()
 	}
+	if err == nil {
+		err = generator.CheckFormats(reloaded)
+	}
+	if err != nil {
+		log.Printf("Error: %v; keeping the previous configuration", err)
+		return cfg
+	}
+	log.Printf("Reloaded %s", cfg.ConfigFile)
+	return reloaded
+}
 
-	// Convert document to schema, merging any `extend type` extensions into
-	// their base definitions.
-	schema := schemaParser.BuildSchema(doc)
+// watchTarget names where watch mode writes the documentation
+func watchTarget(cfg *config.Config) string {
+	if cfg.SplitDir != "" {
+		return cfg.SplitDir
+	}
+	return cfg.OutputFile
+}
+
+// runDiff implements the diff subcommand: it compares two schema versions
+// and writes an AsciiDoc document listing the changes
+func runDiff(args []string) {
+	cfg, err := config.ParseDiffFlags(args)
+	if err != nil {
+		config.PrintError(err.Error())
+	}
+	if cfg.ShowHelp {
+		config.PrintDiffUsage()
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		config.PrintError(err.Error())
+	}
+
+	var files []string
+	load := func(arg string) *ast.Schema {
+		file, pattern := arg, ""
+		if schemafile.IsPattern(arg) {
+			file, pattern = "", arg
+		}
+		loaded, err := schemaParser.LoadSchema(file, pattern)
+		if err != nil {
+			log.Fatalf("%s: %v", arg, err)
+		}
+		if cfg.Verbose {
+			log.Printf("Loaded %d schema files for %s: %v", len(loaded.Files), arg, loaded.Files)
+		}
+		if pattern == "" {
+			// A schema file or archive is dated as a whole
+			files = append(files, file)
+		} else {
+			files = append(files, loaded.Files...)
+		}
+		return loaded.Schema
+	}
+	report := diff.Compare(load(cfg.OldSchema), load(cfg.NewSchema))
+
+	if cfg.Verbose {
+		log.Printf("Found %d breaking and %d non-breaking changes", len(report.Breaking()), len(report.NonBreaking()))
+	}
 
-	// Get output writer
 	outputWriter, shouldClose, err := cfg.GetOutputWriter()
 	if err != nil {
 		log.Fatalf("Failed to setup output: %v", err)
 	}
-	// Generate AsciiDoc documentation
-	gen := generator.New(cfg, schema, outputWriter)
-	err = gen.Generate()
+	data := diff.DocumentData{
+		Title:     cfg.Title,
+		OldSchema: cfg.OldSchema,
+		NewSchema: cfg.NewSchema,
+		RevDate:   config.RevisionDate(cfg, func() time.Time { return config.NewestModTime(files) }),
+	}
+	if cfg.IsReproducible() {
+		data.OldSchema, data.NewSchema = config.RelativePath(data.OldSchema), config.RelativePath(data.NewSchema)
+	}
+	err = report.WriteAsciiDoc(outputWriter, data)
 
 	if shouldClose {
 		if closeErr := outputWriter.Close(); closeErr != nil {
@@ -115,6 +479,6 @@ func main() {
 	}
 
 	if err != nil {
-		log.Fatalf("Failed to generate documentation: %v", err)
+		log.Fatalf("Failed to write changes: %v", err)
 	}
 }
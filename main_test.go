@@ -2,6 +2,7 @@ package main
 
 import (
 	"bytes"
+	"flag"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -206,3 +207,141 @@ func firstDiff(got, want string) string {
 	wantEnd := min(i+80, len(want))
 	return "  got:  …" + got[start:gotEnd] + "…\n  want: …" + want[start:wantEnd] + "…"
 }
+
+// TestIntrospectionMatchesSDL checks that documenting a saved introspection
+// result gives the same output as documenting the equivalent SDL, apart from
+// the source file name in the preamble.
+func TestIntrospectionMatchesSDL(t *testing.T) {
+	const sdlPath, introspectionPath = "test/introspection.graphqls", "test/introspection.json"
+
+	fromSDL, err := renderFixture(sdlPath)
+	if err != nil {
+		t.Fatalf("render %s: %v", sdlPath, err)
+	}
+
+	loaded, err := parser.LoadIntrospection(introspectionPath)
+	if err != nil {
+		t.Fatalf("load %s: %v", introspectionPath, err)
+	}
+	cfg := config.NewConfig()
+	cfg.SchemaFile = sdlPath
+	cfg.IncludeSubscriptions = true
+	var buf bytes.Buffer
+	if err := generator.New(cfg, loaded.Schema, &buf).Generate(); err != nil {
+		t.Fatalf("generate from introspection: %v", err)
+	}
+
+	got, want := normalisePreamble(buf.String()), normalisePreamble(fromSDL)
+	if got != want {
+		t.Errorf("introspection output differs from SDL output.\n\nFirst-diff region:\n%s", firstDiff(got, want))
+	}
+}
+
+var updateGoldens = flag.Bool("update", false, "rewrite the reproducible-output goldens in test/reproducible/")
+
+// reproducibleCase is a documented command line with its expected output in
+// test/reproducible/
+type reproducibleCase struct {
+	golden string
+	// env is set for the run, e.g. SOURCE_DATE_EPOCH
+	env map[string]string
+	// args is the command line the output records
+	args []string
+	cfg  func(*config.Config)
+}
+
+var reproducibleCases = []reproducibleCase{
+	{
+		golden: "multi-schema.adoc",
+		args: []string{"-p", "test/multi-schema/*", "--reproducible", "--revdate", "2025-01-01",
+			"--subscriptions", "--examples", "--diagram", "mermaid"},
+		cfg: func(c *config.Config) {
+			c.SchemaPattern = "test/multi-schema/*"
+			c.Reproducible = true
+			c.RevDate = "2025-01-01"
+			c.IncludeSubscriptions = true
+			c.Examples = true
+			c.Diagram = "mermaid"
+		},
+	},
+	{
+		golden: "catalogue.adoc",
+		env:    map[string]string{config.SourceDateEpochEnv: "1735689600"},
+		args:   []string{"-p", "test/multi-schema/*", "--catalogue", "--subscriptions", "--sub-title", "Blog"},
+		cfg: func(c *config.Config) {
+			c.SchemaPattern = "test/multi-schema/*"
+			c.Catalogue = true
+			c.IncludeSubscriptions = true
+			c.SubTitle = "Blog"
+		},
+	},
+	{
+		golden: "multi-schema.md",
+		args:   []string{"-p", "test/multi-schema/*", "--reproducible", "--revdate", "2025-01-01", "--format", "markdown"},
+		cfg: func(c *config.Config) {
+			c.SchemaPattern = "test/multi-schema/*"
+			c.Reproducible = true
+			c.RevDate = "2025-01-01"
+			c.Format = "markdown"
+		},
+	},
+}
+
+// TestReproducibleGoldens runs the generate pipeline in reproducible mode
+// twice per case and requires both runs to match the golden byte for byte:
+// no clock, absolute path or map order may leak into the output.
+//
+// If a change intentionally moves the output, regenerate the goldens with
+// `make test_doc_reproducible`.
+func TestReproducibleGoldens(t *testing.T) {
+	for _, tc := range reproducibleCases {
+		t.Run(tc.golden, func(t *testing.T) {
+			for name, value := range tc.env {
+				t.Setenv(name, value)
+			}
+			originalArgs := os.Args
+			os.Args = append([]string{"graphqls-to-asciidoc"}, tc.args...)
+			defer func() { os.Args = originalArgs }()
+
+			var runs [2]string
+			for i := range runs {
+				cfg := config.NewConfig()
+				tc.cfg(cfg)
+				cfg.OutputFile = filepath.Join(t.TempDir(), tc.golden)
+				if err := cfg.Validate(); err != nil {
+					t.Fatalf("invalid case: %v", err)
+				}
+				if err := generate(cfg); err != nil {
+					t.Fatalf("generate: %v", err)
+				}
+				out, err := os.ReadFile(cfg.OutputFile)
+				if err != nil {
+					t.Fatal(err)
+				}
+				runs[i] = string(out)
+			}
+			if runs[0] != runs[1] {
+				t.Fatalf("two runs differ.\n\nFirst-diff region:\n%s", firstDiff(runs[1], runs[0]))
+			}
+
+			goldenPath := filepath.Join("test", "reproducible", tc.golden)
+			if *updateGoldens {
+				if err := os.WriteFile(goldenPath, []byte(runs[0]), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("read golden %s: %v", goldenPath, err)
+			}
+			if runs[0] != string(want) {
+				t.Errorf(
+					"%s: output does not match golden.\n"+
+						"Regenerate with `make test_doc_reproducible` and re-run.\n\n"+
+						"First-diff region:\n%s",
+					goldenPath, firstDiff(runs[0], string(want)),
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package antora
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/asciidoc"
+)
+
+// IndexPage is the page holding everything that is not split into its own
+// page: the document header, the summary tables and the section introductions
+const IndexPage = "index.adoc"
+
+// itemKind describes one kind of tagged item in the generated document
+type itemKind struct {
+	dir   string // page directory below pages/
+	title string // navigation group title
+}
+
+// itemKinds maps the tag prefix the generator wraps each item in, e.g.
+// "// tag::query-users[]", to where its page is written
+var itemKinds = map[string]itemKind{
+	"query":        {dir: "queries", title: "Queries"},
+	"mutation":     {dir: "mutations", title: "Mutations"},
+	"subscription": {dir: "subscriptions", title: "Subscriptions"},
+	"type":         {dir: "types", title: "Types"},
+	"interface":    {dir: "interfaces", title: "Interfaces"},
+	"union":        {dir: "unions", title: "Unions"},
+	"enum":         {dir: "enums", title: "Enums"},
+	"input":        {dir: "inputs", title: "Inputs"},
+	"directive":    {dir: "directives", title: "Directives"},
+	"scalar":       {dir: "scalars", title: "Scalars"},
+}
+
+var reItemTag = regexp.MustCompile(`^// tag::([a-z]+)-([_A-Za-z][_A-Za-z0-9]*)\[\]$`)
+
+// Page is one page of the split documentation
+type Page struct {
+	Path    string // relative to the pages directory, e.g. "queries/users.adoc"
+	Title   string
+	Group   string // navigation group, e.g. "Queries"; empty for the index page
+	Content string
+}
+
+// Site is a generated document split into Antora pages
+type Site struct {
+	Title string
+	Pages []Page // the index page first, then the item pages in document order
+}
+
+// region is the line range of one tagged item in the source document
+type region struct {
+	kind       itemKind
+	start, end int // lines of the tag:: and end:: markers
+	mainID     string
+	title      string
+	path       string
+}
+
+// Split splits a complete generated AsciiDoc document into an index page and
+// one page per query, mutation, subscription, type, interface, union, enum,
+// input, directive and scalar. Item boundaries come from the tag regions the
+// generator writes around every item. Cross-references are rewritten to xref:
+// macros so that they resolve across pages.
+func Split(doc string) *Site {
+	lines := strings.Split(doc, "\n")
+	regions := findRegions(lines)
+	links := newLinker(lines, regions)
+
+	site := &Site{Title: documentTitle(lines)}
+	site.Pages = append(site.Pages, Page{
+		Path:    IndexPage,
+		Title:   site.Title,
+		Content: links.rewrite(indexLines(lines, regions), IndexPage),
+	})
+	for _, r := range regions {
+		site.Pages = append(site.Pages, Page{
+			Path:    r.path,
+			Title:   r.title,
+			Group:   r.kind.title,
+			Content: links.rewrite(pageLines(lines, r), r.path),
+		})
+	}
+	return site
+}
+
+// indexLines returns everything outside the item regions, with each region
+// replaced by a link to its page
+func indexLines(lines []string, regions []region) []string {
+	var index []string
+	pos := 0
+	for _, r := range regions {
+		index = append(index, lines[pos:r.start]...)
+		if last := len(index) - 1; last >= 0 && strings.TrimSpace(index[last]) != "" &&
+			!strings.HasPrefix(index[last], "* xref:") {
+			index = append(index, "")
+		}
+		index = append(index, fmt.Sprintf("* xref:%s[%s]", r.path, r.title))
+		pos = r.end + 1
+	}
+	return append(index, lines[pos:]...)
+}
+
+// pageLines returns the content of an item page: the item's section title
+// becomes the page title and its anchor is dropped, since links to the item
+// now point at the page itself
+func pageLines(lines []string, r region) []string {
+	page := []string{"= " + r.title, ""}
+	skippedAnchor, skippedTitle := false, false
+	for _, line := range lines[r.start : r.end+1] {
+		if id, ok := asciidoc.AnchorID(strings.TrimSpace(line)); ok && id == r.mainID && !skippedAnchor {
+			skippedAnchor = true
+			continue
+		}
+		if _, title, ok := asciidoc.SectionTitle(line); ok && title == r.title && !skippedTitle {
+			skippedTitle = true
+			continue
+		}
+		page = append(page, line)
+	}
+	return page
+}
+
+// ItemGroup reports whether a trimmed line is the opening tag of a top-level
+// item, such as "// tag::query-users[]", and returns the navigation group
+// title of the item's kind, e.g. "Queries"
+func ItemGroup(trimmed string) (string, bool) {
+	m := reItemTag.FindStringSubmatch(trimmed)
+	if m == nil {
+		return "", false
+	}
+	kind, ok := itemKinds[m[1]]
+	if !ok {
+		return "", false
+	}
+	return kind.title, true
+}
+
+// findRegions returns the top-level item regions in document order
+func findRegions(lines []string) []region {
+	var regions []region
+	for i := 0; i < len(lines); i++ {
+		m := reItemTag.FindStringSubmatch(strings.TrimSpace(lines[i]))
+		if m == nil {
+			continue
+		}
+		kind, ok := itemKinds[m[1]]
+		if !ok {
+			continue
+		}
+
+		endTag := fmt.Sprintf("// end::%s-%s[]", m[1], m[2])
+		end := -1
+		for j := i + 1; j < len(lines); j++ {
+			if strings.TrimSpace(lines[j]) == endTag {
+				end = j
+				break
+			}
+		}
+		if end < 0 {
+			continue
+		}
+
+		r := region{kind: kind, start: i, end: end, title: m[2]}
+		for _, line := range lines[i+1 : end] {
+			if id, ok := asciidoc.AnchorID(strings.TrimSpace(line)); ok {
+				r.mainID = id
+				break
+			}
+		}
+		for _, line := range lines[i+1 : end] {
+			if _, title, ok := asciidoc.SectionTitle(line); ok {
+				r.title = title
+				break
+			}
+		}
+		r.path = path.Join(kind.dir, m[2]+".adoc")
+
+		regions = append(regions, r)
+		i = end
+	}
+	return regions
+}
+
+// documentTitle returns the level-0 title of the document
+func documentTitle(lines []string) string {
+	for _, line := range lines {
+		if level, title, ok := asciidoc.SectionTitle(line); ok && level == 1 {
+			return title
+		}
+	}
+	return "GraphQL Documentation"
+}
+
+// linker rewrites cross-references for the page they appear on
+type linker struct {
+	targets  map[string]string // xref target (id or title) -> id
+	idPages  map[string]string // id -> page path
+	idTitles map[string]string // id -> section title
+	mainIDs  map[string]bool   // ids that identify a whole page
+}
+
+// newLinker maps every anchor in the document to the page it ends up on
+func newLinker(lines []string, regions []region) *linker {
+	l := &linker{
+		targets:  asciidoc.XrefTargets(lines, nil),
+		idPages:  make(map[string]string),
+		idTitles: make(map[string]string),
+		mainIDs:  make(map[string]bool),
+	}
+	for title, id := range l.targets {
+		if title != id {
+			l.idTitles[id] = title
+		}
+	}
+	for _, r := range regions {
+		l.mainIDs[r.mainID] = true
+	}
+
+	next := 0
+	for i, line := range lines {
+		for next < len(regions) && i > regions[next].end {
+			next++
+		}
+		id, ok := asciidoc.AnchorID(strings.TrimSpace(line))
+		if !ok {
+			continue
+		}
+		if next < len(regions) && i > regions[next].start {
+			l.idPages[id] = regions[next].path
+		} else {
+			l.idPages[id] = IndexPage
+		}
+	}
+	return l
+}
+
+// rewrite joins the page lines, turning <<target,label>> references to other
+// pages into xref:page.adoc#id[label]. References to anchors within the page
+// and to unknown targets are left as they are. Listing blocks are not touched.
+// The page ends with exactly one newline, like the documents the generator
+// writes, whatever the item region ended with.
+func (l *linker) rewrite(lines []string, page string) string {
+	fence := ""
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if trimmed == fence {
+				fence = ""
+			}
+		case trimmed == "----" || trimmed == "....":
+			fence = trimmed
+		default:
+			line = asciidoc.XrefPattern.ReplaceAllStringFunc(line, func(m string) string {
+				return l.xref(m, page)
+			})
+		}
+		out[i] = line
+	}
+	return strings.TrimRight(strings.Join(out, "\n"), "\n") + "\n"
+}
+
+func (l *linker) xref(m, page string) string {
+	parts := asciidoc.XrefPattern.FindStringSubmatch(m)
+	target, label := strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+
+	id, ok := l.targets[target]
+	if !ok {
+		return m
+	}
+	targetPage, ok := l.idPages[id]
+	if !ok || (targetPage == page && !l.mainIDs[id]) {
+		// Same-page anchors still exist; a page's own item anchor does not
+		return m
+	}
+
+	if label == "" {
+		label = target
+		if title, ok := l.idTitles[id]; ok && target == id {
+			label = title
+		}
+	}
+	fragment := ""
+	if !l.mainIDs[id] {
+		fragment = "#" + id
+	}
+	return fmt.Sprintf("xref:%s%s[%s]", targetPage, fragment, label)
+}
+
+// Nav returns the content of an Antora nav.adoc listing every page, grouped
+// by kind in document order
+func (s *Site) Nav() string {
+	var b strings.Builder
+	group := ""
+	for _, p := range s.Pages {
+		if p.Group == "" {
+			fmt.Fprintf(&b, "* xref:%s[%s]\n", p.Path, p.Title)
+			continue
+		}
+		if p.Group != group {
+			group = p.Group
+			fmt.Fprintf(&b, "** %s\n", group)
+		}
+		fmt.Fprintf(&b, "*** xref:%s[%s]\n", p.Path, p.Title)
+	}
+	return b.String()
+}
+
+// Write writes the pages below dir/pages and the navigation to dir/nav.adoc,
+// the layout of an Antora module directory. Existing files are overwritten;
+// pages for items no longer in the schema are not removed.
+func (s *Site) Write(dir string) error {
+	for _, p := range s.Pages {
+		file := filepath.Join(dir, "pages", filepath.FromSlash(p.Path))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return fmt.Errorf("failed to create page directory: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(p.Content), 0o644); err != nil { //nolint:gosec // documentation output
+			return fmt.Errorf("failed to write page '%s': %v", file, err)
+		}
+	}
+
+	navFile := filepath.Join(dir, "nav.adoc")
+	if err := os.WriteFile(navFile, []byte(s.Nav()), 0o644); err != nil { //nolint:gosec // documentation output
+		return fmt.Errorf("failed to write navigation '%s': %v", navFile, err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package antora
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDoc = `= API Docs
+:toc: left
+
+== Queries
+
+// tag::query-user[]
+[[query_user]]
+=== user
+
+Returns <<User,` + "`User`" + `>>, see <<query_user,this query>> and <<notes>>.
+
+[[notes]]
+==== Notes
+
+See <<notes>>.
+// end::query-user[]
+
+== Types
+
+// tag::type-User[]
+[[type_user]]
+=== User
+
+Back to <<query_user>>.
+
+[source]
+----
+<<User>>
+----
+// end::type-User[]
+`
+
+func TestSplit(t *testing.T) {
+	site := Split(testDoc)
+
+	if site.Title != "API Docs" {
+		t.Errorf("Expected title 'API Docs', got %q", site.Title)
+	}
+
+	var paths []string
+	for _, p := range site.Pages {
+		paths = append(paths, p.Path)
+	}
+	if got := strings.Join(paths, ","); got != "index.adoc,queries/user.adoc,types/User.adoc" {
+		t.Fatalf("Unexpected pages: %s", got)
+	}
+
+	index := site.Pages[0].Content
+	for _, expected := range []string{"= API Docs\n", "== Queries\n\n* xref:queries/user.adoc[user]\n", "* xref:types/User.adoc[User]"} {
+		if !strings.Contains(index, expected) {
+			t.Errorf("Index should contain %q, got:\n%s", expected, index)
+		}
+	}
+	if strings.Contains(index, "=== user") {
+		t.Errorf("Index should not contain item content, got:\n%s", index)
+	}
+}
+
+func TestSplitPageContent(t *testing.T) {
+	site := Split(testDoc)
+	query, typ := site.Pages[1], site.Pages[2]
+
+	if query.Group != "Queries" || typ.Group != "Types" {
+		t.Errorf("Unexpected groups %q and %q", query.Group, typ.Group)
+	}
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:    "item title becomes page title",
+			content: query.Content,
+			expected: []string{
+				"= user\n",
+				"// tag::query-user[]",
+				"// end::query-user[]",
+			},
+			absent: []string{"[[query_user]]", "=== user"},
+		},
+		{
+			name:     "references to other pages become xrefs",
+			content:  query.Content,
+			expected: []string{"Returns xref:types/User.adoc[`User`]"},
+		},
+		{
+			name:     "reference to the page's own item points at the page",
+			content:  query.Content,
+			expected: []string{"see xref:queries/user.adoc[this query]"},
+		},
+		{
+			name:     "references to anchors on the same page are kept",
+			content:  query.Content,
+			expected: []string{"and <<notes>>.", "[[notes]]", "See <<notes>>."},
+		},
+		{
+			name:     "references by id get the section title as label",
+			content:  typ.Content,
+			expected: []string{"Back to xref:queries/user.adoc[user]."},
+		},
+		{
+			name:     "listing blocks are not rewritten",
+			content:  typ.Content,
+			expected: []string{"----\n<<User>>\n----"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, expected := range tc.expected {
+				if !strings.Contains(tc.content, expected) {
+					t.Errorf("Expected page to contain %q, got:\n%s", expected, tc.content)
+				}
+			}
+			for _, absent := range tc.absent {
+				if strings.Contains(tc.content, absent) {
+					t.Errorf("Expected page NOT to contain %q, got:\n%s", absent, tc.content)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitFragmentXref(t *testing.T) {
+	doc := "// tag::type-A[]\n[[type_a]]\n=== A\n\n[[type_a_detail]]\n==== Detail\n// end::type-A[]\n\n" +
+		"// tag::type-B[]\n[[type_b]]\n=== B\n\nSee <<type_a_detail>>.\n// end::type-B[]\n"
+	site := Split(doc)
+
+	if !strings.Contains(site.Pages[2].Content, "See xref:types/A.adoc#type_a_detail[Detail].") {
+		t.Errorf("Expected fragment xref, got:\n%s", site.Pages[2].Content)
+	}
+}
+
+func TestSplitUnterminatedRegion(t *testing.T) {
+	site := Split("= Doc\n\n// tag::type-A[]\n=== A\n")
+	if len(site.Pages) != 1 {
+		t.Errorf("Unterminated region should stay on the index page, got %d pages", len(site.Pages))
+	}
+}
+
+func TestNav(t *testing.T) {
+	expected := "* xref:index.adoc[API Docs]\n" +
+		"** Queries\n" +
+		"*** xref:queries/user.adoc[user]\n" +
+		"** Types\n" +
+		"*** xref:types/User.adoc[User]\n"
+	if nav := Split(testDoc).Nav(); nav != expected {
+		t.Errorf("Unexpected nav:\n%s\nexpected:\n%s", nav, expected)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	site := Split(testDoc)
+	if err := site.Write(dir); err != nil {
+		t.Fatalf("Write() returned error: %v", err)
+	}
+
+	for _, file := range []string{"nav.adoc", "pages/index.adoc", "pages/queries/user.adoc", "pages/types/User.adoc"} {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+			t.Errorf("Expected %s to be written: %v", file, err)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "pages", "types", "User.adoc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != site.Pages[2].Content {
+		t.Error("Written page content does not match the site page")
+	}
+
+	for _, page := range site.Pages {
+		if !strings.HasSuffix(page.Content, "\n") || strings.HasSuffix(page.Content, "\n\n") {
+			t.Errorf("Page %s should end with a single newline, got %q", page.Path, page.Content)
+		}
+	}
+}
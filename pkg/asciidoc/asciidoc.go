@@ -0,0 +1,107 @@
+package asciidoc
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	reSectionTitle = regexp.MustCompile(`^(={1,6})\s+(.+?)\s*$`)
+	reAnchorLine   = regexp.MustCompile(`^\[\[([\w:.-]+)(?:,[^\]]*)?\]\]$|^\[#([\w:-]+)[^\]]*\]$`)
+	reBlockAttr    = regexp.MustCompile(`^\[([^\[\]].*)\]$`)
+
+	// XrefPattern matches an internal cross-reference, <<target>> or
+	// <<target,label>>. Group 1 is the target and group 2 the optional label.
+	XrefPattern = regexp.MustCompile(`<<([^,>]+?)(?:,\s*([^>]+?))?>>`)
+)
+
+// IsComment reports whether a trimmed line is an AsciiDoc line comment,
+// which includes the tag::/end:: region markers
+func IsComment(trimmed string) bool {
+	return strings.HasPrefix(trimmed, "//") && !strings.HasPrefix(trimmed, "////")
+}
+
+// AnchorID returns the id declared by a block anchor line ([[id]] or [#id])
+func AnchorID(trimmed string) (string, bool) {
+	m := reAnchorLine.FindStringSubmatch(trimmed)
+	if m == nil {
+		return "", false
+	}
+	if m[1] != "" {
+		return m[1], true
+	}
+	return m[2], true
+}
+
+// IsBlockAttributeLine reports whether a trimmed line is a block attribute
+// list such as [source,kotlin] or [options="header"], and returns its content.
+// A line holding a cross-reference, such as the list type [<<Post,`Post`>>],
+// is text.
+func IsBlockAttributeLine(trimmed string) (string, bool) {
+	if strings.HasPrefix(trimmed, "[[") || strings.Contains(trimmed, "<<") {
+		return "", false
+	}
+	m := reBlockAttr.FindStringSubmatch(trimmed)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// SectionTitle parses a section title line such as "=== User", returning its
+// level (the number of = signs) and title
+func SectionTitle(line string) (int, string, bool) {
+	m := reSectionTitle.FindStringSubmatch(line)
+	if m == nil {
+		return 0, "", false
+	}
+	return len(m[1]), m[2], true
+}
+
+// XrefTargets maps every anchor id in a document to itself and every section
+// title to the id of its section, so that <<Name>> references by title can be
+// resolved like Asciidoctor does. When several sections share a title the
+// last one wins: type sections follow the operation sections, and the
+// generator only cross-references types by name. Sections without an
+// explicit anchor get the id returned by untitled, or are skipped when
+// untitled is nil.
+func XrefTargets(lines []string, untitled func(title string) string) map[string]string {
+	targets := make(map[string]string)
+	pending := ""
+	fence := ""
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if trimmed == fence {
+				fence = ""
+			}
+			continue
+		}
+
+		if trimmed == "----" || trimmed == "...." {
+			fence = trimmed
+			pending = ""
+			continue
+		}
+		if id, ok := AnchorID(trimmed); ok {
+			pending = id
+			targets[id] = id
+			continue
+		}
+		if _, ok := IsBlockAttributeLine(trimmed); ok || trimmed == "" || IsComment(trimmed) {
+			// Anchors may be separated from their section by these lines
+			continue
+		}
+		if _, title, ok := SectionTitle(line); ok {
+			id := pending
+			if id == "" && untitled != nil {
+				id = untitled(title)
+			}
+			if id != "" {
+				targets[title] = id
+			}
+		}
+		pending = ""
+	}
+	return targets
+}
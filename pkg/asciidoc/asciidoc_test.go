@@ -0,0 +1,84 @@
+package asciidoc
+
+import "testing"
+
+func TestAnchorID(t *testing.T) {
+	testCases := []struct {
+		input string
+		id    string
+		ok    bool
+	}{
+		{input: "[[type_user]]", id: "type_user", ok: true},
+		{input: "[[type_user,User]]", id: "type_user", ok: true},
+		{input: "[#scalar-Date]", id: "scalar-Date", ok: true},
+		{input: "[#scalar-Date.role]", id: "scalar-Date", ok: true},
+		{input: "[source,kotlin]", ok: false},
+		{input: "text [[inline]]", ok: false},
+	}
+
+	for _, tc := range testCases {
+		id, ok := AnchorID(tc.input)
+		if id != tc.id || ok != tc.ok {
+			t.Errorf("AnchorID(%q) = %q, %v; want %q, %v", tc.input, id, ok, tc.id, tc.ok)
+		}
+	}
+}
+
+func TestIsBlockAttributeLine(t *testing.T) {
+	for input, expected := range map[string]bool{
+		"[source,kotlin]":        true,
+		`[options="header"]`:     true,
+		"[[type_user]]":          false,
+		"[<<Post,`Post`>>]":      false,
+		"[<<Post,`Post`>>!]!":    false,
+		"plain text [not attrs]": false,
+	} {
+		if _, ok := IsBlockAttributeLine(input); ok != expected {
+			t.Errorf("IsBlockAttributeLine(%q) = %v, want %v", input, ok, expected)
+		}
+	}
+}
+
+func TestSectionTitle(t *testing.T) {
+	level, title, ok := SectionTitle("=== User  ")
+	if !ok || level != 3 || title != "User" {
+		t.Errorf("SectionTitle() = %d, %q, %v", level, title, ok)
+	}
+	if _, _, ok := SectionTitle("====="); ok {
+		t.Error("A delimiter line is not a section title")
+	}
+}
+
+func TestXrefTargets(t *testing.T) {
+	lines := []string{
+		"[[query_user]]",
+		"=== User",
+		"",
+		"[[type_user]]",
+		"// tag::x[]",
+		"=== User",
+		"----",
+		"[[in_listing]]",
+		"----",
+		"=== Untitled Section",
+	}
+
+	targets := XrefTargets(lines, nil)
+	if targets["User"] != "type_user" {
+		t.Errorf("Last section with a title should win, got %q", targets["User"])
+	}
+	if targets["query_user"] != "query_user" {
+		t.Error("Anchor ids should map to themselves")
+	}
+	if _, ok := targets["in_listing"]; ok {
+		t.Error("Anchors inside listing blocks should be ignored")
+	}
+	if _, ok := targets["Untitled Section"]; ok {
+		t.Error("Sections without an anchor should be skipped when untitled is nil")
+	}
+
+	targets = XrefTargets(lines, func(title string) string { return "auto" })
+	if targets["Untitled Section"] != "auto" {
+		t.Errorf("Expected generated id for untitled section, got %q", targets["Untitled Section"])
+	}
+}
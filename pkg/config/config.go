@@ -3,9 +3,16 @@ package config
 import (
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
 )
 
 var (
@@ -13,31 +20,100 @@ var (
 	BuildTime = "unknown"
 )
 
-// Config holds all configuration options for the application
+// Config holds all configuration options for the application. The yaml tags
+// name the matching keys of the project configuration file, which reuse the
+// long flag names.
 type Config struct {
-	SchemaFile           string
-	SchemaPattern        string
-	OutputFile           string
-	ExcludeInternal      bool // Deprecated: use IncludeInternal instead
-	IncludeInternal      bool
-	IncludeDeprecated    bool
-	IncludePreview       bool
-	IncludeLegacy        bool
-	IncludeZeroVersion   bool
-	IncludeMutations     bool
-	IncludeQueries       bool
-	IncludeSubscriptions bool
-	IncludeDirectives    bool
-	IncludeTypes         bool
-	IncludeEnums         bool
-	IncludeInputs        bool
-	IncludeScalars       bool
-	ShowVersion          bool
-	ShowHelp             bool
-	Verbose              bool
-	Catalogue            bool
-	SubTitle             string
-	IncludeChangelog     bool
+	SchemaFile           string            `yaml:"schema"`
+	SchemaPattern        string            `yaml:"pattern"`
+	IntrospectionFile    string            `yaml:"introspection"`
+	OutputFile           string            `yaml:"output"`
+	ExcludeInternal      bool              `yaml:"exclude-internal"` // Deprecated: use IncludeInternal instead
+	IncludeInternal      bool              `yaml:"inc-internal"`
+	IncludeDeprecated    bool              `yaml:"inc-deprecated"`
+	IncludePreview       bool              `yaml:"inc-preview"`
+	IncludeLegacy        bool              `yaml:"inc-legacy"`
+	IncludeZeroVersion   bool              `yaml:"inc-zero"`
+	IncludeMutations     bool              `yaml:"mutations"`
+	IncludeQueries       bool              `yaml:"queries"`
+	IncludeSubscriptions bool              `yaml:"subscriptions"`
+	IncludeDirectives    bool              `yaml:"directives"`
+	IncludeTypes         bool              `yaml:"types"`
+	IncludeInterfaces    bool              `yaml:"interfaces"`
+	IncludeUnions        bool              `yaml:"unions"`
+	IncludeEnums         bool              `yaml:"enums"`
+	IncludeInputs        bool              `yaml:"inputs"`
+	IncludeScalars       bool              `yaml:"scalars"`
+	ShowVersion          bool              `yaml:"-"`
+	ShowHelp             bool              `yaml:"-"`
+	Verbose              bool              `yaml:"verbose"`
+	Catalogue            bool              `yaml:"catalogue"`
+	SubTitle             string            `yaml:"sub-title"`
+	IncludeChangelog     bool              `yaml:"inc-changelog"`
+	TemplatesDir         string            `yaml:"templates"`
+	Title                string            `yaml:"title"`
+	HeaderAttributes     Attributes        `yaml:"attributes"`
+	Author               string            `yaml:"author"`
+	DocVersion           string            `yaml:"doc-version"`
+	AttributesFile       string            `yaml:"attributes-file"` // Included below the attributes
+	PreambleFile         string            `yaml:"preamble"`
+	BannerFile           string            `yaml:"banner"` // Replaces the generated-file notice
+	NoBanner             bool              `yaml:"no-banner"`
+	Filters              FilterRules       `yaml:"filters"`
+	Format               string            `yaml:"format"`
+	SplitDir             string            `yaml:"split-dir"`
+	Strict               bool              `yaml:"strict"`
+	Examples             bool              `yaml:"examples"`
+	ExampleDepth         int               `yaml:"example-depth"`
+	ScalarSamples        map[string]string `yaml:"scalar-samples"` // Sample values of custom scalars in examples
+	SignatureStyle       string            `yaml:"signature-style"`
+	Diagram              string            `yaml:"diagram"`
+	DiagramRoot          string            `yaml:"diagram-root"`
+	DiagramFile          string            `yaml:"diagram-file"`
+	GroupBy              string            `yaml:"group-by"`
+	Watch                bool              `yaml:"watch"`
+	ServeAddr            string            `yaml:"addr"` // Listen address of the serve command
+	Reproducible         bool              `yaml:"reproducible"`
+	RevDate              string            `yaml:"revdate"` // Fixed revision date, see ParseRevDate
+
+	// ConfigFile is the project configuration file that was loaded, if any
+	ConfigFile string `yaml:"-"`
+	// fileErrors holds problems found while loading ConfigFile; they are
+	// reported by Validate so that --help and --version keep working.
+	fileErrors []string
+}
+
+// DefaultExampleDepth is the number of nested selection sets expanded in
+// example operations
+const DefaultExampleDepth = 2
+
+// Signature styles for the operation signatures, see --signature-style
+const (
+	SignatureKotlin     = "kotlin"
+	SignatureGraphQL    = "graphql"
+	SignatureTypeScript = "typescript"
+)
+
+// SignatureStyles lists the supported signature styles
+var SignatureStyles = []string{SignatureGraphQL, SignatureKotlin, SignatureTypeScript}
+
+// Ways of grouping the documentation into top-level sections, see --group-by
+const (
+	GroupByFile      = "file"
+	GroupByDirectory = "directory"
+	GroupByTag       = "tag"
+)
+
+// GroupByModes lists the supported --group-by values
+var GroupByModes = []string{GroupByFile, GroupByDirectory, GroupByTag}
+
+// FilterRules restricts which queries, mutations and subscriptions are
+// documented by name. Patterns use path.Match syntax, e.g. "debug*".
+type FilterRules struct {
+	// Include, when non-empty, documents only operations matching a pattern
+	Include []string `yaml:"include"`
+	// Exclude drops operations matching a pattern, even if also included
+	Exclude []string `yaml:"exclude"`
 }
 
 // NewConfig creates a new Config with default values
@@ -48,72 +124,193 @@ func NewConfig() *Config {
 		IncludeSubscriptions: false,
 		IncludeDirectives:    true,
 		IncludeTypes:         true,
+		IncludeInterfaces:    true,
+		IncludeUnions:        true,
 		IncludeEnums:         true,
 		IncludeInputs:        true,
 		IncludeScalars:       true,
+		Format:               "asciidoc",
+		ExampleDepth:         DefaultExampleDepth,
+		SignatureStyle:       SignatureKotlin,
 	}
 }
 
 // ParseFlags parses command-line flags and returns a Config
 func ParseFlags() *Config {
 	config := NewConfig()
+	configFile := config.defineFlags(flag.CommandLine)
+
+	// Custom usage function
+	flag.Usage = PrintUsage
+
+	flag.Parse()
+
+	// Settings from the configuration file sit between defaults and flags
+	config.applyConfigFile(flag.CommandLine, *configFile)
+
+	return config
+}
+
+// ParseArgs parses the generation flags in args, as ParseFlags does for the
+// command line, but reports a bad flag instead of exiting. Watch mode uses it
+// to read the configuration file again when it changes.
+func ParseArgs(args []string) (*Config, error) {
+	config := NewConfig()
+
+	fs := flag.NewFlagSet("graphqls-to-asciidoc", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := config.defineFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	config.applyConfigFile(fs, *configFile)
+	return config, nil
+}
+
+// defineFlags defines the generation flags on fs, bound to the fields of c,
+// and returns the destination of the --config flag
+func (c *Config) defineFlags(fs *flag.FlagSet) *string {
+	configFile := new(string)
 
 	// Core flags with short aliases
-	flag.StringVar(&config.SchemaFile, "schema", "", "Path to the GraphQL schema file")
-	flag.StringVar(&config.SchemaFile, "s", "", "Path to the GraphQL schema file (shorthand)")
+	fs.StringVar(&c.SchemaFile, "schema", "", "Path to the GraphQL schema file")
+	fs.StringVar(&c.SchemaFile, "s", "", "Path to the GraphQL schema file (shorthand)")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.SchemaPattern, "pattern", "", "Pattern to match multiple GraphQL schema files (e.g., 'schemas/**/*.graphqls')")
+	fs.StringVar(&c.SchemaPattern, "p", "", "Pattern to match multiple GraphQL schema files (shorthand)")
 	//nolint:lll // flag usage text
-	flag.StringVar(&config.SchemaPattern, "pattern", "", "Pattern to match multiple GraphQL schema files (e.g., 'schemas/**/*.graphqls')")
-	flag.StringVar(&config.SchemaPattern, "p", "", "Pattern to match multiple GraphQL schema files (shorthand)")
-	flag.StringVar(&config.OutputFile, "output", "", "Output file path (default: stdout)")
-	flag.StringVar(&config.OutputFile, "o", "", "Output file path (shorthand)")
+	fs.StringVar(&c.IntrospectionFile, "introspection", "", "Path to an introspection result (__schema JSON) to document instead of SDL")
+	fs.StringVar(&c.OutputFile, "output", "", "Output file path (default: stdout)")
+	fs.StringVar(&c.OutputFile, "o", "", "Output file path (shorthand)")
 
 	// Control flags
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.ExcludeInternal, "exclude-internal", false, "Exclude internal queries from output (deprecated: use --inc-internal)")
-	flag.BoolVar(&config.ExcludeInternal, "x", false, "Exclude internal queries from output (deprecated, shorthand)")
+	fs.BoolVar(&c.ExcludeInternal, "exclude-internal", false, "Exclude internal queries from output (deprecated: use --inc-internal)")
+	fs.BoolVar(&c.ExcludeInternal, "x", false, "Exclude internal queries from output (deprecated, shorthand)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludeInternal, "inc-internal", false, "Include internal queries/mutations (those starting with 'internal' or marked INTERNAL)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludeDeprecated, "inc-deprecated", false, "Include deprecated queries/mutations (those with @deprecated directive or marked deprecated)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludePreview, "inc-preview", false, "Include preview queries/mutations (those marked as PREVIEW or preview)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludeLegacy, "inc-legacy", false, "Include legacy queries/mutations (those marked as LEGACY or legacy)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludeZeroVersion, "inc-zero", false, "Include items with version 0.0.0 or 0.0.0.0 (by default, items marked with @version: 0.0.0 or @version: 0.0.0.0 are excluded)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.IncludeChangelog, "inc-changelog", false, "Include changelog information in catalogue descriptions (version annotations)")
+	fs.BoolVar(&c.ShowVersion, "version", false, "Show program version and build information")
+	fs.BoolVar(&c.ShowVersion, "v", false, "Show program version and build information (shorthand)")
+	fs.BoolVar(&c.ShowHelp, "help", false, "Show detailed help information")
+	fs.BoolVar(&c.ShowHelp, "h", false, "Show detailed help information (shorthand)")
+	fs.BoolVar(&c.Verbose, "verbose", false, "Enable verbose logging with metrics")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.Strict, "strict", false, "Validate the schema fully and fail on unresolved types, bad implementations or directive usage")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.Catalogue, "catalogue", false, "Generate a catalogue table with query/mutation names and first sentence descriptions")
+	fs.StringVar(&c.SubTitle, "sub-title", "", "Optional subtitle for catalogue (e.g., 'Activities')")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.TemplatesDir, "templates", "", "Directory of <section>.tmpl files that replace the built-in templates")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.Format, "format", "asciidoc", "Output format: asciidoc, html or markdown")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.SplitDir, "split-dir", "", "Write one Antora page per item below DIR/pages, plus DIR/nav.adoc (HTML pages with --format html)")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&c.Examples, "examples", false, "Add an example operation with variables to every query, mutation and subscription")
+	//nolint:lll // flag usage text
+	fs.IntVar(&c.ExampleDepth, "example-depth", DefaultExampleDepth, "Number of nested selection sets expanded in example operations")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.SignatureStyle, "signature-style", SignatureKotlin, "Operation signature style: "+strings.Join(SignatureStyles, ", "))
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.Diagram, "diagram", "", "Add a type relationship diagram: mermaid or plantuml")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.DiagramRoot, "diagram-root", "", "Limit the diagram to types reachable from a root field, e.g. users or Mutation.createUser")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludeInternal, "inc-internal", false, "Include internal queries/mutations (those starting with 'internal' or marked INTERNAL)")
+	fs.StringVar(&c.DiagramFile, "diagram-file", "", "Write the diagram source to this file instead of embedding it")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludeDeprecated, "inc-deprecated", false, "Include deprecated queries/mutations (those with @deprecated directive or marked deprecated)")
+	fs.StringVar(&c.GroupBy, "group-by", "", "Give each source file, directory or @tag module its own section: "+strings.Join(GroupByModes, ", "))
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludePreview, "inc-preview", false, "Include preview queries/mutations (those marked as PREVIEW or preview)")
+	fs.BoolVar(&c.Watch, "watch", false, "Regenerate the output whenever the schema files change, until interrupted")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludeLegacy, "inc-legacy", false, "Include legacy queries/mutations (those marked as LEGACY or legacy)")
+	fs.BoolVar(&c.Reproducible, "reproducible", false, "Stamp the output with a fixed date and paths relative to the working directory, so identical input gives identical output")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludeZeroVersion, "inc-zero", false, "Include items with version 0.0.0 or 0.0.0.0 (by default, items marked with @version: 0.0.0 or @version: 0.0.0.0 are excluded)")
+	fs.StringVar(&c.RevDate, "revdate", "", "Fixed revision date as YYYY-MM-DD or RFC 3339; implies --reproducible")
+	fs.StringVar(&c.Title, "title", "", "Document title (default: GraphQL Documentation)")
+	fs.StringVar(&c.Author, "author", "", "Document author, written as the :author: attribute")
+	fs.StringVar(&c.DocVersion, "doc-version", "", "Document version, written as the :revnumber: attribute")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.IncludeChangelog, "inc-changelog", false, "Include changelog information in catalogue descriptions (version annotations)")
-	flag.BoolVar(&config.ShowVersion, "version", false, "Show program version and build information")
-	flag.BoolVar(&config.ShowVersion, "v", false, "Show program version and build information (shorthand)")
-	flag.BoolVar(&config.ShowHelp, "help", false, "Show detailed help information")
-	flag.BoolVar(&config.ShowHelp, "h", false, "Show detailed help information (shorthand)")
-	flag.BoolVar(&config.Verbose, "verbose", false, "Enable verbose logging with metrics")
+	fs.Var(&c.HeaderAttributes, "attribute", "Header attribute as NAME=VALUE, NAME or NAME! to unset; replaces a built-in attribute of the same name (repeatable)")
+	fs.Var(&c.HeaderAttributes, "a", "Header attribute as NAME=VALUE (shorthand)")
 	//nolint:lll // flag usage text
-	flag.BoolVar(&config.Catalogue, "catalogue", false, "Generate a catalogue table with query/mutation names and first sentence descriptions")
-	flag.StringVar(&config.SubTitle, "sub-title", "", "Optional subtitle for catalogue (e.g., 'Activities')")
+	fs.StringVar(&c.AttributesFile, "attributes-file", "", "AsciiDoc file to include below the header attributes, e.g. _attributes.adoc")
+	fs.StringVar(&c.PreambleFile, "preamble", "", "AsciiDoc file inserted after the header and banner")
+	//nolint:lll // flag usage text
+	fs.StringVar(&c.BannerFile, "banner", "", "AsciiDoc file replacing the notice that the document is generated")
+	fs.BoolVar(&c.NoBanner, "no-banner", false, "Leave out the notice that the document is generated")
+	//nolint:lll // flag usage text
+	fs.StringVar(configFile, "config", "", "Path to a YAML or TOML configuration file (default: "+DefaultConfigFile+" or .toml if present)")
 
 	// Section inclusion flags
-	flag.BoolVar(&config.IncludeQueries, "queries", true, "Include queries in the output")
-	flag.BoolVar(&config.IncludeQueries, "q", true, "Include queries in the output (shorthand)")
-	flag.BoolVar(&config.IncludeMutations, "mutations", true, "Include mutations in the output")
-	flag.BoolVar(&config.IncludeMutations, "m", true, "Include mutations in the output (shorthand)")
-	flag.BoolVar(&config.IncludeSubscriptions, "subscriptions", false, "Include subscriptions in the output")
-	flag.BoolVar(&config.IncludeTypes, "types", true, "Include types in the output")
-	flag.BoolVar(&config.IncludeTypes, "t", true, "Include types in the output (shorthand)")
-	flag.BoolVar(&config.IncludeEnums, "enums", true, "Include enums in the output")
-	flag.BoolVar(&config.IncludeEnums, "e", true, "Include enums in the output (shorthand)")
-	flag.BoolVar(&config.IncludeInputs, "inputs", true, "Include inputs in the output")
-	flag.BoolVar(&config.IncludeInputs, "i", true, "Include inputs in the output (shorthand)")
-	flag.BoolVar(&config.IncludeDirectives, "directives", true, "Include directives in the output")
-	flag.BoolVar(&config.IncludeDirectives, "d", true, "Include directives in the output (shorthand)")
-	flag.BoolVar(&config.IncludeScalars, "scalars", true, "Include scalars in the output")
+	fs.BoolVar(&c.IncludeQueries, "queries", true, "Include queries in the output")
+	fs.BoolVar(&c.IncludeQueries, "q", true, "Include queries in the output (shorthand)")
+	fs.BoolVar(&c.IncludeMutations, "mutations", true, "Include mutations in the output")
+	fs.BoolVar(&c.IncludeMutations, "m", true, "Include mutations in the output (shorthand)")
+	fs.BoolVar(&c.IncludeSubscriptions, "subscriptions", false, "Include subscriptions in the output")
+	fs.BoolVar(&c.IncludeTypes, "types", true, "Include types in the output")
+	fs.BoolVar(&c.IncludeTypes, "t", true, "Include types in the output (shorthand)")
+	fs.BoolVar(&c.IncludeInterfaces, "interfaces", true, "Include interfaces in the output")
+	fs.BoolVar(&c.IncludeUnions, "unions", true, "Include unions in the output")
+	fs.BoolVar(&c.IncludeEnums, "enums", true, "Include enums in the output")
+	fs.BoolVar(&c.IncludeEnums, "e", true, "Include enums in the output (shorthand)")
+	fs.BoolVar(&c.IncludeInputs, "inputs", true, "Include inputs in the output")
+	fs.BoolVar(&c.IncludeInputs, "i", true, "Include inputs in the output (shorthand)")
+	fs.BoolVar(&c.IncludeDirectives, "directives", true, "Include directives in the output")
+	fs.BoolVar(&c.IncludeDirectives, "d", true, "Include directives in the output (shorthand)")
+	fs.BoolVar(&c.IncludeScalars, "scalars", true, "Include scalars in the output")
+
+	return configFile
+}
 
-	// Custom usage function
-	flag.Usage = PrintUsage
+// SchemaSource returns the schema file, pattern or introspection file the
+// documentation is generated from
+func (c *Config) SchemaSource() string {
+	switch {
+	case c.IntrospectionFile != "":
+		return c.IntrospectionFile
+	}
+	return schemaSource(c.SchemaFile, c.SchemaPattern)
+}
 
-	flag.Parse()
+// schemaSource names a schema file or pattern in messages: "<stdin>" for
+// standard input, and an archive together with the pattern applied inside it
+func schemaSource(file, pattern string) string {
+	if file == schemafile.Stdin {
+		file = schemafile.StdinName
+	}
+	switch {
+	case file != "" && pattern != "":
+		return file + " (" + pattern + ")"
+	case pattern != "":
+		return pattern
+	}
+	return file
+}
 
-	return config
+// countSchemaSources returns how many of the schema file, pattern and
+// introspection file are set. A pattern applied inside an archive, or to an
+// archive piped to stdin, counts with its file as one source.
+func countSchemaSources(file, pattern, introspection string) int {
+	sources := 0
+	for _, source := range []string{file, pattern, introspection} {
+		if source != "" {
+			sources++
+		}
+	}
+	if pattern != "" && (file == schemafile.Stdin || schemafile.IsArchive(file)) {
+		sources--
+	}
+	return sources
 }
 
 // HandleVersion handles the version flag display
@@ -139,22 +336,135 @@ func (c *Config) HandleHelp() bool {
 
 // Validate validates the configuration
 func (c *Config) Validate() error {
-	// Require either schema file or pattern, but not both
-	if c.SchemaFile == "" && c.SchemaPattern == "" {
-		return fmt.Errorf("either -schema or -pattern flag is required")
+	// Report configuration file problems first, all at once
+	if len(c.fileErrors) > 0 {
+		return fmt.Errorf("invalid configuration file:\n  %s", strings.Join(c.fileErrors, "\n  "))
 	}
 
-	if c.SchemaFile != "" && c.SchemaPattern != "" {
-		return fmt.Errorf("-schema and -pattern flags are mutually exclusive")
+	// Require exactly one schema source
+	sources := countSchemaSources(c.SchemaFile, c.SchemaPattern, c.IntrospectionFile)
+	if sources == 0 {
+		return fmt.Errorf("either -schema, -pattern or -introspection flag is required")
+	}
+	if sources > 1 {
+		if c.IntrospectionFile != "" {
+			return fmt.Errorf("-introspection cannot be combined with -schema or -pattern")
+		}
+		return fmt.Errorf("-schema and -pattern flags are mutually exclusive, unless the schema is an archive")
 	}
 
 	// Check if schema file exists (single file mode)
-	if c.SchemaFile != "" {
+	if c.SchemaFile != "" && c.SchemaFile != schemafile.Stdin {
 		if _, err := os.Stat(c.SchemaFile); os.IsNotExist(err) {
 			return fmt.Errorf("schema file '%s' does not exist", c.SchemaFile)
 		}
 	}
 
+	// Strict validation reports positions in the SDL sources
+	if c.Strict && c.IntrospectionFile != "" {
+		return fmt.Errorf("--strict requires an SDL schema (-schema or -pattern)")
+	}
+
+	// Check if introspection file exists
+	if c.IntrospectionFile != "" {
+		if _, err := os.Stat(c.IntrospectionFile); os.IsNotExist(err) {
+			return fmt.Errorf("introspection file '%s' does not exist", c.IntrospectionFile)
+		}
+	}
+
+	// Check that the template directory exists if specified
+	if c.TemplatesDir != "" {
+		info, err := os.Stat(c.TemplatesDir)
+		if err != nil || !info.IsDir() {
+			return fmt.Errorf("template directory '%s' does not exist", c.TemplatesDir)
+		}
+	}
+
+	// Split output replaces the single output document
+	if c.SplitDir != "" {
+		if c.OutputFile != "" {
+			return fmt.Errorf("--split-dir and --output are mutually exclusive")
+		}
+		if c.Catalogue {
+			return fmt.Errorf("--split-dir cannot be used with --catalogue")
+		}
+	}
+
+	if !isSignatureStyle(c.SignatureStyle) {
+		return fmt.Errorf("unsupported signature style '%s' (supported: %s)",
+			c.SignatureStyle, strings.Join(SignatureStyles, ", "))
+	}
+
+	if c.Diagram == "" && (c.DiagramRoot != "" || c.DiagramFile != "") {
+		return fmt.Errorf("--diagram-root and --diagram-file require --diagram")
+	}
+
+	if c.GroupBy != "" {
+		if !isGroupByMode(c.GroupBy) {
+			return fmt.Errorf("unsupported group-by '%s' (supported: %s)", c.GroupBy, strings.Join(GroupByModes, ", "))
+		}
+		if c.Catalogue {
+			return fmt.Errorf("--group-by cannot be used with --catalogue")
+		}
+		if c.SplitDir != "" {
+			return fmt.Errorf("--group-by cannot be used with --split-dir")
+		}
+		if c.IntrospectionFile != "" && c.GroupBy != GroupByTag {
+			return fmt.Errorf("--group-by %s requires an SDL schema (-schema or -pattern)", c.GroupBy)
+		}
+	}
+
+	if c.Examples && c.ExampleDepth < 1 {
+		return fmt.Errorf("--example-depth must be at least 1, got %d", c.ExampleDepth)
+	}
+
+	// Check that filter patterns are well formed
+	for _, pattern := range append(append([]string{}, c.Filters.Include...), c.Filters.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid filter pattern '%s': %v", pattern, err)
+		}
+	}
+
+	// Header files are read when the document is generated
+	for _, file := range []string{c.PreambleFile, c.BannerFile} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("header file '%s' does not exist", file)
+		}
+	}
+	if c.BannerFile != "" && c.NoBanner {
+		return fmt.Errorf("--banner and --no-banner are mutually exclusive")
+	}
+	for _, attr := range c.HeaderAttributes {
+		if attr.Name == "" || strings.ContainsAny(attr.Name, " :\n") {
+			return fmt.Errorf("invalid header attribute name '%s'", attr.Name)
+		}
+	}
+
+	if err := validateRevisionDate(c.RevDate); err != nil {
+		return err
+	}
+
+	// Watch mode rewrites its output on every change, which needs a file
+	if c.Watch {
+		if c.IntrospectionFile != "" {
+			return fmt.Errorf("--watch requires an SDL schema (-schema or -pattern)")
+		}
+		if c.SchemaFile == schemafile.Stdin {
+			return fmt.Errorf("--watch cannot read the schema from stdin")
+		}
+		if c.OutputFile == "" && c.SplitDir == "" {
+			return fmt.Errorf("--watch requires --output or --split-dir")
+		}
+	}
+
+	// The preview server re-reads the schema on every change
+	if c.ServeAddr != "" && c.SchemaFile == schemafile.Stdin {
+		return fmt.Errorf("serve cannot read the schema from stdin")
+	}
+
 	// Validate output file directory if specified
 	if c.OutputFile != "" {
 		dir := c.OutputFile[:len(c.OutputFile)-len(filepath.Base(c.OutputFile))]
@@ -168,19 +478,186 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// SourceDateEpochEnv names the environment variable of the reproducible
+// builds convention: seconds since the Unix epoch to use as the build date
+const SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+
+// RevisionDateLayout formats the :revdate: attribute of generated documents
+const RevisionDateLayout = "Mon, 02 Jan 2006 15:04:05 MST"
+
+// RevisionOptions is implemented by the configurations of commands whose
+// documents carry a revision date
+type RevisionOptions interface {
+	IsReproducible() bool
+	FixedRevisionTime() (time.Time, bool, error)
+}
+
+// RevisionDate returns the :revdate: of a document: the current time or, in
+// reproducible mode, a fixed date in UTC. The fixed date comes from --revdate
+// or SOURCE_DATE_EPOCH, else from newest, which returns the modification
+// time of the newest input file.
+func RevisionDate(opts RevisionOptions, newest func() time.Time) string {
+	if !opts.IsReproducible() {
+		return time.Now().Format(RevisionDateLayout)
+	}
+	if t, ok, err := opts.FixedRevisionTime(); ok && err == nil {
+		return t.UTC().Format(RevisionDateLayout)
+	}
+	return newest().UTC().Format(RevisionDateLayout)
+}
+
+// NewestModTime returns the latest modification time of files, or the Unix
+// epoch when none can be read. Sub-second precision is dropped, as it is not
+// shown and varies between file systems.
+func NewestModTime(files []string) time.Time {
+	newest := time.Unix(0, 0)
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(newest) {
+			newest = info.ModTime()
+		}
+	}
+	return newest.Truncate(time.Second)
+}
+
+// IsReproducible reports whether the output must be byte-identical for
+// identical input: with --reproducible, a fixed --revdate or when
+// SOURCE_DATE_EPOCH is set
+func (c *Config) IsReproducible() bool {
+	return isReproducible(c.Reproducible, c.RevDate)
+}
+
+// FixedRevisionTime returns the revision date given by --revdate or, failing
+// that, SOURCE_DATE_EPOCH. ok is false when neither is set.
+func (c *Config) FixedRevisionTime() (t time.Time, ok bool, err error) {
+	return fixedRevisionTime(c.RevDate)
+}
+
+// RelativePath returns an absolute path relative to the working directory,
+// with forward slashes, as reproducible output names paths. Other values are
+// returned unchanged.
+func RelativePath(p string) string {
+	if !filepath.IsAbs(p) {
+		return p
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return p
+	}
+	rel, err := filepath.Rel(wd, p)
+	if err != nil {
+		return p
+	}
+	return filepath.ToSlash(rel)
+}
+
+func isReproducible(reproducible bool, revDate string) bool {
+	return reproducible || revDate != "" || os.Getenv(SourceDateEpochEnv) != ""
+}
+
+func fixedRevisionTime(revDate string) (t time.Time, ok bool, err error) {
+	if revDate != "" {
+		t, err = ParseRevDate(revDate)
+		return t, err == nil, err
+	}
+	return sourceDateEpoch()
+}
+
+// validateRevisionDate checks that a fixed revision date parses, whether
+// given as a flag or through SOURCE_DATE_EPOCH
+func validateRevisionDate(revDate string) error {
+	if revDate != "" {
+		if _, err := ParseRevDate(revDate); err != nil {
+			return err
+		}
+	}
+	_, _, err := sourceDateEpoch()
+	return err
+}
+
+// ParseRevDate parses a revision date given as YYYY-MM-DD or RFC 3339
+func ParseRevDate(s string) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid revision date '%s' (expected YYYY-MM-DD or RFC 3339)", s)
+}
+
+// sourceDateEpoch returns the time given by SOURCE_DATE_EPOCH, if set
+func sourceDateEpoch() (time.Time, bool, error) {
+	value := os.Getenv(SourceDateEpochEnv)
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s '%s' (expected seconds since the Unix epoch)",
+			SourceDateEpochEnv, value)
+	}
+	return time.Unix(seconds, 0).UTC(), true, nil
+}
+
+// isSignatureStyle reports whether style names a supported signature style.
+// An empty style selects the default.
+func isSignatureStyle(style string) bool {
+	if style == "" {
+		return true
+	}
+	for _, s := range SignatureStyles {
+		if strings.EqualFold(style, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGroupByMode reports whether mode names a supported --group-by value
+func isGroupByMode(mode string) bool {
+	for _, m := range GroupByModes {
+		if mode == m {
+			return true
+		}
+	}
+	return false
+}
+
 // PrintUsage prints detailed usage information
 func PrintUsage() {
 	fmt.Printf(`graphqls-to-asciidoc - Convert GraphQL schema files to comprehensive AsciiDoc documentation
 
 USAGE:
     graphqls-to-asciidoc [OPTIONS]
+    graphqls-to-asciidoc diff [OPTIONS] OLD NEW
+    graphqls-to-asciidoc serve [OPTIONS]
+    graphqls-to-asciidoc coverage [OPTIONS]
+
+COMMANDS:
+    diff                    Document the changes between two schema versions, classified as
+                            breaking or non-breaking (see: graphqls-to-asciidoc diff --help)
+    serve                   Preview the documentation as HTML on a local web server that
+                            reloads on schema changes (see: graphqls-to-asciidoc serve --help)
+    coverage                Report the share of types, fields, arguments and enum values
+                            with descriptions, failing below a threshold
+                            (see: graphqls-to-asciidoc coverage --help)
+    lint                    Check descriptions, naming, mutation prefixes, @param names and
+                            deprecation reasons (see: graphqls-to-asciidoc lint --help)
 
 REQUIRED (choose one):
-    -s, --schema PATH       Path to the GraphQL schema file
-    -p, --pattern PATTERN   Pattern to match multiple GraphQL schema files
+    -s, --schema PATH       Path to the GraphQL schema file, - for stdin, or a .zip or
+                            .tar.gz archive of schema files
+    -p, --pattern PATTERN   Pattern to match multiple GraphQL schema files; with an
+                            archive, the files inside it to use
+        --introspection PATH
+                            Introspection query result (JSON with a __schema object)
 
 OPTIONS:
     -o, --output PATH       Output file path (default: stdout)
+        --format FORMAT     Output format: asciidoc, html (self-contained, with search) or
+                            markdown (GitHub-flavoured) (default: asciidoc)
+        --split-dir DIR     Write an Antora module instead of a single document: one page per
+                            query, type, etc. below DIR/pages, plus DIR/nav.adoc. With
+                            --format html, writes one HTML page per item below DIR instead
     -h, --help              Show this help information
     -v, --version           Show program version and build information
         --inc-internal      Include internal queries/mutations (by default, items starting with
@@ -196,14 +673,65 @@ OPTIONS:
         --inc-changelog     Include changelog information in catalogue descriptions
                             (extracts version annotations like add.version: 1.0.0)
         --verbose           Enable verbose logging with processing metrics
+        --strict            Run full GraphQL schema validation first and fail, listing every
+                            unresolved type, bad interface implementation and invalid directive
+                            usage with its file and line
         --catalogue         Generate a catalogue table with query/mutation names and descriptions
         --sub-title TEXT    Optional subtitle for catalogue (e.g., 'Activities')
+        --templates DIR     Directory of <section>.tmpl files replacing the built-in templates
+                            (e.g. query.tmpl, type-section.tmpl; see docs/TEMPLATES.md)
+        --signature-style STYLE
+                            Operation signatures as graphql (SDL field definitions), kotlin
+                            or typescript (function types) (default: kotlin)
+        --examples          Add a runnable example operation, with variables, to every query,
+                            mutation and subscription
+        --example-depth N   Nested selection sets expanded in examples; fields returning
+                            objects deeper than this are left out (default: 2)
+        --diagram FORMAT    Add a class diagram of the types and their field references, as a
+                            plantuml or mermaid block (rendered by asciidoctor-diagram)
+        --diagram-root FIELD
+                            Only draw the types reachable from this root field, e.g. users
+                            or Mutation.createUser
+        --diagram-file PATH Write the diagram source to PATH instead of embedding it
+        --group-by MODE     Give each source file, directory or module its own top-level
+                            section with its operations and types, after an index of all of
+                            them. MODE is file, directory or tag; tag groups by the
+                            @tag(name: ...) directive, or a "@module: NAME" description line
+        --watch             Keep running and regenerate the output whenever the schema file, or
+                            a file matching the pattern, is changed, added or removed, and when
+                            the config file or a template changes. Errors are reported without
+                            exiting. Requires --output or --split-dir
+        --reproducible      Make the output byte-identical for identical input: the revision
+                            date comes from --revdate, SOURCE_DATE_EPOCH or the newest schema
+                            file, in UTC. The command line omits the program path, and
+                            absolute paths are made relative to the working directory
+        --revdate DATE      Fixed revision date, as YYYY-MM-DD or RFC 3339; implies --reproducible
+        --title TEXT        Document title (default: GraphQL Documentation, or GraphQL API
+                            Catalogue with --catalogue)
+        --author NAME       Document author (:author: attribute)
+        --doc-version VERSION
+                            Document version (:revnumber: attribute)
+    -a, --attribute NAME=VALUE
+                            Add a header attribute, or replace the built-in one of the same
+                            name, such as toc or pdf-page-size. NAME alone sets an attribute
+                            without a value and NAME! unsets it. Repeatable
+        --attributes-file PATH
+                            Add include::PATH[] below the header attributes
+        --preamble PATH     Insert the AsciiDoc in PATH after the header and banner
+        --banner PATH       Replace the "automatically generated" notice with the AsciiDoc
+                            in PATH
+        --no-banner         Leave out the "automatically generated" notice
+        --config PATH       YAML or TOML (.toml) configuration file (default:
+                            .graphqls-to-asciidoc.yaml or .toml in the current directory, if
+                            present); command-line flags override it
 
 SECTION CONTROL:
     -q, --queries           Include queries in the output (default: true)
     -m, --mutations         Include mutations in the output (default: true)
         --subscriptions     Include subscriptions in the output (default: false)
     -t, --types             Include types in the output (default: true)
+        --interfaces        Include interfaces in the output (default: true)
+        --unions            Include unions in the output (default: true)
     -e, --enums             Include enums in the output (default: true)
     -i, --inputs            Include inputs in the output (default: true)
     -d, --directives        Include directives in the output (default: true)
@@ -219,6 +747,12 @@ EXAMPLES:
     # Generate from pattern with specific extensions
     graphqls-to-asciidoc -p "src/graphql/*.{graphql,graphqls}" -o api-docs.adoc
 
+    # Generate documentation from a schema piped to stdin
+    cat schema.graphql | graphqls-to-asciidoc -s - -o docs.adoc
+
+    # Generate documentation from the users schemas in a registry export
+    graphqls-to-asciidoc -s export.tar.gz -p "users/**/*.graphqls" -o users.adoc
+
     # Generate only types and enums from single file
     graphqls-to-asciidoc -s schema.graphql -o types.adoc -q=false -m=false
 
@@ -234,12 +768,52 @@ EXAMPLES:
     # Generate with verbose logging and metrics
     graphqls-to-asciidoc -p "schemas/*.graphql" -o docs.adoc --verbose
 
+    # Fail the build if the schema has unresolved types or other errors
+    graphqls-to-asciidoc -p "schemas/**/*.graphqls" -o docs.adoc --strict
+
     # Generate a catalogue table of queries and mutations
     graphqls-to-asciidoc -s schema.graphql --catalogue -o catalogue.adoc
 
     # Generate a catalogue with a subtitle
     graphqls-to-asciidoc -s schema.graphql --catalogue --sub-title "Activities" -o catalogue.adoc
 
+    # Generate documentation from a saved introspection result
+    graphqls-to-asciidoc --introspection schema.json -o api-docs.adoc
+
+    # Generate GitHub-flavoured Markdown instead of AsciiDoc
+    graphqls-to-asciidoc -s schema.graphql --format markdown -o api.md
+
+    # Generate a single self-contained HTML file with search
+    graphqls-to-asciidoc -s schema.graphql --format html -o api.html
+
+    # Write Antora pages and navigation into a module directory
+    graphqls-to-asciidoc -s schema.graphql --split-dir docs/modules/api
+
+    # Show operation signatures as GraphQL SDL
+    graphqls-to-asciidoc -s schema.graphql --signature-style graphql -o api-docs.adoc
+
+    # Show an example operation for every query and mutation
+    graphqls-to-asciidoc -s schema.graphql --examples --example-depth 3 -o api-docs.adoc
+
+    # Add a Mermaid diagram of the types reachable from the users query
+    graphqls-to-asciidoc -s schema.graphql --diagram mermaid --diagram-root users -o api-docs.adoc
+
+    # Give every schema file its own section, with an index of all of them
+    graphqls-to-asciidoc -p "schemas/**/*.graphqls" --group-by file -o docs.adoc
+
+    # Regenerate the documentation on every schema change while editing
+    graphqls-to-asciidoc -p "schemas/**/*.graphqls" -o docs.adoc --watch
+
+    # Set the title, author, version and table of contents placement
+    graphqls-to-asciidoc -s schema.graphql --title "Orders API" --author "API Team" \
+        --doc-version 2.1 -a toc=right -o api-docs.adoc
+
+    # Regenerate committed documentation without spurious diffs
+    graphqls-to-asciidoc -p "schemas/**/*.graphqls" -o docs.adoc --reproducible
+
+    # Use a project configuration file, overriding its output path
+    graphqls-to-asciidoc --config docs/graphqls-to-asciidoc.yaml -o preview.adoc
+
 FEATURES:
     ✓ Admonition blocks (NOTE, WARNING, TIP, etc.)
     ✓ Code callouts with automatic conversion
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 const (
@@ -31,6 +33,12 @@ func TestNewConfig(t *testing.T) {
 	if !config.IncludeTypes {
 		t.Error("Expected IncludeTypes to be true by default")
 	}
+	if !config.IncludeInterfaces {
+		t.Error("Expected IncludeInterfaces to be true by default")
+	}
+	if !config.IncludeUnions {
+		t.Error("Expected IncludeUnions to be true by default")
+	}
 	if !config.IncludeEnums {
 		t.Error("Expected IncludeEnums to be true by default")
 	}
@@ -271,3 +279,352 @@ func TestValidateOutputDirNotExist(t *testing.T) {
 		t.Error("Expected error for non-existent output directory in Validate")
 	}
 }
+
+func TestValidateTemplatesDir(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaFile = "../../test/schema.graphql"
+
+	cfg.TemplatesDir = t.TempDir()
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected existing template directory to validate, got: %v", err)
+	}
+
+	cfg.TemplatesDir = "/nonexistentdir/templates"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error for non-existent template directory")
+	}
+}
+
+func TestValidateSplitDir(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "split dir alone", modify: func(c *Config) {}},
+		{name: "with output file", modify: func(c *Config) { c.OutputFile = "api.adoc" }, wantErr: true},
+		{name: "with catalogue", modify: func(c *Config) { c.Catalogue = true }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			cfg.SplitDir = t.TempDir()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateIntrospection(t *testing.T) {
+	cfg := NewConfig()
+	cfg.IntrospectionFile = "../../test/introspection.json"
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected introspection file to validate, got: %v", err)
+	}
+	if got := cfg.SchemaSource(); got != cfg.IntrospectionFile {
+		t.Errorf("Expected schema source %q, got %q", cfg.IntrospectionFile, got)
+	}
+
+	cfg.SchemaFile = "../../test/schema.graphql"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error when combined with -schema")
+	}
+
+	cfg.SchemaFile = ""
+	cfg.IntrospectionFile = "nonexistent.json"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error for non-existent introspection file")
+	}
+}
+
+func TestValidateStrict(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaFile = "../../test/schema.graphql"
+	cfg.Strict = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected strict mode with a schema file to validate, got: %v", err)
+	}
+
+	cfg.SchemaFile = ""
+	cfg.IntrospectionFile = "../../test/introspection.json"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error for strict mode with introspection input")
+	}
+}
+
+func TestValidateExampleDepth(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaFile = "../../test/schema.graphql"
+	cfg.Examples = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected the default example depth to validate, got: %v", err)
+	}
+
+	cfg.ExampleDepth = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error for an example depth below 1")
+	}
+}
+
+func TestValidateSignatureStyle(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaFile = "../../test/schema.graphql"
+
+	for _, style := range []string{"graphql", "kotlin", "typescript", "GraphQL", ""} {
+		cfg.SignatureStyle = style
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Expected signature style %q to validate, got: %v", style, err)
+		}
+	}
+
+	cfg.SignatureStyle = "java"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Should return error for unsupported signature style")
+	}
+}
+
+func TestValidateDiagram(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "no diagram", modify: func(c *Config) {}},
+		{name: "plantuml", modify: func(c *Config) { c.Diagram = "plantuml" }},
+		{name: "mermaid with root and file", modify: func(c *Config) {
+			c.Diagram, c.DiagramRoot, c.DiagramFile = "Mermaid", "users", "types.mmd"
+		}},
+		{name: "root without diagram", modify: func(c *Config) { c.DiagramRoot = "users" }, wantErr: true},
+		{name: "file without diagram", modify: func(c *Config) { c.DiagramFile = "types.puml" }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "no grouping", modify: func(c *Config) {}},
+		{name: "file", modify: func(c *Config) { c.GroupBy = "file" }},
+		{name: "directory", modify: func(c *Config) { c.GroupBy = "directory" }},
+		{name: "tag from introspection", modify: func(c *Config) {
+			c.SchemaFile, c.IntrospectionFile, c.GroupBy = "", "../../test/introspection.json", "tag"
+		}},
+		{name: "unsupported mode", modify: func(c *Config) { c.GroupBy = "package" }, wantErr: true},
+		{name: "with catalogue", modify: func(c *Config) { c.GroupBy, c.Catalogue = "file", true }, wantErr: true},
+		{name: "with split dir", modify: func(c *Config) {
+			c.GroupBy, c.SplitDir = "tag", "docs/modules/api"
+		}, wantErr: true},
+		{name: "file from introspection", modify: func(c *Config) {
+			c.SchemaFile, c.IntrospectionFile, c.GroupBy = "", "../../test/introspection.json", "file"
+		}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateWatch(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "with output file", modify: func(c *Config) { c.OutputFile = "api.adoc" }},
+		{name: "with split dir", modify: func(c *Config) { c.SplitDir = "docs/modules/api" }},
+		{name: "to stdout", modify: func(c *Config) {}, wantErr: true},
+		{name: "with introspection", modify: func(c *Config) {
+			c.SchemaFile, c.IntrospectionFile, c.OutputFile = "", "../../test/introspection.json", "api.adoc"
+		}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			cfg.Watch = true
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateSchemaStdinAndArchive(t *testing.T) {
+	archive := filepath.Join(t.TempDir(), "export.tar.gz")
+	if err := os.WriteFile(archive, []byte{0x1f, 0x8b}, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		source  string
+		wantErr bool
+	}{
+		{name: "stdin", modify: func(c *Config) { c.SchemaFile = "-" }, source: "<stdin>"},
+		{name: "archive", modify: func(c *Config) { c.SchemaFile = archive }, source: archive},
+		{name: "archive with pattern", modify: func(c *Config) {
+			c.SchemaFile, c.SchemaPattern = archive, "users/**/*.graphqls"
+		}, source: archive + " (users/**/*.graphqls)"},
+		{name: "stdin with pattern", modify: func(c *Config) {
+			c.SchemaFile, c.SchemaPattern = "-", "*.graphqls"
+		}, source: "<stdin> (*.graphqls)"},
+		{name: "file with pattern", modify: func(c *Config) {
+			c.SchemaFile, c.SchemaPattern = "../../test/schema.graphql", "*.graphqls"
+		}, wantErr: true},
+		{name: "missing archive", modify: func(c *Config) { c.SchemaFile = "missing.zip" }, wantErr: true},
+		{name: "watch stdin", modify: func(c *Config) {
+			c.SchemaFile, c.Watch, c.OutputFile = "-", true, "api.adoc"
+		}, wantErr: true},
+		{name: "serve stdin", modify: func(c *Config) { c.SchemaFile, c.ServeAddr = "-", DefaultServeAddr }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr {
+				if err == nil {
+					t.Error("Expected validation error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got: %v", err)
+			}
+			if got := cfg.SchemaSource(); got != tc.source {
+				t.Errorf("Expected schema source %q, got %q", tc.source, got)
+			}
+		})
+	}
+}
+
+func TestValidateReproducible(t *testing.T) {
+	testCases := []struct {
+		name    string
+		revDate string
+		epoch   string
+		wantErr bool
+	}{
+		{name: "date", revDate: "2025-01-01"},
+		{name: "RFC 3339", revDate: "2025-01-01T12:30:00+10:00"},
+		{name: "source date epoch", epoch: "1735689600"},
+		{name: "bad date", revDate: "01/01/2025", wantErr: true},
+		{name: "bad source date epoch", epoch: "yesterday", wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(SourceDateEpochEnv, tc.epoch)
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			cfg.RevDate = tc.revDate
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+			if !tc.wantErr && !cfg.IsReproducible() {
+				t.Error("Expected a fixed revision date to imply reproducible output")
+			}
+		})
+	}
+}
+
+func TestNewestModTime(t *testing.T) {
+	dir := t.TempDir()
+	older, newer := filepath.Join(dir, "a.graphqls"), filepath.Join(dir, "b.graphqls")
+	for _, file := range []string{older, newer} {
+		if err := os.WriteFile(file, []byte("type A { id: ID }"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+	if err := os.Chtimes(older, want.Add(-time.Hour), want.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(newer, want.Add(500*time.Millisecond), want.Add(500*time.Millisecond)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := NewestModTime([]string{older, newer, filepath.Join(dir, "missing")}); !got.Equal(want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+	if got := NewestModTime(nil); !got.Equal(time.Unix(0, 0)) {
+		t.Errorf("Expected the Unix epoch without files, got %v", got)
+	}
+}
+
+func TestFixedRevisionTime(t *testing.T) {
+	t.Setenv(SourceDateEpochEnv, "1735689600")
+	cfg := NewConfig()
+
+	got, ok, err := cfg.FixedRevisionTime()
+	if err != nil || !ok || !got.Equal(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected SOURCE_DATE_EPOCH to give 2025-01-01, got %v, %v, %v", got, ok, err)
+	}
+
+	// --revdate wins over the environment, and is converted to UTC
+	cfg.RevDate = "2025-06-30T20:00:00-04:00"
+	got, ok, err = cfg.FixedRevisionTime()
+	if err != nil || !ok || got.Format(time.RFC3339) != "2025-07-01T00:00:00Z" {
+		t.Errorf("Expected --revdate to give 2025-07-01T00:00:00Z, got %v, %v, %v", got, ok, err)
+	}
+
+	t.Setenv(SourceDateEpochEnv, "")
+	cfg.RevDate = ""
+	if _, ok, _ := cfg.FixedRevisionTime(); ok || cfg.IsReproducible() {
+		t.Error("Expected no fixed revision time without --revdate or SOURCE_DATE_EPOCH")
+	}
+}
@@ -0,0 +1,149 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/coverage"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+// CoverageConfig holds the options of the coverage subcommand
+type CoverageConfig struct {
+	SchemaFile        string
+	SchemaPattern     string
+	IntrospectionFile string
+	OutputFile        string
+	Format            string
+	// MinCoverage is the percentage of documented elements below which the
+	// command fails; zero disables the check
+	MinCoverage float64
+	Verbose     bool
+	ShowHelp    bool
+}
+
+// ParseCoverageFlags parses the arguments following the coverage subcommand
+func ParseCoverageFlags(args []string) (*CoverageConfig, error) {
+	cfg := &CoverageConfig{}
+
+	fs := flag.NewFlagSet("coverage", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.SchemaFile, "schema", "", "Path to the GraphQL schema file")
+	fs.StringVar(&cfg.SchemaFile, "s", "", "Path to the GraphQL schema file (shorthand)")
+	fs.StringVar(&cfg.SchemaPattern, "pattern", "", "Pattern to match multiple GraphQL schema files")
+	fs.StringVar(&cfg.SchemaPattern, "p", "", "Pattern to match multiple GraphQL schema files (shorthand)")
+	fs.StringVar(&cfg.IntrospectionFile, "introspection", "", "Path to an introspection result (__schema JSON)")
+	fs.StringVar(&cfg.OutputFile, "output", "", "Output file path (default: stdout)")
+	fs.StringVar(&cfg.OutputFile, "o", "", "Output file path (shorthand)")
+	fs.StringVar(&cfg.Format, "format", coverage.Table, "Report format: "+strings.Join(coverage.Formats, ", "))
+	fs.Float64Var(&cfg.MinCoverage, "min-coverage", 0, "Fail when fewer than this percentage of elements are documented")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "Enable verbose logging")
+	fs.BoolVar(&cfg.ShowHelp, "help", false, "Show help for the coverage command")
+	fs.BoolVar(&cfg.ShowHelp, "h", false, "Show help for the coverage command (shorthand)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return cfg, nil
+}
+
+// SchemaSource returns the schema file, pattern or introspection file the
+// report is made from
+func (c *CoverageConfig) SchemaSource() string {
+	switch {
+	case c.IntrospectionFile != "":
+		return c.IntrospectionFile
+	}
+	return schemaSource(c.SchemaFile, c.SchemaPattern)
+}
+
+// Validate checks that exactly one schema source is given, that the format
+// and threshold are valid and that the output directory exists
+func (c *CoverageConfig) Validate() error {
+	if countSchemaSources(c.SchemaFile, c.SchemaPattern, c.IntrospectionFile) != 1 {
+		return fmt.Errorf("coverage requires exactly one of -schema, -pattern or -introspection")
+	}
+	for _, file := range []string{c.SchemaFile, c.IntrospectionFile} {
+		if file == "" || file == schemafile.Stdin {
+			continue
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("schema file '%s' does not exist", file)
+		}
+	}
+
+	if !coverage.IsFormat(c.Format) {
+		return fmt.Errorf("unsupported coverage format '%s' (supported: %s)", c.Format, strings.Join(coverage.Formats, ", "))
+	}
+	if c.MinCoverage < 0 || c.MinCoverage > 100 {
+		return fmt.Errorf("--min-coverage must be between 0 and 100, got %g", c.MinCoverage)
+	}
+
+	if c.OutputFile != "" {
+		if dir := filepath.Dir(c.OutputFile); dir != "." {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				return fmt.Errorf("output directory '%s' does not exist", dir)
+			}
+		}
+	}
+	return nil
+}
+
+// GetOutputWriter returns either stdout or a file writer based on configuration
+func (c *CoverageConfig) GetOutputWriter() (*os.File, bool, error) {
+	if c.OutputFile == "" {
+		return os.Stdout, false, nil
+	}
+
+	file, err := os.Create(c.OutputFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create output file '%s': %v", c.OutputFile, err)
+	}
+	return file, true, nil
+}
+
+// PrintCoverageUsage prints usage information for the coverage subcommand
+func PrintCoverageUsage() {
+	fmt.Printf(`graphqls-to-asciidoc coverage - Report how much of a schema is documented
+
+USAGE:
+    graphqls-to-asciidoc coverage [OPTIONS]
+
+Every type, field, argument, input field and enum value should have a
+description. The report gives the percentage that do, by element kind and by
+type, plus an average completeness score: plain descriptions score 30%%, and
+@param, @returns, @throws and @example sections add the rest. The query,
+mutation and subscription types count through their fields only.
+
+REQUIRED (choose one):
+    -s, --schema PATH       Path to the GraphQL schema file, - for stdin, or a .zip or
+                            .tar.gz archive of schema files
+    -p, --pattern PATTERN   Pattern to match multiple GraphQL schema files
+        --introspection PATH
+                            Introspection query result (JSON with a __schema object)
+
+OPTIONS:
+    -o, --output PATH       Output file path (default: stdout)
+        --format FORMAT     Report format: table, json (every element with its score) or
+                            junit (one failing test case per undocumented element)
+                            (default: table)
+        --min-coverage PCT  Exit with status 1 when fewer than PCT percent of the elements
+                            are documented (default: 0, no check)
+        --verbose           Enable verbose logging
+    -h, --help              Show this help information
+
+EXAMPLES:
+    # Show the coverage of a schema
+    graphqls-to-asciidoc coverage -p "schemas/**/*.graphqls"
+
+    # Fail the build below 80%%, with a JUnit report for the CI server
+    graphqls-to-asciidoc coverage -s schema.graphql --format junit -o coverage.xml --min-coverage 80
+`)
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestParseCoverageFlags(t *testing.T) {
+	cfg, err := ParseCoverageFlags([]string{"-s", "schema.graphqls", "--format", "junit", "--min-coverage", "80"})
+	if err != nil {
+		t.Fatalf("ParseCoverageFlags() returned error: %v", err)
+	}
+	if cfg.SchemaFile != "schema.graphqls" || cfg.Format != "junit" || cfg.MinCoverage != 80 {
+		t.Errorf("Unexpected config: %+v", cfg)
+	}
+
+	cfg, err = ParseCoverageFlags([]string{"-p", "schemas/*.graphqls"})
+	if err != nil {
+		t.Fatalf("ParseCoverageFlags() returned error: %v", err)
+	}
+	if cfg.Format != "table" || cfg.SchemaSource() != "schemas/*.graphqls" {
+		t.Errorf("Expected the table format by default, got %+v", cfg)
+	}
+
+	for _, args := range [][]string{{"extra"}, {"--unknown"}, {"--min-coverage", "high"}} {
+		if _, err := ParseCoverageFlags(args); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestCoverageConfigValidate(t *testing.T) {
+	schema := "../../test/schema.graphql"
+	testCases := []struct {
+		name    string
+		cfg     CoverageConfig
+		wantErr bool
+	}{
+		{name: "schema file", cfg: CoverageConfig{SchemaFile: schema, Format: "table"}},
+		{name: "format in any case", cfg: CoverageConfig{SchemaFile: schema, Format: "JSON", MinCoverage: 100}},
+		{name: "no schema", cfg: CoverageConfig{Format: "table"}, wantErr: true},
+		{
+			name:    "two schemas",
+			cfg:     CoverageConfig{SchemaFile: schema, SchemaPattern: "*.graphqls", Format: "table"},
+			wantErr: true,
+		},
+		{name: "missing file", cfg: CoverageConfig{SchemaFile: "nope.graphqls", Format: "table"}, wantErr: true},
+		{name: "unsupported format", cfg: CoverageConfig{SchemaFile: schema, Format: "csv"}, wantErr: true},
+		{name: "threshold above 100", cfg: CoverageConfig{SchemaFile: schema, Format: "table", MinCoverage: 120}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+// DiffConfig holds the options of the diff subcommand
+type DiffConfig struct {
+	// OldSchema and NewSchema are each a schema file or a file pattern
+	OldSchema  string
+	NewSchema  string
+	OutputFile string
+	Title      string
+	Verbose    bool
+	ShowHelp   bool
+	// Reproducible and RevDate fix the revision date as for the main command
+	Reproducible bool
+	RevDate      string
+}
+
+// ParseDiffFlags parses the arguments following the diff subcommand. The old
+// and new schemas may be given with --old and --new or as two positional
+// arguments.
+func ParseDiffFlags(args []string) (*DiffConfig, error) {
+	cfg := &DiffConfig{}
+
+	fs := flag.NewFlagSet("diff", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.OldSchema, "old", "", "Old schema file or pattern")
+	fs.StringVar(&cfg.NewSchema, "new", "", "New schema file or pattern")
+	fs.StringVar(&cfg.OutputFile, "output", "", "Output file path (default: stdout)")
+	fs.StringVar(&cfg.OutputFile, "o", "", "Output file path (shorthand)")
+	fs.StringVar(&cfg.Title, "title", "", "Document title (default: What's Changed)")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "Enable verbose logging")
+	//nolint:lll // flag usage text
+	fs.BoolVar(&cfg.Reproducible, "reproducible", false, "Stamp the document with a fixed date and relative schema paths")
+	fs.StringVar(&cfg.RevDate, "revdate", "", "Fixed revision date as YYYY-MM-DD or RFC 3339; implies --reproducible")
+	fs.BoolVar(&cfg.ShowHelp, "help", false, "Show help for the diff command")
+	fs.BoolVar(&cfg.ShowHelp, "h", false, "Show help for the diff command (shorthand)")
+
+	// Flags may appear before, between or after the positional arguments
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		rest = append(rest, args[0])
+		args = args[1:]
+	}
+
+	if cfg.OldSchema == "" && len(rest) > 0 {
+		cfg.OldSchema, rest = rest[0], rest[1:]
+	}
+	if cfg.NewSchema == "" && len(rest) > 0 {
+		cfg.NewSchema, rest = rest[0], rest[1:]
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", rest)
+	}
+	return cfg, nil
+}
+
+// Validate checks that both schemas are given, that plain schema files and
+// the output directory exist and that a fixed revision date parses
+func (c *DiffConfig) Validate() error {
+	if c.OldSchema == "" || c.NewSchema == "" {
+		return fmt.Errorf("diff requires an old and a new schema")
+	}
+
+	for _, schema := range []string{c.OldSchema, c.NewSchema} {
+		if schemafile.IsPattern(schema) {
+			continue
+		}
+		if _, err := os.Stat(schema); os.IsNotExist(err) {
+			return fmt.Errorf("schema file '%s' does not exist", schema)
+		}
+	}
+
+	if c.OutputFile != "" {
+		if dir := filepath.Dir(c.OutputFile); dir != "." {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				return fmt.Errorf("output directory '%s' does not exist", dir)
+			}
+		}
+	}
+	return validateRevisionDate(c.RevDate)
+}
+
+// IsReproducible reports whether the document must be byte-identical for
+// identical input, see Config.IsReproducible
+func (c *DiffConfig) IsReproducible() bool {
+	return isReproducible(c.Reproducible, c.RevDate)
+}
+
+// FixedRevisionTime returns the revision date given by --revdate or, failing
+// that, SOURCE_DATE_EPOCH. ok is false when neither is set.
+func (c *DiffConfig) FixedRevisionTime() (t time.Time, ok bool, err error) {
+	return fixedRevisionTime(c.RevDate)
+}
+
+// GetOutputWriter returns either stdout or a file writer based on configuration
+func (c *DiffConfig) GetOutputWriter() (*os.File, bool, error) {
+	if c.OutputFile == "" {
+		return os.Stdout, false, nil
+	}
+
+	file, err := os.Create(c.OutputFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create output file '%s': %v", c.OutputFile, err)
+	}
+	return file, true, nil
+}
+
+// PrintDiffUsage prints usage information for the diff subcommand
+func PrintDiffUsage() {
+	fmt.Printf(`graphqls-to-asciidoc diff - Document the changes between two schema versions
+
+USAGE:
+    graphqls-to-asciidoc diff [OPTIONS] OLD NEW
+    graphqls-to-asciidoc diff [OPTIONS] --old OLD --new NEW
+
+OLD and NEW are each a schema file or a pattern matching several schema files
+(e.g. 'v1/**/*.graphqls').
+
+OPTIONS:
+        --old SCHEMA        Old schema file or pattern
+        --new SCHEMA        New schema file or pattern
+    -o, --output PATH       Output file path (default: stdout)
+        --title TEXT        Document title (default: What's Changed)
+        --verbose           Enable verbose logging
+        --reproducible      Make the document byte-identical for identical input: the
+                            revision date comes from --revdate, SOURCE_DATE_EPOCH or the
+                            newest schema file, in UTC, and absolute schema paths are made
+                            relative to the working directory
+        --revdate DATE      Fixed revision date, as YYYY-MM-DD or RFC 3339; implies --reproducible
+    -h, --help              Show this help information
+
+Added, removed and changed types, fields, arguments, input fields, enum values,
+union members and interfaces are reported, including nullability changes. Each
+change is classified as breaking or non-breaking:
+
+    Breaking        removals, new required arguments and input fields, kind
+                    changes, output fields becoming nullable, arguments and
+                    input fields becoming non-null, other type changes
+    Non-breaking    additions, output fields becoming non-null, arguments and
+                    input fields becoming nullable, default value changes,
+                    deprecations
+
+EXAMPLES:
+    # Compare two schema files
+    graphqls-to-asciidoc diff schema-v1.graphqls schema-v2.graphqls -o changes.adoc
+
+    # Compare two directories of schema files
+    graphqls-to-asciidoc diff -o changes.adoc "v1/**/*.graphqls" "v2/**/*.graphqls"
+`)
+}
@@ -0,0 +1,111 @@
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDiffFlags(t *testing.T) {
+	testCases := []struct {
+		name    string
+		args    []string
+		old     string
+		new     string
+		output  string
+		wantErr bool
+	}{
+		{name: "positional", args: []string{"v1.graphqls", "v2.graphqls"}, old: "v1.graphqls", new: "v2.graphqls"},
+		{name: "flags", args: []string{"--old", "v1.graphqls", "--new", "v2.graphqls"}, old: "v1.graphqls", new: "v2.graphqls"},
+		{
+			name: "flags after positional arguments", args: []string{"v1.graphqls", "v2.graphqls", "-o", "changes.adoc"},
+			old: "v1.graphqls", new: "v2.graphqls", output: "changes.adoc",
+		},
+		{
+			name: "reproducible flags", args: []string{"--revdate", "2025-01-01", "v1.graphqls", "--reproducible", "v2.graphqls"},
+			old: "v1.graphqls", new: "v2.graphqls",
+		},
+		{name: "too many arguments", args: []string{"a", "b", "c"}, wantErr: true},
+		{name: "unknown flag", args: []string{"--unknown"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg, err := ParseDiffFlags(tc.args)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseDiffFlags() returned error: %v", err)
+			}
+			if cfg.OldSchema != tc.old || cfg.NewSchema != tc.new || cfg.OutputFile != tc.output {
+				t.Errorf("Got old=%q new=%q output=%q", cfg.OldSchema, cfg.NewSchema, cfg.OutputFile)
+			}
+		})
+	}
+}
+
+func TestDiffConfigRevisionDate(t *testing.T) {
+	t.Setenv(SourceDateEpochEnv, "")
+	cfg, err := ParseDiffFlags([]string{"--revdate", "2025-01-01", "v1.graphqls", "v2.graphqls"})
+	if err != nil {
+		t.Fatalf("ParseDiffFlags() returned error: %v", err)
+	}
+	if !cfg.IsReproducible() {
+		t.Error("Expected --revdate to imply reproducible output")
+	}
+	if got := RevisionDate(cfg, nil); got != "Wed, 01 Jan 2025 00:00:00 UTC" {
+		t.Errorf("Expected the --revdate date, got %q", got)
+	}
+
+	cfg, err = ParseDiffFlags([]string{"--reproducible", "v1.graphqls", "v2.graphqls"})
+	if err != nil {
+		t.Fatalf("ParseDiffFlags() returned error: %v", err)
+	}
+	newest := func() time.Time { return time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC) }
+	if got := RevisionDate(cfg, newest); got != "Mon, 06 May 2024 07:08:09 UTC" {
+		t.Errorf("Expected the newest schema file's date, got %q", got)
+	}
+}
+
+func TestDiffConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		cfg     DiffConfig
+		wantErr bool
+	}{
+		{name: "two files", cfg: DiffConfig{OldSchema: "../../test/schema.graphql", NewSchema: "../../test/schema.graphql"}},
+		{name: "patterns are not checked", cfg: DiffConfig{OldSchema: "v1/*.graphqls", NewSchema: "v2/*.graphqls"}},
+		{name: "missing new schema", cfg: DiffConfig{OldSchema: "../../test/schema.graphql"}, wantErr: true},
+		{name: "missing file", cfg: DiffConfig{OldSchema: "../../test/schema.graphql", NewSchema: "nope.graphql"}, wantErr: true},
+		{
+			name: "invalid revision date",
+			cfg: DiffConfig{
+				OldSchema: "../../test/schema.graphql", NewSchema: "../../test/schema.graphql", RevDate: "yesterday",
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing output directory",
+			cfg: DiffConfig{
+				OldSchema: "../../test/schema.graphql", NewSchema: "../../test/schema.graphql",
+				OutputFile: "/nonexistent/changes.adoc",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
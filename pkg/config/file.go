@@ -0,0 +1,255 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+// DefaultConfigFile is the project configuration file looked up in the working
+// directory when --config is not given
+const DefaultConfigFile = ".graphqls-to-asciidoc.yaml"
+
+// DefaultTOMLConfigFile is looked up when DefaultConfigFile does not exist
+const DefaultTOMLConfigFile = ".graphqls-to-asciidoc.toml"
+
+// Attribute is a single AsciiDoc document attribute written to the header
+type Attribute struct {
+	Name  string
+	Value string
+}
+
+// Attributes is an ordered list of document attributes. In the configuration
+// file it is written as a mapping and keeps the order given there.
+type Attributes []Attribute
+
+// UnmarshalYAML decodes a mapping of attribute names to scalar values. A null
+// value produces a valueless attribute such as ":experimental:".
+func (a *Attributes) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: attributes must be a mapping of name: value", node.Line)
+	}
+
+	attrs := make(Attributes, 0, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind != yaml.ScalarNode {
+			return fmt.Errorf("line %d: attribute '%s' must have a scalar value", value.Line, key.Value)
+		}
+		attr := Attribute{Name: key.Value}
+		if value.Tag != "!!null" {
+			attr.Value = value.Value
+		}
+		attrs = append(attrs, attr)
+	}
+
+	*a = attrs
+	return nil
+}
+
+// String formats the attributes as NAME=VALUE lines, the form Set accepts
+func (a *Attributes) String() string {
+	if a == nil {
+		return ""
+	}
+	lines := make([]string, len(*a))
+	for i, attr := range *a {
+		lines[i] = attr.Name
+		if attr.Value != "" {
+			lines[i] += "=" + attr.Value
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Set adds the attributes given as NAME=VALUE or NAME, one per line, for the
+// repeatable --attribute flag
+func (a *Attributes) Set(value string) error {
+	for _, line := range strings.Split(value, "\n") {
+		name, val, _ := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return fmt.Errorf("invalid attribute '%s' (expected NAME=VALUE)", line)
+		}
+		*a = append(*a, Attribute{Name: name, Value: strings.TrimSpace(val)})
+	}
+	return nil
+}
+
+// LoadFile applies the settings from a YAML or, for a .toml file, TOML
+// project configuration file on top of the current values. Keys missing from
+// the file keep their current value, and relative paths in the file are taken
+// relative to its directory. Syntax and type errors are returned; unknown
+// keys are recorded and reported by Validate together with their line
+// numbers.
+func (c *Config) LoadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file '%s': %v", path, err)
+	}
+
+	var root *yaml.Node
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if root, err = parseTOML(string(content)); err != nil {
+			return fmt.Errorf("failed to parse config file '%s': %v", path, err)
+		}
+	} else {
+		var doc yaml.Node
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				// An empty file is a valid, if pointless, configuration
+				c.ConfigFile = path
+				return nil
+			}
+			return fmt.Errorf("failed to parse config file '%s': %v", path, err)
+		}
+
+		root = &doc
+		if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+			root = root.Content[0]
+		}
+		if root.Kind != yaml.MappingNode {
+			return fmt.Errorf("config file '%s': line %d: expected a mapping of settings", path, root.Line)
+		}
+	}
+
+	if err := root.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file '%s': %v", path, err)
+	}
+
+	c.resolvePaths(root, filepath.Dir(path))
+	c.ConfigFile = path
+	for _, unknown := range findUnknownKeys(root, reflect.TypeOf(*c), "") {
+		c.fileErrors = append(c.fileErrors, fmt.Sprintf("%s: %s", path, unknown))
+	}
+	return nil
+}
+
+// resolvePaths makes the relative paths set by a configuration file relative
+// to the file's directory, so that a file passed with --config works from any
+// working directory. Stdin and patterns selecting files inside an archive are
+// left as they are.
+func (c *Config) resolvePaths(root *yaml.Node, dir string) {
+	paths := map[string]*string{
+		"schema":          &c.SchemaFile,
+		"pattern":         &c.SchemaPattern,
+		"introspection":   &c.IntrospectionFile,
+		"output":          &c.OutputFile,
+		"templates":       &c.TemplatesDir,
+		"attributes-file": &c.AttributesFile,
+		"preamble":        &c.PreambleFile,
+		"banner":          &c.BannerFile,
+		"split-dir":       &c.SplitDir,
+		"diagram-file":    &c.DiagramFile,
+	}
+	if c.SchemaFile == schemafile.Stdin || schemafile.IsArchive(c.SchemaFile) {
+		delete(paths, "pattern")
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		value, ok := paths[root.Content[i].Value]
+		if !ok || *value == "" || *value == schemafile.Stdin || filepath.IsAbs(*value) {
+			continue
+		}
+		*value = filepath.Join(dir, *value)
+	}
+}
+
+// findUnknownKeys walks a mapping node and returns a message for every key
+// that has no matching yaml tag in t. Nested structs are checked recursively.
+func findUnknownKeys(node *yaml.Node, t reflect.Type, prefix string) []string {
+	fields := yamlFields(t)
+
+	var unknown []string
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		field, ok := fields[key.Value]
+		if !ok {
+			unknown = append(unknown, fmt.Sprintf("line %d: unknown key '%s%s' (valid keys: %s)",
+				key.Line, prefix, key.Value, strings.Join(sortedKeys(fields), ", ")))
+			continue
+		}
+		if field.Kind() == reflect.Struct && value.Kind == yaml.MappingNode {
+			unknown = append(unknown, findUnknownKeys(value, field, prefix+key.Value+".")...)
+		}
+	}
+	return unknown
+}
+
+// yamlFields maps the yaml key of every exported, non-skipped field of t to
+// the field's type
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field.Type
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]reflect.Type) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyConfigFile loads the project configuration file, then re-applies every
+// flag that was set explicitly on the command line so that flags always take
+// precedence over the file. When path is empty DefaultConfigFile or, failing
+// that, DefaultTOMLConfigFile is used if it exists in the working directory.
+func (c *Config) applyConfigFile(fs *flag.FlagSet, path string) {
+	if path == "" {
+		for _, name := range []string{DefaultConfigFile, DefaultTOMLConfigFile} {
+			if _, err := os.Stat(name); err == nil {
+				path = name
+				break
+			}
+		}
+		if path == "" {
+			return
+		}
+	}
+
+	// A shorthand and its long flag share a value, which is re-applied once
+	explicit := make(map[flag.Value]string)
+	fs.Visit(func(f *flag.Flag) {
+		explicit[f.Value] = f.Value.String()
+	})
+
+	if err := c.LoadFile(path); err != nil {
+		c.fileErrors = append(c.fileErrors, err.Error())
+		return
+	}
+
+	for flagValue, value := range explicit {
+		// Setting an unchanged repeatable flag again would add its values twice
+		if flagValue.String() != value {
+			_ = flagValue.Set(value)
+		}
+	}
+}
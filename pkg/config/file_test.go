@@ -0,0 +1,384 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), DefaultConfigFile)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFile(t *testing.T) {
+	schema, err := filepath.Abs("../../test/schema.graphql")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := writeConfigFile(t, `
+schema: `+schema+`
+output: api.adoc
+subscriptions: true
+mutations: false
+inc-internal: true
+title: Orders API
+attributes:
+  toc: right
+  experimental:
+  company: ACME
+filters:
+  include: ["order*"]
+  exclude: ["orderDebug*"]
+scalar-samples:
+  DateTime: 2030-06-01T12:00:00Z
+  Money: 9.99
+`)
+
+	cfg := NewConfig()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+
+	if cfg.SchemaFile != schema || cfg.OutputFile != filepath.Join(filepath.Dir(path), "api.adoc") {
+		t.Errorf("Paths not loaded, got schema %q output %q", cfg.SchemaFile, cfg.OutputFile)
+	}
+	if !cfg.IncludeSubscriptions || cfg.IncludeMutations || !cfg.IncludeInternal {
+		t.Error("Boolean settings not loaded from config file")
+	}
+	if !cfg.IncludeQueries {
+		t.Error("Settings missing from the file should keep their defaults")
+	}
+	if cfg.Title != "Orders API" {
+		t.Errorf("Expected title 'Orders API', got %q", cfg.Title)
+	}
+	if cfg.ConfigFile != path {
+		t.Errorf("Expected ConfigFile %q, got %q", path, cfg.ConfigFile)
+	}
+
+	wantAttrs := Attributes{{"toc", "right"}, {"experimental", ""}, {"company", "ACME"}}
+	if len(cfg.HeaderAttributes) != len(wantAttrs) {
+		t.Fatalf("Expected %d attributes, got %v", len(wantAttrs), cfg.HeaderAttributes)
+	}
+	for i, want := range wantAttrs {
+		if cfg.HeaderAttributes[i] != want {
+			t.Errorf("Attribute %d: expected %v, got %v", i, want, cfg.HeaderAttributes[i])
+		}
+	}
+
+	if len(cfg.Filters.Include) != 1 || len(cfg.Filters.Exclude) != 1 {
+		t.Errorf("Filter rules not loaded, got %+v", cfg.Filters)
+	}
+	if cfg.ScalarSamples["DateTime"] != "2030-06-01T12:00:00Z" || cfg.ScalarSamples["Money"] != "9.99" {
+		t.Errorf("Scalar samples not loaded, got %v", cfg.ScalarSamples)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected loaded config to validate, got: %v", err)
+	}
+}
+
+func TestLoadFileTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultTOMLConfigFile)
+	content := `pattern = "schemas/**/*.graphqls"
+subscriptions = true
+example-depth = 3
+title = "Orders API"
+querys = false
+
+[attributes]
+toc = "right"
+experimental = ""
+
+[filters]
+include = ["order*"]
+
+[scalar-samples]
+Money = 9.99
+DateTime = 2030-06-01T12:00:00Z
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewConfig()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+
+	if cfg.SchemaPattern != filepath.Join(filepath.Dir(path), "schemas/**/*.graphqls") {
+		t.Errorf("Expected the pattern relative to the config file, got %q", cfg.SchemaPattern)
+	}
+	if !cfg.IncludeSubscriptions || cfg.ExampleDepth != 3 || cfg.Title != "Orders API" {
+		t.Errorf("Settings not loaded, got subscriptions %v, example depth %d, title %q",
+			cfg.IncludeSubscriptions, cfg.ExampleDepth, cfg.Title)
+	}
+	if got := cfg.HeaderAttributes.String(); got != "toc=right\nexperimental" {
+		t.Errorf("Expected attributes in file order, got %q", got)
+	}
+	if len(cfg.Filters.Include) != 1 || cfg.Filters.Include[0] != "order*" {
+		t.Errorf("Filter rules not loaded, got %+v", cfg.Filters)
+	}
+	if cfg.ScalarSamples["DateTime"] != "2030-06-01T12:00:00Z" || cfg.ScalarSamples["Money"] != "9.99" {
+		t.Errorf("Scalar samples not loaded, got %v", cfg.ScalarSamples)
+	}
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "line 5: unknown key 'querys'") {
+		t.Errorf("Expected the unknown key to be reported with its line, got: %v", err)
+	}
+}
+
+func TestLoadFileUnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, `schema: ../../test/schema.graphql
+querys: false
+filters:
+  exclude: ["debug*"]
+  inclde: ["order*"]
+`)
+
+	cfg := NewConfig()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("Unknown keys should not fail loading, got: %v", err)
+	}
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("Expected Validate to report unknown keys")
+	}
+	for _, want := range []string{"line 2: unknown key 'querys'", "line 5: unknown key 'filters.inclde'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error to contain %q, got: %v", want, err)
+		}
+	}
+}
+
+func TestLoadFileRelativePaths(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docs", "api.yaml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "pattern: schemas/**/*.graphqls\noutput: ../build/api.adoc\ntemplates: /srv/templates\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewConfig()
+	cfg.PreambleFile = "preamble.adoc"
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	dir := filepath.Dir(path)
+	if cfg.SchemaPattern != filepath.Join(dir, "schemas/**/*.graphqls") {
+		t.Errorf("Expected the pattern relative to the config file, got %q", cfg.SchemaPattern)
+	}
+	if cfg.OutputFile != filepath.Join(filepath.Dir(dir), "build", "api.adoc") {
+		t.Errorf("Expected the output relative to the config file, got %q", cfg.OutputFile)
+	}
+	if cfg.TemplatesDir != "/srv/templates" {
+		t.Errorf("Expected an absolute path to be kept, got %q", cfg.TemplatesDir)
+	}
+	if cfg.PreambleFile != "preamble.adoc" {
+		t.Errorf("Expected a path not set by the file to be kept, got %q", cfg.PreambleFile)
+	}
+
+	// A pattern inside an archive and stdin are not paths on disk
+	if err := os.WriteFile(path, []byte("schema: \"-\"\npattern: \"**/*.graphqls\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg = NewConfig()
+	if err := cfg.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile returned error: %v", err)
+	}
+	if cfg.SchemaFile != "-" || cfg.SchemaPattern != "**/*.graphqls" {
+		t.Errorf("Expected stdin and its pattern unchanged, got %q and %q", cfg.SchemaFile, cfg.SchemaPattern)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "invalid yaml", content: "schema: [unclosed\n", want: "failed to parse config file"},
+		{name: "wrong type", content: "queries: maybe\n", want: "line 1"},
+		{name: "not a mapping", content: "- schema.graphql\n", want: "expected a mapping"},
+		{name: "attributes not a mapping", content: "attributes: [toc]\n", want: "attributes must be a mapping"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := NewConfig().LoadFile(writeConfigFile(t, tc.content))
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected error to contain %q, got: %v", tc.want, err)
+			}
+		})
+	}
+
+	if err := NewConfig().LoadFile("/nonexistentdir/config.yaml"); err == nil {
+		t.Error("Expected an error for a missing config file")
+	}
+	toml := filepath.Join(t.TempDir(), DefaultTOMLConfigFile)
+	if err := os.WriteFile(toml, []byte("queries = maybe\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewConfig().LoadFile(toml); err == nil || !strings.Contains(err.Error(), "line 1: invalid value") {
+		t.Errorf("Expected a TOML syntax error with its line, got: %v", err)
+	}
+}
+
+func TestApplyConfigFileFlagsOverride(t *testing.T) {
+	path := writeConfigFile(t, `
+output: from-file.adoc
+title: From File
+queries: false
+enums: false
+`)
+
+	cfg := NewConfig()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.StringVar(&cfg.OutputFile, "output", "", "")
+	fs.StringVar(&cfg.OutputFile, "o", "", "")
+	fs.StringVar(&cfg.Title, "title", "", "")
+	fs.BoolVar(&cfg.IncludeQueries, "queries", true, "")
+	fs.BoolVar(&cfg.IncludeEnums, "enums", true, "")
+	if err := fs.Parse([]string{"-o", "from-flag.adoc", "--queries=true"}); err != nil {
+		t.Fatalf("Failed to parse flags: %v", err)
+	}
+
+	cfg.applyConfigFile(fs, path)
+
+	if cfg.OutputFile != "from-flag.adoc" {
+		t.Errorf("Expected flag to override file output, got %q", cfg.OutputFile)
+	}
+	if !cfg.IncludeQueries {
+		t.Error("Expected --queries=true to override the file")
+	}
+	if cfg.Title != "From File" || cfg.IncludeEnums {
+		t.Error("Expected file values for flags not given on the command line")
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	path := writeConfigFile(t, "title: From File\nenums: false\n")
+
+	cfg, err := ParseArgs([]string{"--config", path, "--title", "From Flag"})
+	if err != nil {
+		t.Fatalf("ParseArgs() returned error: %v", err)
+	}
+	if cfg.Title != "From Flag" || cfg.IncludeEnums || cfg.ConfigFile != path {
+		t.Errorf("Expected the flag title and the file settings of %s, got %+v", path, cfg)
+	}
+
+	if _, err := ParseArgs([]string{"--unknown"}); err == nil {
+		t.Error("Expected an error for an unknown flag")
+	}
+}
+
+func TestApplyConfigFileDefaultTOML(t *testing.T) {
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(originalDir) }()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(DefaultTOMLConfigFile, []byte("title = \"From TOML\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := NewConfig()
+	cfg.applyConfigFile(flag.NewFlagSet("test", flag.ContinueOnError), "")
+	if cfg.Title != "From TOML" || cfg.ConfigFile != DefaultTOMLConfigFile {
+		t.Errorf("Expected %s to be used without a YAML file, got title %q from %q",
+			DefaultTOMLConfigFile, cfg.Title, cfg.ConfigFile)
+	}
+
+	if err := os.WriteFile(DefaultConfigFile, []byte("title: From YAML\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg = NewConfig()
+	cfg.applyConfigFile(flag.NewFlagSet("test", flag.ContinueOnError), "")
+	if cfg.Title != "From YAML" {
+		t.Errorf("Expected %s to take precedence, got title %q", DefaultConfigFile, cfg.Title)
+	}
+}
+
+func TestApplyConfigFileAttributeFlags(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "file attributes first", content: "attributes:\n  toc: right\n", want: "toc=right\nicons=font\nexperimental"},
+		{name: "flags only", content: "title: Orders\n", want: "icons=font\nexperimental"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.Var(&cfg.HeaderAttributes, "attribute", "")
+			fs.Var(&cfg.HeaderAttributes, "a", "")
+			if err := fs.Parse([]string{"-a", "icons=font", "--attribute", "experimental"}); err != nil {
+				t.Fatalf("Failed to parse flags: %v", err)
+			}
+
+			cfg.applyConfigFile(fs, writeConfigFile(t, tc.content))
+
+			if got := cfg.HeaderAttributes.String(); got != tc.want {
+				t.Errorf("Expected attributes %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestValidateHeaderOptions(t *testing.T) {
+	banner := writeConfigFile(t, "NOTE: Generated.")
+	testCases := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr bool
+	}{
+		{name: "banner file", modify: func(c *Config) { c.BannerFile = banner }},
+		{name: "no banner", modify: func(c *Config) { c.NoBanner = true }},
+		{name: "banner and no banner", modify: func(c *Config) { c.BannerFile, c.NoBanner = banner, true }, wantErr: true},
+		{name: "missing preamble", modify: func(c *Config) { c.PreambleFile = "nope.adoc" }, wantErr: true},
+		{name: "unset attribute", modify: func(c *Config) { c.HeaderAttributes = Attributes{{Name: "toc!"}} }},
+		{name: "attribute with colon", modify: func(c *Config) { c.HeaderAttributes = Attributes{{Name: "a:b"}} }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			cfg.SchemaFile = "../../test/schema.graphql"
+			tc.modify(cfg)
+
+			err := cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateFilterPatterns(t *testing.T) {
+	cfg := NewConfig()
+	cfg.SchemaFile = "../../test/schema.graphql"
+	cfg.Filters.Exclude = []string{"debug["}
+
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected error for malformed filter pattern")
+	}
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+// LintConfig holds the options of the lint subcommand
+type LintConfig struct {
+	SchemaFile        string
+	SchemaPattern     string
+	IntrospectionFile string
+	OutputFile        string
+	// Rules maps rule names to "off", "warn" or "error"; rules not listed
+	// keep their default severity
+	Rules    map[string]string
+	Verbose  bool
+	ShowHelp bool
+}
+
+// ruleLevels collects repeated --rule name=level flags
+type ruleLevels map[string]string
+
+func (r ruleLevels) String() string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		names[i] = name + "=" + r[name]
+	}
+	return strings.Join(names, ",")
+}
+
+func (r ruleLevels) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		name, level, ok := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return fmt.Errorf("invalid rule '%s' (expected name=off|warn|error)", item)
+		}
+		r[name] = strings.TrimSpace(level)
+	}
+	return nil
+}
+
+// ParseLintFlags parses the arguments following the lint subcommand
+func ParseLintFlags(args []string) (*LintConfig, error) {
+	cfg := &LintConfig{Rules: make(map[string]string)}
+
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&cfg.SchemaFile, "schema", "", "Path to the GraphQL schema file")
+	fs.StringVar(&cfg.SchemaFile, "s", "", "Path to the GraphQL schema file (shorthand)")
+	fs.StringVar(&cfg.SchemaPattern, "pattern", "", "Pattern to match multiple GraphQL schema files")
+	fs.StringVar(&cfg.SchemaPattern, "p", "", "Pattern to match multiple GraphQL schema files (shorthand)")
+	fs.StringVar(&cfg.IntrospectionFile, "introspection", "", "Path to an introspection result (__schema JSON)")
+	fs.StringVar(&cfg.OutputFile, "output", "", "Output file path (default: stdout)")
+	fs.StringVar(&cfg.OutputFile, "o", "", "Output file path (shorthand)")
+	fs.Var(ruleLevels(cfg.Rules), "rule", "Rule severity as name=off|warn|error (repeatable)")
+	fs.BoolVar(&cfg.Verbose, "verbose", false, "Enable verbose logging")
+	fs.BoolVar(&cfg.ShowHelp, "help", false, "Show help for the lint command")
+	fs.BoolVar(&cfg.ShowHelp, "h", false, "Show help for the lint command (shorthand)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return cfg, nil
+}
+
+// Validate checks that exactly one schema source is given and that the output
+// directory exists. Rule names and severities are checked by lint.New.
+func (c *LintConfig) Validate() error {
+	if countSchemaSources(c.SchemaFile, c.SchemaPattern, c.IntrospectionFile) != 1 {
+		return fmt.Errorf("lint requires exactly one of -schema, -pattern or -introspection")
+	}
+	for _, file := range []string{c.SchemaFile, c.IntrospectionFile} {
+		if file == "" || file == schemafile.Stdin {
+			continue
+		}
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			return fmt.Errorf("schema file '%s' does not exist", file)
+		}
+	}
+
+	if c.OutputFile != "" {
+		if dir := filepath.Dir(c.OutputFile); dir != "." {
+			if _, err := os.Stat(dir); os.IsNotExist(err) {
+				return fmt.Errorf("output directory '%s' does not exist", dir)
+			}
+		}
+	}
+	return nil
+}
+
+// GetOutputWriter returns either stdout or a file writer based on configuration
+func (c *LintConfig) GetOutputWriter() (*os.File, bool, error) {
+	if c.OutputFile == "" {
+		return os.Stdout, false, nil
+	}
+
+	file, err := os.Create(c.OutputFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create output file '%s': %v", c.OutputFile, err)
+	}
+	return file, true, nil
+}
+
+// PrintLintUsage prints usage information for the lint subcommand
+func PrintLintUsage() {
+	fmt.Printf(`graphqls-to-asciidoc lint - Check a schema against documentation and naming conventions
+
+USAGE:
+    graphqls-to-asciidoc lint [OPTIONS]
+
+Each issue is printed as "file:line: severity: message [rule]". The command
+exits with status 1 when any rule set to error reports an issue.
+
+REQUIRED (choose one):
+    -s, --schema PATH       Path to the GraphQL schema file, - for stdin, or a .zip or
+                            .tar.gz archive of schema files
+    -p, --pattern PATTERN   Pattern to match multiple GraphQL schema files
+        --introspection PATH
+                            Introspection query result (JSON with a __schema object)
+
+OPTIONS:
+    -o, --output PATH       Output file path (default: stdout)
+        --rule NAME=LEVEL   Set a rule to off, warn or error; repeat the flag or separate
+                            several rules with commas
+        --verbose           Enable verbose logging
+    -h, --help              Show this help information
+
+RULES (default severity):
+    missing-description     Types, fields, arguments, input fields and enum values have a
+                            description (warn)
+    field-camel-case        Field, input field and argument names are camelCase (warn)
+    enum-value-case         Enum values are SCREAMING_CASE (warn)
+    mutation-prefix         Mutation names start with add, update, delete or save, the verbs
+                            the catalogue groups them by (warn)
+    param-names             Every @param in a field description names an argument of the
+                            field (error)
+    deprecation-reason      Every @deprecated directive gives a reason (warn)
+
+EXAMPLES:
+    # Lint a schema with the default severities
+    graphqls-to-asciidoc lint -p "schemas/**/*.graphqls"
+
+    # Fail on missing descriptions and ignore mutation names
+    graphqls-to-asciidoc lint -s schema.graphql --rule missing-description=error --rule mutation-prefix=off
+`)
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestParseLintFlags(t *testing.T) {
+	cfg, err := ParseLintFlags([]string{
+		"-s", "schema.graphqls", "--rule", "missing-description=error", "--rule", "mutation-prefix=off, enum-value-case=warn",
+	})
+	if err != nil {
+		t.Fatalf("ParseLintFlags() returned error: %v", err)
+	}
+	expected := map[string]string{"missing-description": "error", "mutation-prefix": "off", "enum-value-case": "warn"}
+	if cfg.SchemaFile != "schema.graphqls" || len(cfg.Rules) != len(expected) {
+		t.Fatalf("Unexpected config: %+v", cfg)
+	}
+	for name, level := range expected {
+		if cfg.Rules[name] != level {
+			t.Errorf("Expected rule %s at %s, got %q", name, level, cfg.Rules[name])
+		}
+	}
+
+	for _, args := range [][]string{{"extra"}, {"--unknown"}, {"--rule", "missing-description"}, {"--rule", "=warn"}} {
+		if _, err := ParseLintFlags(args); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestLintConfigValidate(t *testing.T) {
+	schema := "../../test/schema.graphql"
+	testCases := []struct {
+		name    string
+		cfg     LintConfig
+		wantErr bool
+	}{
+		{name: "schema file", cfg: LintConfig{SchemaFile: schema}},
+		{name: "rule levels", cfg: LintConfig{SchemaFile: schema, Rules: map[string]string{"param-names": "Warn"}}},
+		{name: "no schema", cfg: LintConfig{}, wantErr: true},
+		{name: "stdin", cfg: LintConfig{SchemaFile: "-"}},
+		{name: "stdin with pattern", cfg: LintConfig{SchemaFile: "-", SchemaPattern: "*.graphqls"}},
+		{name: "two schemas", cfg: LintConfig{SchemaFile: schema, SchemaPattern: "*.graphqls"}, wantErr: true},
+		{name: "missing file", cfg: LintConfig{SchemaFile: "nope.graphqls"}, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			if tc.wantErr && err == nil {
+				t.Error("Expected validation error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+)
+
+// DefaultServeAddr is the listen address of the serve command. It is a
+// loopback address so the preview is not reachable from other machines.
+const DefaultServeAddr = "127.0.0.1:8080"
+
+// serveExcluded lists the flags that make no sense for the serve command,
+// which always renders HTML in memory
+var serveExcluded = []string{"output", "o", "split-dir", "format", "watch"}
+
+// ParseServeFlags parses the arguments following the serve subcommand. It
+// accepts every generation flag of the main command except the ones that
+// choose the output, plus --addr. Output settings from the configuration
+// file are ignored, so a project file written for the main command works
+// unchanged.
+func ParseServeFlags(args []string) (*Config, error) {
+	config := NewConfig()
+
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	configFile := config.defineFlags(fs)
+	fs.StringVar(&config.ServeAddr, "addr", DefaultServeAddr, "Address to listen on")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+
+	var excluded []string
+	fs.Visit(func(f *flag.Flag) {
+		for _, name := range serveExcluded {
+			if f.Name != name {
+				continue
+			}
+			if len(name) == 1 {
+				excluded = append(excluded, "-"+name)
+			} else {
+				excluded = append(excluded, "--"+name)
+			}
+		}
+	})
+	if len(excluded) > 0 {
+		return nil, fmt.Errorf("%s cannot be used with serve, which renders HTML in memory", strings.Join(excluded, ", "))
+	}
+
+	config.applyConfigFile(fs, *configFile)
+	config.OutputFile, config.SplitDir, config.Watch = "", "", false
+	config.Format = "html"
+	if config.ServeAddr == "" {
+		config.ServeAddr = DefaultServeAddr
+	}
+	return config, nil
+}
+
+// IsLoopback reports whether the serve address only accepts connections
+// from this machine
+func (c *Config) IsLoopback() bool {
+	host, _, err := net.SplitHostPort(c.ServeAddr)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// PrintServeUsage prints usage information for the serve subcommand
+func PrintServeUsage() {
+	fmt.Printf(`graphqls-to-asciidoc serve - Preview the documentation in a browser
+
+USAGE:
+    graphqls-to-asciidoc serve [OPTIONS]
+
+Renders the documentation as a single HTML page in memory and serves it. When a
+schema file changes, or a file matching the pattern is added or removed, the
+page is regenerated and open browsers reload it; so it is when the config file
+or a template changes. Schema errors are shown in the browser until fixed.
+
+REQUIRED (choose one):
+    -s, --schema PATH       Path to the GraphQL schema file or a .zip or .tar.gz archive
+    -p, --pattern PATTERN   Pattern to match multiple GraphQL schema files
+        --introspection PATH
+                            Introspection query result (JSON with a __schema object)
+
+OPTIONS:
+        --addr HOST:PORT    Address to listen on (default: %s). Only loopback
+                            addresses keep the preview private to this machine
+    -h, --help              Show this help information
+
+Every other option of the main command, such as --title, --examples or
+--inc-deprecated, is accepted too, as is the configuration file. --output,
+--split-dir, --format and --watch are not: the output is always HTML, served
+from memory.
+
+EXAMPLES:
+    # Preview the documentation at http://127.0.0.1:8080/
+    graphqls-to-asciidoc serve -p "schemas/**/*.graphqls"
+
+    # Use another port, with example operations
+    graphqls-to-asciidoc serve -s schema.graphql --addr 127.0.0.1:3000 --examples
+`, DefaultServeAddr)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseServeFlags(t *testing.T) {
+	cfg, err := ParseServeFlags([]string{"-s", "schema.graphqls", "--examples", "--title", "Preview"})
+	if err != nil {
+		t.Fatalf("ParseServeFlags() returned error: %v", err)
+	}
+	if cfg.SchemaFile != "schema.graphqls" || !cfg.Examples || cfg.Title != "Preview" {
+		t.Errorf("Generation flags were not parsed: %+v", cfg)
+	}
+	if cfg.ServeAddr != DefaultServeAddr || cfg.Format != "html" {
+		t.Errorf("Expected address %q and html format, got %q and %q", DefaultServeAddr, cfg.ServeAddr, cfg.Format)
+	}
+
+	for _, args := range [][]string{
+		{"-s", "schema.graphqls", "-o", "api.html"},
+		{"-s", "schema.graphqls", "--split-dir", "site"},
+		{"-s", "schema.graphqls", "--format", "markdown"},
+		{"-s", "schema.graphqls", "--watch"},
+		{"-s", "schema.graphqls", "extra"},
+		{"--unknown"},
+	} {
+		if _, err := ParseServeFlags(args); err == nil {
+			t.Errorf("Expected an error for %v", args)
+		}
+	}
+}
+
+func TestParseServeFlagsIgnoresFileOutput(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "config.yaml")
+	content := "schema: schema.graphqls\noutput: api.adoc\nformat: markdown\naddr: 127.0.0.1:3000\n"
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := ParseServeFlags([]string{"--config", file})
+	if err != nil {
+		t.Fatalf("ParseServeFlags() returned error: %v", err)
+	}
+	if cfg.SchemaFile != filepath.Join(dir, "schema.graphqls") || cfg.ServeAddr != "127.0.0.1:3000" {
+		t.Errorf("Expected schema and address from the file, got %q and %q", cfg.SchemaFile, cfg.ServeAddr)
+	}
+	if cfg.OutputFile != "" || cfg.Format != "html" {
+		t.Errorf("Expected output settings of the file to be ignored, got output %q and format %q",
+			cfg.OutputFile, cfg.Format)
+	}
+}
+
+func TestIsLoopback(t *testing.T) {
+	for addr, expected := range map[string]bool{
+		"127.0.0.1:8080": true,
+		"localhost:8080": true,
+		"[::1]:8080":     true,
+		"0.0.0.0:8080":   false,
+		":8080":          false,
+		"192.168.1.2:80": false,
+		"invalid":        false,
+	} {
+		cfg := &Config{ServeAddr: addr}
+		if got := cfg.IsLoopback(); got != expected {
+			t.Errorf("IsLoopback(%q) = %v, want %v", addr, got, expected)
+		}
+	}
+}
@@ -0,0 +1,538 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// parseTOML parses a TOML configuration file into the same node tree a YAML
+// file produces, so both are decoded, checked for unknown keys and have their
+// paths resolved by the same code. Keys and values keep their line numbers.
+// Dates and times, which the settings only ever hold as text, become strings.
+func parseTOML(content string) (*yaml.Node, error) {
+	p := &tomlParser{src: content, line: 1}
+	root := mappingNode(1)
+	table := root
+	defined := make(map[*yaml.Node]bool)
+
+	for {
+		p.skipBlank()
+		if p.eof() {
+			return root, nil
+		}
+
+		if p.peek() == '[' {
+			var err error
+			if table, err = p.parseTableHeader(root, defined); err != nil {
+				return nil, err
+			}
+		} else if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+
+		p.skipSpace()
+		p.skipComment()
+		if !p.eof() && !p.consumeNewline() {
+			return nil, p.errorf("expected the end of the line, found '%c'", p.peek())
+		}
+	}
+}
+
+// tomlKey is one part of a dotted TOML key
+type tomlKey struct {
+	name string
+	line int
+}
+
+type tomlParser struct {
+	src  string
+	pos  int
+	line int
+}
+
+func mappingNode(line int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: line}
+}
+
+func scalarNode(tag, value string, line int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value, Line: line}
+}
+
+func (p *tomlParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %d: "+format, append([]interface{}{p.line}, args...)...)
+}
+
+func (p *tomlParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tomlParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.src[p.pos]
+}
+
+func (p *tomlParser) skipSpace() {
+	for !p.eof() && (p.peek() == ' ' || p.peek() == '\t') {
+		p.pos++
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if p.peek() != '#' {
+		return
+	}
+	for !p.eof() && p.peek() != '\n' {
+		p.pos++
+	}
+}
+
+func (p *tomlParser) consumeNewline() bool {
+	if strings.HasPrefix(p.src[p.pos:], "\r\n") {
+		p.pos += 2
+	} else if p.peek() == '\n' {
+		p.pos++
+	} else {
+		return false
+	}
+	p.line++
+	return true
+}
+
+// skipBlank skips whitespace, newlines and comments
+func (p *tomlParser) skipBlank() {
+	for {
+		p.skipSpace()
+		p.skipComment()
+		if !p.consumeNewline() {
+			return
+		}
+	}
+}
+
+// parseTableHeader reads a [table] or [[array.of.tables]] header and returns
+// the mapping that the following key/value pairs belong to
+func (p *tomlParser) parseTableHeader(root *yaml.Node, defined map[*yaml.Node]bool) (*yaml.Node, error) {
+	isArray := strings.HasPrefix(p.src[p.pos:], "[[")
+	p.pos++
+	if isArray {
+		p.pos++
+	}
+
+	p.skipSpace()
+	keys, err := p.parseKey()
+	if err != nil {
+		return nil, err
+	}
+	closing := "]"
+	if isArray {
+		closing = "]]"
+	}
+	if !strings.HasPrefix(p.src[p.pos:], closing) {
+		return nil, p.errorf("expected '%s' after the table name", closing)
+	}
+	p.pos += len(closing)
+
+	parent, err := p.descend(root, keys[:len(keys)-1])
+	if err != nil {
+		return nil, err
+	}
+	last := keys[len(keys)-1]
+	existing := lookup(parent, last.name)
+
+	if isArray {
+		if existing == nil {
+			existing = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: last.line}
+			appendPair(parent, last, existing)
+		} else if existing.Kind != yaml.SequenceNode {
+			return nil, p.errorf("'%s' is already defined and is not an array of tables", last.name)
+		}
+		table := mappingNode(last.line)
+		existing.Content = append(existing.Content, table)
+		return table, nil
+	}
+
+	if existing == nil {
+		existing = mappingNode(last.line)
+		appendPair(parent, last, existing)
+	} else if existing.Kind != yaml.MappingNode || defined[existing] {
+		return nil, p.errorf("table '%s' is already defined", last.name)
+	}
+	defined[existing] = true
+	return existing, nil
+}
+
+// parseKeyValue reads a key = value pair into table
+func (p *tomlParser) parseKeyValue(table *yaml.Node) error {
+	keys, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if p.peek() != '=' {
+		return p.errorf("expected '=' after key '%s'", keys[len(keys)-1].name)
+	}
+	p.pos++
+	p.skipSpace()
+
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+
+	parent, err := p.descend(table, keys[:len(keys)-1])
+	if err != nil {
+		return err
+	}
+	last := keys[len(keys)-1]
+	if lookup(parent, last.name) != nil {
+		return p.errorf("duplicate key '%s'", last.name)
+	}
+	appendPair(parent, last, value)
+	return nil
+}
+
+// descend follows the tables named by keys from table, creating the missing
+// ones. An array of tables stands for its last table.
+func (p *tomlParser) descend(table *yaml.Node, keys []tomlKey) (*yaml.Node, error) {
+	for _, key := range keys {
+		next := lookup(table, key.name)
+		if next == nil {
+			next = mappingNode(key.line)
+			appendPair(table, key, next)
+		}
+		if next.Kind == yaml.SequenceNode && len(next.Content) > 0 {
+			next = next.Content[len(next.Content)-1]
+		}
+		if next.Kind != yaml.MappingNode {
+			return nil, p.errorf("'%s' is not a table", key.name)
+		}
+		table = next
+	}
+	return table, nil
+}
+
+func lookup(table *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(table.Content); i += 2 {
+		if table.Content[i].Value == name {
+			return table.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func appendPair(table *yaml.Node, key tomlKey, value *yaml.Node) {
+	table.Content = append(table.Content, scalarNode("!!str", key.name, key.line), value)
+}
+
+// parseKey reads a bare, quoted or dotted key and the spaces after it
+func (p *tomlParser) parseKey() ([]tomlKey, error) {
+	var keys []tomlKey
+	for {
+		var name string
+		switch c := p.peek(); {
+		case c == '"':
+			s, err := p.parseBasicString()
+			if err != nil {
+				return nil, err
+			}
+			name = s
+		case c == '\'':
+			s, err := p.parseLiteralString()
+			if err != nil {
+				return nil, err
+			}
+			name = s
+		default:
+			start := p.pos
+			for !p.eof() && isBareKeyChar(p.peek()) {
+				p.pos++
+			}
+			if start == p.pos {
+				return nil, p.errorf("expected a key, found '%c'", c)
+			}
+			name = p.src[start:p.pos]
+		}
+		keys = append(keys, tomlKey{name: name, line: p.line})
+
+		p.skipSpace()
+		if p.peek() != '.' {
+			return keys, nil
+		}
+		p.pos++
+		p.skipSpace()
+	}
+}
+
+func isBareKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-'
+}
+
+// parseValue reads a string, number, boolean, date, array or inline table
+func (p *tomlParser) parseValue() (*yaml.Node, error) {
+	line := p.line
+	switch {
+	case strings.HasPrefix(p.src[p.pos:], `"""`):
+		s, err := p.parseMultilineString(`"""`)
+		return scalarNode("!!str", s, line), err
+	case strings.HasPrefix(p.src[p.pos:], "'''"):
+		s, err := p.parseMultilineString("'''")
+		return scalarNode("!!str", s, line), err
+	case p.peek() == '"':
+		s, err := p.parseBasicString()
+		return scalarNode("!!str", s, line), err
+	case p.peek() == '\'':
+		s, err := p.parseLiteralString()
+		return scalarNode("!!str", s, line), err
+	case p.peek() == '[':
+		return p.parseArray()
+	case p.peek() == '{':
+		return p.parseInlineTable()
+	}
+
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\r\n#,]}", rune(p.peek())) {
+		p.pos++
+	}
+	// A local date-time may separate the date and time with a space
+	if p.pos-start == len("2006-01-02") && p.peek() == ' ' && p.pos+1 < len(p.src) && isDigit(p.src[p.pos+1]) {
+		p.pos++
+		for !p.eof() && !strings.ContainsRune(" \t\r\n#,]}", rune(p.peek())) {
+			p.pos++
+		}
+	}
+	return p.scalar(p.src[start:p.pos], line)
+}
+
+// scalar converts a bare value to a node tagged as YAML would resolve it
+func (p *tomlParser) scalar(raw string, line int) (*yaml.Node, error) {
+	switch raw {
+	case "":
+		return nil, p.errorf("expected a value")
+	case "true", "false":
+		return scalarNode("!!bool", raw, line), nil
+	case "inf", "+inf":
+		return scalarNode("!!float", ".inf", line), nil
+	case "-inf":
+		return scalarNode("!!float", "-.inf", line), nil
+	case "nan", "+nan", "-nan":
+		return scalarNode("!!float", ".nan", line), nil
+	}
+
+	if isDateTime(raw) {
+		return scalarNode("!!str", raw, line), nil
+	}
+	if n, err := strconv.ParseInt(raw, 0, 64); err == nil {
+		return scalarNode("!!int", strconv.FormatInt(n, 10), line), nil
+	}
+	if f, err := strconv.ParseFloat(strings.ReplaceAll(raw, "_", ""), 64); err == nil && isDigit(raw[len(raw)-1]) {
+		return scalarNode("!!float", strconv.FormatFloat(f, 'g', -1, 64), line), nil
+	}
+	return nil, p.errorf("invalid value '%s'", raw)
+}
+
+// isDateTime reports whether a bare value is a date, a time or both
+func isDateTime(raw string) bool {
+	if len(raw) >= len("2006-01-02") && raw[4] == '-' && raw[7] == '-' {
+		return true
+	}
+	return len(raw) >= len("15:04") && raw[2] == ':' && isDigit(raw[0])
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parseArray reads an array, which may span lines and end with a comma
+func (p *tomlParser) parseArray() (*yaml.Node, error) {
+	node := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Line: p.line}
+	p.pos++
+	for {
+		p.skipBlank()
+		if p.peek() == ']' {
+			p.pos++
+			return node, nil
+		}
+		if p.eof() {
+			return nil, p.errorf("unterminated array")
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, value)
+
+		p.skipBlank()
+		switch {
+		case p.peek() == ',':
+			p.pos++
+		case p.peek() == ']':
+		case p.eof():
+			return nil, p.errorf("unterminated array")
+		default:
+			return nil, p.errorf("expected ',' or ']' in array")
+		}
+	}
+}
+
+// parseInlineTable reads a { key = value, ... } table on a single line
+func (p *tomlParser) parseInlineTable() (*yaml.Node, error) {
+	node := mappingNode(p.line)
+	p.pos++
+	p.skipSpace()
+	if p.peek() == '}' {
+		p.pos++
+		return node, nil
+	}
+	for {
+		if err := p.parseKeyValue(node); err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		switch p.peek() {
+		case ',':
+			p.pos++
+			p.skipSpace()
+		case '}':
+			p.pos++
+			return node, nil
+		default:
+			return nil, p.errorf("expected ',' or '}' in inline table")
+		}
+	}
+}
+
+// parseLiteralString reads a '...' string, which has no escapes
+func (p *tomlParser) parseLiteralString() (string, error) {
+	p.pos++
+	end := strings.IndexAny(p.src[p.pos:], "'\n")
+	if end < 0 || p.src[p.pos+end] != '\'' {
+		return "", p.errorf("unterminated string")
+	}
+	s := p.src[p.pos : p.pos+end]
+	p.pos += end + 1
+	return s, nil
+}
+
+// parseBasicString reads a "..." string with escapes
+func (p *tomlParser) parseBasicString() (string, error) {
+	p.pos++
+	var b strings.Builder
+	for {
+		if p.eof() || p.peek() == '\n' {
+			return "", p.errorf("unterminated string")
+		}
+		c := p.peek()
+		if c == '"' {
+			p.pos++
+			return b.String(), nil
+		}
+		if c == '\\' {
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+			continue
+		}
+		b.WriteByte(c)
+		p.pos++
+	}
+}
+
+// parseMultilineString reads a multi-line basic or literal string, which ends
+// at the same three quotes it starts with. A newline straight after the
+// opening quotes is dropped and, in basic strings, a backslash at the end of
+// a line also drops the whitespace that follows it.
+func (p *tomlParser) parseMultilineString(quotes string) (string, error) {
+	p.pos += len(quotes)
+	p.consumeNewline()
+
+	var b strings.Builder
+	for {
+		if p.eof() {
+			return "", p.errorf("unterminated multi-line string")
+		}
+		if strings.HasPrefix(p.src[p.pos:], quotes) {
+			// Up to two quotes may come right before the closing ones
+			extra := 0
+			for extra < 2 && strings.HasPrefix(p.src[p.pos+extra+1:], quotes) {
+				extra++
+			}
+			b.WriteString(p.src[p.pos : p.pos+extra])
+			p.pos += extra + len(quotes)
+			return b.String(), nil
+		}
+
+		c := p.peek()
+		switch {
+		case c == '\n' || strings.HasPrefix(p.src[p.pos:], "\r\n"):
+			p.consumeNewline()
+			b.WriteByte('\n')
+		case c == '\\' && quotes == `"""` && p.isLineEndingBackslash():
+			p.pos++
+			for !p.eof() && strings.ContainsRune(" \t\r\n", rune(p.peek())) {
+				if !p.consumeNewline() {
+					p.pos++
+				}
+			}
+		case c == '\\' && quotes == `"""`:
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+}
+
+// isLineEndingBackslash reports whether the backslash at the current position
+// is followed only by whitespace up to the end of the line
+func (p *tomlParser) isLineEndingBackslash() bool {
+	rest := strings.TrimLeft(p.src[p.pos+1:], " \t")
+	return strings.HasPrefix(rest, "\n") || strings.HasPrefix(rest, "\r\n")
+}
+
+// parseEscape reads a backslash escape sequence into b
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	p.pos++
+	c := p.peek()
+	p.pos++
+	switch c {
+	case 'b':
+		b.WriteByte('\b')
+	case 't':
+		b.WriteByte('\t')
+	case 'n':
+		b.WriteByte('\n')
+	case 'f':
+		b.WriteByte('\f')
+	case 'r':
+		b.WriteByte('\r')
+	case 'e':
+		b.WriteByte('\x1b')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		size := 4
+		if c == 'U' {
+			size = 8
+		}
+		if p.pos+size > len(p.src) {
+			return p.errorf("invalid escape '\\%c'", c)
+		}
+		code, err := strconv.ParseUint(p.src[p.pos:p.pos+size], 16, 32)
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid escape '\\%c%s'", c, p.src[p.pos:p.pos+size])
+		}
+		b.WriteRune(rune(code))
+		p.pos += size
+	default:
+		return p.errorf("invalid escape '\\%c'", c)
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseTOML(t *testing.T) {
+	root, err := parseTOML(`# Project settings
+schema = "schema.graphql"   # trailing comment
+'sub-title' = 'C:\Docs'
+title = "Orders \"API\" \u00e9"
+example-depth = 1_000
+mutations = false
+preamble = """
+first
+  second \
+    continued"""
+banner = '''
+raw \n'''
+filters.include = ["order*",
+  "user*", # comment inside an array
+]
+filters.exclude = []
+
+[attributes]
+toc = "right"
+experimental = ""
+
+[scalar-samples]
+Money = 9.99
+Hex = 0x1F
+DateTime = 2030-06-01T12:00:00Z
+LocalDate = 2030-06-01
+Spaced = 2030-06-01 12:00:00
+Inline = { a = 1, b.c = "x" }
+`)
+	if err != nil {
+		t.Fatalf("parseTOML returned error: %v", err)
+	}
+
+	expected := map[string]struct {
+		tag, value string
+		line       int
+	}{
+		"schema":                    {"!!str", "schema.graphql", 2},
+		"sub-title":                 {"!!str", `C:\Docs`, 3},
+		"title":                     {"!!str", `Orders "API" é`, 4},
+		"example-depth":             {"!!int", "1000", 5},
+		"mutations":                 {"!!bool", "false", 6},
+		"preamble":                  {"!!str", "first\n  second continued", 7},
+		"banner":                    {"!!str", `raw \n`, 11},
+		"attributes.toc":            {"!!str", "right", 19},
+		"attributes.experimental":   {"!!str", "", 20},
+		"scalar-samples.Money":      {"!!float", "9.99", 23},
+		"scalar-samples.Hex":        {"!!int", "31", 24},
+		"scalar-samples.DateTime":   {"!!str", "2030-06-01T12:00:00Z", 25},
+		"scalar-samples.LocalDate":  {"!!str", "2030-06-01", 26},
+		"scalar-samples.Spaced":     {"!!str", "2030-06-01 12:00:00", 27},
+		"scalar-samples.Inline.b.c": {"!!str", "x", 28},
+	}
+	for path, want := range expected {
+		node := tomlPath(root, path)
+		if node == nil {
+			t.Errorf("%s: not found", path)
+			continue
+		}
+		if node.Tag != want.tag || node.Value != want.value || node.Line != want.line {
+			t.Errorf("%s: expected %s %q on line %d, got %s %q on line %d",
+				path, want.tag, want.value, want.line, node.Tag, node.Value, node.Line)
+		}
+	}
+
+	include := tomlPath(root, "filters.include")
+	if include == nil || include.Kind != yaml.SequenceNode || len(include.Content) != 2 {
+		t.Errorf("Expected a two-element filters.include array, got %+v", include)
+	}
+	if exclude := tomlPath(root, "filters.exclude"); exclude == nil || len(exclude.Content) != 0 {
+		t.Errorf("Expected an empty filters.exclude array, got %+v", exclude)
+	}
+}
+
+func TestParseTOMLArrayOfTables(t *testing.T) {
+	root, err := parseTOML("[[servers]]\nname = \"a\"\n[[servers]]\nname = \"b\"\n[servers.tls]\nenabled = true\n")
+	if err != nil {
+		t.Fatalf("parseTOML returned error: %v", err)
+	}
+	servers := tomlPath(root, "servers")
+	if servers == nil || servers.Kind != yaml.SequenceNode || len(servers.Content) != 2 {
+		t.Fatalf("Expected two servers, got %+v", servers)
+	}
+	if tls := tomlPath(servers.Content[1], "tls.enabled"); tls == nil || tls.Value != "true" {
+		t.Errorf("Expected [servers.tls] to belong to the last server, got %+v", servers.Content[1])
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "missing equals", content: "schema \"a\"\n", want: "line 1: expected '='"},
+		{name: "missing value", content: "\nschema =\n", want: "line 2: expected a value"},
+		{name: "invalid value", content: "queries = maybe\n", want: "invalid value 'maybe'"},
+		{name: "unterminated string", content: "title = \"Orders\n", want: "unterminated string"},
+		{name: "unterminated array", content: "filters.include = [\"a\"", want: "unterminated array"},
+		{name: "invalid escape", content: "title = \"\\q\"\n", want: "invalid escape"},
+		{name: "duplicate key", content: "title = \"a\"\ntitle = \"b\"\n", want: "line 2: duplicate key 'title'"},
+		{name: "duplicate table", content: "[filters]\n[filters]\n", want: "table 'filters' is already defined"},
+		{name: "key below a value", content: "title = \"a\"\ntitle.sub = \"b\"\n", want: "'title' is not a table"},
+		{name: "two values on a line", content: "title = \"a\" queries = true\n", want: "expected the end of the line"},
+		{name: "unclosed table", content: "[filters\n", want: "expected ']'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := parseTOML(tc.content)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.want) {
+				t.Errorf("Expected error to contain %q, got: %v", tc.want, err)
+			}
+		})
+	}
+}
+
+// tomlPath returns the node at a dotted path of mapping keys
+func tomlPath(node *yaml.Node, path string) *yaml.Node {
+	for _, name := range strings.Split(path, ".") {
+		if node = lookup(node, name); node == nil {
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,230 @@
+// Package coverage measures how much of a schema is documented. Every type,
+// field, argument and enum value is an element that should have a
+// description; each description is also scored for completeness with the
+// parser's DescriptionMetrics.
+package coverage
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// Kind is the kind of a documented element
+type Kind string
+
+const (
+	TypeKind      Kind = "type"
+	FieldKind     Kind = "field"
+	ArgumentKind  Kind = "argument"
+	EnumValueKind Kind = "enum value"
+)
+
+// Kinds lists the element kinds in report order
+var Kinds = []Kind{TypeKind, FieldKind, ArgumentKind, EnumValueKind}
+
+// Element is a schema element that should be documented
+type Element struct {
+	Kind Kind `json:"kind"`
+	// Path names the element, e.g. "User", "User.email",
+	// "Query.users(limit)" or "Role.ADMIN"
+	Path string `json:"path"`
+	// Type is the type the element belongs to, e.g. "User" for "User.email"
+	Type      string `json:"type"`
+	Described bool   `json:"described"`
+	// Completeness is the description's completeness score from 0 to 1
+	Completeness float64 `json:"completeness"`
+	WordCount    int     `json:"wordCount"`
+	// Position is where the element is defined in the schema source
+	Position *ast.Position `json:"-"`
+}
+
+// Summary totals the elements of one kind or one type
+type Summary struct {
+	Name      string `json:"name"`
+	Total     int    `json:"total"`
+	Described int    `json:"described"`
+	// Coverage is the percentage of elements with a description
+	Coverage float64 `json:"coverage"`
+	// Completeness is the average completeness score of the elements, from
+	// 0 to 1; elements without a description score 0
+	Completeness float64 `json:"completeness"`
+}
+
+// Report lists the documented elements of a schema, in type name order and
+// then in definition order
+type Report struct {
+	Elements []Element
+}
+
+// Analyse returns the documentation coverage of a schema. Types, their
+// fields and field arguments, input fields and enum values are counted. An
+// argument without a description of its own is documented when its field's
+// description covers it.
+// The query, mutation and subscription types are represented by their
+// fields only, since their own descriptions are rarely shown. Built-in
+// scalars and introspection types are ignored.
+func Analyse(schema *ast.Schema) *Report {
+	a := &analyser{descriptions: parser.NewDescriptionParser()}
+
+	names := make([]string, 0, len(schema.Types))
+	for name := range schema.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		def := schema.Types[name]
+		if strings.HasPrefix(name, "__") || builtinScalars[name] {
+			continue
+		}
+		if !isRootType(schema, name) {
+			a.add(TypeKind, name, name, def.Description, def.Position)
+		}
+		for _, f := range def.Fields {
+			fieldPath := name + "." + f.Name
+			a.add(FieldKind, fieldPath, name, f.Description, f.Position)
+			for _, arg := range f.Arguments {
+				description := arg.Description
+				if strings.TrimSpace(description) == "" {
+					description = a.argumentDoc(f.Description, arg.Name)
+				}
+				a.add(ArgumentKind, fieldPath+"("+arg.Name+")", name, description, arg.Position)
+			}
+		}
+		for _, v := range def.EnumValues {
+			a.add(EnumValueKind, name+"."+v.Name, name, v.Description, v.Position)
+		}
+	}
+	return &Report{Elements: a.elements}
+}
+
+// builtinScalars are defined by the GraphQL specification, not the schema
+var builtinScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+type analyser struct {
+	descriptions *parser.DescriptionParser
+	elements     []Element
+}
+
+func (a *analyser) add(kind Kind, path, typeName, description string, pos *ast.Position) {
+	e := Element{
+		Kind: kind, Path: path, Type: typeName, Described: strings.TrimSpace(description) != "", Position: pos,
+	}
+	if e.Described {
+		if metrics := a.descriptions.ParseDescription(description).Metrics; metrics != nil {
+			e.Completeness = metrics.Completeness
+			e.WordCount = metrics.WordCount
+		}
+	}
+	a.elements = append(a.elements, e)
+}
+
+// argumentItem matches a "- `name`: ..." list item documenting an argument
+var argumentItem = regexp.MustCompile("(?m)^[ \\t]*[-*][ \\t]+`(?P<name>[_A-Za-z][_0-9A-Za-z]*)`[ \\t]*:?(?P<doc>.*)$")
+
+// argumentDoc returns the documentation of an argument in its field's
+// description, where arguments are commonly described with "@param name"
+// or in a "- `name`: ..." list, or "" if the argument is not mentioned or
+// its list item holds nothing but the name
+func (a *analyser) argumentDoc(fieldDescription, name string) string {
+	if parsed := a.descriptions.ParseDescription(fieldDescription); parsed.Structured != nil {
+		for _, p := range parsed.Structured.Parameters {
+			if p.Name == name {
+				return p.Description
+			}
+		}
+	}
+
+	for _, m := range argumentItem.FindAllStringSubmatch(fieldDescription, -1) {
+		if m[argumentItem.SubexpIndex("name")] == name {
+			return strings.TrimSpace(m[argumentItem.SubexpIndex("doc")])
+		}
+	}
+	return ""
+}
+
+func isRootType(schema *ast.Schema, name string) bool {
+	for _, root := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		if root != nil && root.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Coverage returns the percentage of elements with a description. A schema
+// without elements is fully covered.
+func (r *Report) Coverage() float64 {
+	return summarise("total", r.Elements).Coverage
+}
+
+// Total summarises every element
+func (r *Report) Total() Summary {
+	return summarise("total", r.Elements)
+}
+
+// ByKind summarises the elements of each kind, in the order of Kinds.
+// Kinds without elements are included with full coverage.
+func (r *Report) ByKind() []Summary {
+	summaries := make([]Summary, len(Kinds))
+	for i, kind := range Kinds {
+		var elements []Element
+		for _, e := range r.Elements {
+			if e.Kind == kind {
+				elements = append(elements, e)
+			}
+		}
+		summaries[i] = summarise(string(kind), elements)
+	}
+	return summaries
+}
+
+// ByType summarises the elements of each type: the type itself and its
+// fields, arguments and enum values, in type name order
+func (r *Report) ByType() []Summary {
+	var summaries []Summary
+	for start := 0; start < len(r.Elements); {
+		end := start
+		for end < len(r.Elements) && r.Elements[end].Type == r.Elements[start].Type {
+			end++
+		}
+		summaries = append(summaries, summarise(r.Elements[start].Type, r.Elements[start:end]))
+		start = end
+	}
+	return summaries
+}
+
+// Undocumented returns the elements without a description
+func (r *Report) Undocumented() []Element {
+	var elements []Element
+	for _, e := range r.Elements {
+		if !e.Described {
+			elements = append(elements, e)
+		}
+	}
+	return elements
+}
+
+func summarise(name string, elements []Element) Summary {
+	s := Summary{Name: name, Total: len(elements), Coverage: 100}
+	if len(elements) == 0 {
+		return s
+	}
+	var completeness float64
+	for _, e := range elements {
+		if e.Described {
+			s.Described++
+		}
+		completeness += e.Completeness
+	}
+	s.Coverage = 100 * float64(s.Described) / float64(s.Total)
+	s.Completeness = completeness / float64(s.Total)
+	return s
+}
@@ -0,0 +1,187 @@
+package coverage
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+const testSchema = `
+type Query {
+  "Find users. @param limit page size @returns the matching users"
+  users(limit: Int, "Name filter" name: String): [User!]!
+  me: User
+  """
+  Posts of a user.
+
+  - ` + "`author`" + `: the author's id
+  - ` + "`after`" + `
+  """
+  posts(author: ID!, after: String): [String]
+}
+"A registered user"
+type User {
+  "Unique id"
+  id: ID!
+  name: String
+  role: Role
+}
+enum Role {
+  "Full access"
+  ADMIN
+  USER
+}
+scalar DateTime
+`
+
+func buildSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "test", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+func TestAnalyse(t *testing.T) {
+	report := Analyse(buildSchema(t, testSchema))
+
+	var paths []string
+	for _, e := range report.Elements {
+		paths = append(paths, e.Path)
+	}
+	expected := "DateTime Query.users Query.users(limit) Query.users(name) Query.me " +
+		"Query.posts Query.posts(author) Query.posts(after) " +
+		"Role Role.ADMIN Role.USER User User.id User.name User.role"
+	if got := strings.Join(paths, " "); got != expected {
+		t.Errorf("Expected elements\n%s\ngot\n%s", expected, got)
+	}
+
+	total := report.Total()
+	if total.Total != 15 || total.Described != 8 || total.Coverage != 800.0/15 {
+		t.Errorf("Unexpected total: %+v", total)
+	}
+
+	kinds := report.ByKind()
+	for i, want := range []Summary{
+		{Name: "type", Total: 3, Described: 1},
+		{Name: "field", Total: 6, Described: 3},
+		{Name: "argument", Total: 4, Described: 3},
+		{Name: "enum value", Total: 2, Described: 1},
+	} {
+		if kinds[i].Name != want.Name || kinds[i].Total != want.Total || kinds[i].Described != want.Described {
+			t.Errorf("Expected kind summary %+v, got %+v", want, kinds[i])
+		}
+	}
+
+	types := report.ByType()
+	if len(types) != 4 || types[1].Name != "Query" || types[1].Total != 7 || types[1].Described != 5 {
+		t.Errorf("Unexpected type summaries: %+v", types)
+	}
+
+	// Arguments can be documented in their field's description, though not
+	// by a list item holding only the name
+	for _, e := range report.Elements[2:8] {
+		if e.Described != (e.Path != "Query.me" && e.Path != "Query.posts(after)") {
+			t.Errorf("Unexpected described state of %s: %v", e.Path, e.Described)
+		}
+	}
+
+	// The structured operation description scores higher than a plain one
+	users, id := report.Elements[1], report.Elements[12]
+	if users.Completeness <= id.Completeness || id.Completeness == 0 {
+		t.Errorf("Expected users (%v) to be more complete than User.id (%v)", users.Completeness, id.Completeness)
+	}
+
+	if undocumented := report.Undocumented(); len(undocumented) != 7 || undocumented[0].Path != "DateTime" {
+		t.Errorf("Unexpected undocumented elements: %+v", undocumented)
+	}
+}
+
+func TestAnalyseEmptySchema(t *testing.T) {
+	report := Analyse(buildSchema(t, "type Query"))
+	if report.Coverage() != 100 || len(report.Elements) != 0 {
+		t.Errorf("Expected full coverage without elements, got %v%%: %+v", report.Coverage(), report.Elements)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Analyse(buildSchema(t, testSchema)).Write(&buf, "TABLE"); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	for _, expected := range []string{"Documentation Coverage", "│ enum value │", "│ total", "53.3%", "│ Query"} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected table to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestWriteTableEmptyKinds(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Analyse(buildSchema(t, "type Query { \"Answer\" a: Int }")).WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable returned error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "│ argument   │     0 │         0 │ -        │ -            │") {
+		t.Errorf("Expected no coverage for a kind without elements, got:\n%s", buf.String())
+	}
+	if !strings.Contains(buf.String(), "100.0%") {
+		t.Errorf("Expected full coverage of the field, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Analyse(buildSchema(t, testSchema)).Write(&buf, JSON); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var decoded jsonReport
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.Described != 8 || decoded.Total != 15 || len(decoded.Kinds) != 4 || len(decoded.Elements) != 15 {
+		t.Errorf("Unexpected JSON report: %+v", decoded)
+	}
+	if !strings.Contains(buf.String(), `"path": "Query.users(limit)"`) {
+		t.Errorf("Expected argument element in JSON, got:\n%s", buf.String())
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Analyse(buildSchema(t, testSchema)).Write(&buf, JUnit); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	var decoded junitSuites
+	if err := xml.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid XML: %v\n%s", err, buf.String())
+	}
+	if decoded.Tests != 15 || decoded.Failures != 7 || len(decoded.Suites) != 4 {
+		t.Errorf("Unexpected test suites: %+v", decoded)
+	}
+	for _, expected := range []string{
+		`<testsuite name="User" tests="4" failures="2">`,
+		`<testcase classname="User" name="User.id"></testcase>`,
+		`<failure message="missing description" type="undocumented">Field User.name has no description</failure>`,
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Errorf("Expected JUnit XML to contain %q, got:\n%s", expected, buf.String())
+		}
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	err := Analyse(buildSchema(t, testSchema)).Write(&bytes.Buffer{}, "csv")
+	if err == nil || !strings.Contains(err.Error(), "json, junit, table") {
+		t.Errorf("Expected error listing supported formats, got: %v", err)
+	}
+}
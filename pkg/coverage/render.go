@@ -0,0 +1,195 @@
+package coverage
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+)
+
+// Report formats, as accepted by --format
+const (
+	Table = "table"
+	JSON  = "json"
+	JUnit = "junit"
+)
+
+// Formats lists the supported report formats
+var Formats = []string{JSON, JUnit, Table}
+
+// IsFormat reports whether name is a supported report format, ignoring case
+func IsFormat(name string) bool {
+	for _, f := range Formats {
+		if strings.EqualFold(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Write writes the report in the named format
+func (r *Report) Write(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case Table:
+		return r.WriteTable(w)
+	case JSON:
+		return r.WriteJSON(w)
+	case JUnit:
+		return r.WriteJUnit(w)
+	}
+	return fmt.Errorf("unsupported coverage format '%s' (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// WriteTable writes two tables: coverage by element kind, then by type
+func (r *Report) WriteTable(w io.Writer) error {
+	kinds := table.NewWriter()
+	kinds.SetOutputMirror(w)
+	kinds.SetStyle(table.StyleRounded)
+	kinds.SetTitle("Documentation Coverage")
+	kinds.AppendHeader(table.Row{"Element", "Total", "Described", "Coverage", "Completeness"})
+	for _, s := range r.ByKind() {
+		kinds.AppendRow(summaryRow(s))
+	}
+	kinds.AppendSeparator()
+	kinds.AppendRow(summaryRow(r.Total()))
+	kinds.Render()
+
+	if _, err := fmt.Fprintln(w); err != nil {
+		return err
+	}
+
+	types := table.NewWriter()
+	types.SetOutputMirror(w)
+	types.SetStyle(table.StyleRounded)
+	types.AppendHeader(table.Row{"Type", "Total", "Described", "Coverage", "Completeness"})
+	for _, s := range r.ByType() {
+		types.AppendRow(summaryRow(s))
+	}
+	types.Render()
+	return nil
+}
+
+// summaryRow returns a table row; a summary without elements has no
+// coverage to show
+func summaryRow(s Summary) table.Row {
+	if s.Total == 0 {
+		return table.Row{s.Name, s.Total, s.Described, "-", "-"}
+	}
+	return table.Row{
+		s.Name, s.Total, s.Described,
+		fmt.Sprintf("%.1f%%", s.Coverage), fmt.Sprintf("%.0f%%", 100*s.Completeness),
+	}
+}
+
+// jsonReport is the JSON form of a report
+type jsonReport struct {
+	Coverage     float64   `json:"coverage"`
+	Completeness float64   `json:"completeness"`
+	Total        int       `json:"total"`
+	Described    int       `json:"described"`
+	Kinds        []Summary `json:"kinds"`
+	Types        []Summary `json:"types"`
+	Elements     []Element `json:"elements"`
+}
+
+// WriteJSON writes the summaries and every element as indented JSON
+func (r *Report) WriteJSON(w io.Writer) error {
+	total := r.Total()
+	elements := r.Elements
+	if elements == nil {
+		elements = []Element{}
+	}
+	types := r.ByType()
+	if types == nil {
+		types = []Summary{}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(jsonReport{
+		Coverage:     total.Coverage,
+		Completeness: total.Completeness,
+		Total:        total.Total,
+		Described:    total.Described,
+		Kinds:        r.ByKind(),
+		Types:        types,
+		Elements:     elements,
+	})
+}
+
+// JUnit XML elements, as read by CI servers
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Name     string       `xml:"name,attr"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		ClassName string        `xml:"classname,attr"`
+		Name      string        `xml:"name,attr"`
+		Failure   *junitFailure `xml:"failure,omitempty"`
+	}
+	junitFailure struct {
+		Message string `xml:"message,attr"`
+		Type    string `xml:"type,attr"`
+		Text    string `xml:",chardata"`
+	}
+)
+
+// WriteJUnit writes the report as JUnit XML: one test suite per type and one
+// test case per element, failing when the element has no description
+func (r *Report) WriteJUnit(w io.Writer) error {
+	total := r.Total()
+	suites := junitSuites{
+		Name:     "Documentation coverage",
+		Tests:    total.Total,
+		Failures: total.Total - total.Described,
+	}
+	for _, s := range r.ByType() {
+		suite := junitSuite{Name: s.Name, Tests: s.Total, Failures: s.Total - s.Described}
+		for _, e := range r.Elements {
+			if e.Type != s.Name {
+				continue
+			}
+			c := junitCase{ClassName: e.Type, Name: e.Path}
+			if !e.Described {
+				c.Failure = &junitFailure{
+					Message: "missing description",
+					Type:    "undocumented",
+					Text:    fmt.Sprintf("%s %s has no description", capitalise(string(e.Kind)), e.Path),
+				}
+			}
+			suite.Cases = append(suite.Cases, c)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
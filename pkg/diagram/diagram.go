@@ -0,0 +1,332 @@
+// Package diagram draws class diagrams of the types in a schema in PlantUML
+// or Mermaid syntax. Objects, interfaces, unions, enums and input types are
+// drawn with their fields; every field that refers to another drawn type
+// becomes an edge, labelled with the field name.
+package diagram
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Supported diagram formats, as accepted by --diagram
+const (
+	PlantUML = "plantuml"
+	Mermaid  = "mermaid"
+)
+
+// Formats lists the supported diagram formats
+var Formats = []string{Mermaid, PlantUML}
+
+// IsFormat reports whether name is a supported diagram format, ignoring case
+func IsFormat(name string) bool {
+	for _, f := range Formats {
+		if strings.EqualFold(name, f) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diagram is a class diagram of a set of schema types
+type Diagram struct {
+	schema *ast.Schema
+	types  []*ast.Definition // in name order
+	drawn  map[string]bool
+}
+
+// New returns a diagram of every object, interface, union, enum and input
+// type in the schema. The query, mutation and subscription types are left
+// out: they are documented as operations and would connect to nearly
+// everything.
+func New(schema *ast.Schema) *Diagram {
+	var names []string
+	for name, def := range schema.Types {
+		if drawable(schema, def) {
+			names = append(names, name)
+		}
+	}
+	return newDiagram(schema, names)
+}
+
+// Reachable returns a diagram of the types reachable from a root field: its
+// return type, the types of its arguments and, transitively, the types of
+// their fields and arguments, the members of unions and the implementations
+// of interfaces. root is a field name such as "users", looked up on the
+// query, mutation and subscription types in that order, or a qualified name
+// such as "Mutation.createUser".
+func Reachable(schema *ast.Schema, root string) (*Diagram, error) {
+	field, err := rootField(schema, root)
+	if err != nil {
+		return nil, err
+	}
+
+	queue := []string{field.Type.Name()}
+	for _, arg := range field.Arguments {
+		queue = append(queue, arg.Type.Name())
+	}
+
+	seen := make(map[string]bool)
+	var names []string
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		def := schema.Types[name]
+		if seen[name] || def == nil || !drawable(schema, def) {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		queue = append(queue, references(schema, def)...)
+	}
+	return newDiagram(schema, names), nil
+}
+
+func newDiagram(schema *ast.Schema, names []string) *Diagram {
+	sort.Strings(names)
+	d := &Diagram{schema: schema, drawn: make(map[string]bool, len(names))}
+	for _, name := range names {
+		d.types = append(d.types, schema.Types[name])
+		d.drawn[name] = true
+	}
+	return d
+}
+
+// rootField finds the field a scoped diagram starts from
+func rootField(schema *ast.Schema, root string) (*ast.FieldDefinition, error) {
+	if typeName, fieldName, ok := strings.Cut(root, "."); ok {
+		if def := schema.Types[typeName]; def != nil {
+			if f := def.Fields.ForName(fieldName); f != nil {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("diagram root '%s' does not exist in the schema", root)
+	}
+
+	for _, def := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		if def == nil {
+			continue
+		}
+		if f := def.Fields.ForName(root); f != nil {
+			return f, nil
+		}
+	}
+	return nil, fmt.Errorf("diagram root '%s' is not a query, mutation or subscription field", root)
+}
+
+// drawable reports whether a type belongs in a diagram
+func drawable(schema *ast.Schema, def *ast.Definition) bool {
+	if strings.HasPrefix(def.Name, "__") || isRootType(schema, def.Name) {
+		return false
+	}
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject:
+		return true
+	}
+	return false
+}
+
+func isRootType(schema *ast.Schema, name string) bool {
+	for _, root := range []*ast.Definition{schema.Query, schema.Mutation, schema.Subscription} {
+		if root != nil && root.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// references returns the names of the types a type refers to
+func references(schema *ast.Schema, def *ast.Definition) []string {
+	var names []string
+	for _, f := range def.Fields {
+		names = append(names, f.Type.Name())
+		for _, arg := range f.Arguments {
+			names = append(names, arg.Type.Name())
+		}
+	}
+	names = append(names, def.Interfaces...)
+	names = append(names, def.Types...)
+	if def.Kind == ast.Interface {
+		names = append(names, implementations(schema, def.Name)...)
+	}
+	return names
+}
+
+// implementations returns the sorted names of the types implementing an
+// interface
+func implementations(schema *ast.Schema, iface string) []string {
+	var names []string
+	for name, def := range schema.Types {
+		for _, i := range def.Interfaces {
+			if i == iface {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Len returns the number of types in the diagram
+func (d *Diagram) Len() int {
+	return len(d.types)
+}
+
+// edge is a line between two types
+type edge struct {
+	from, to string
+	kind     edgeKind
+	label    string
+	many     bool // the field holds a list
+}
+
+type edgeKind int
+
+const (
+	association    edgeKind = iota // a field of the type
+	dependency                     // an argument of a field
+	realisation                    // an implemented interface
+	generalisation                 // a union member
+)
+
+// edges returns the lines between the drawn types, in type and field order
+func (d *Diagram) edges() []edge {
+	var edges []edge
+	for _, def := range d.types {
+		for _, iface := range def.Interfaces {
+			if d.drawn[iface] {
+				edges = append(edges, edge{from: iface, to: def.Name, kind: realisation})
+			}
+		}
+		for _, member := range def.Types {
+			if d.drawn[member] {
+				edges = append(edges, edge{from: def.Name, to: member, kind: generalisation})
+			}
+		}
+		for _, f := range def.Fields {
+			if d.drawn[f.Type.Name()] {
+				edges = append(edges, edge{
+					from: def.Name, to: f.Type.Name(), kind: association, label: f.Name, many: f.Type.Elem != nil,
+				})
+			}
+			for _, arg := range f.Arguments {
+				if d.drawn[arg.Type.Name()] {
+					edges = append(edges, edge{
+						from: def.Name, to: arg.Type.Name(), kind: dependency,
+						label: fmt.Sprintf("%s(%s)", f.Name, arg.Name),
+					})
+				}
+			}
+		}
+	}
+	return edges
+}
+
+// members returns the lines listed inside a type's box: fields with their
+// types, or the values of an enum
+func members(def *ast.Definition) []string {
+	var lines []string
+	if def.Kind == ast.Enum {
+		for _, v := range def.EnumValues {
+			lines = append(lines, v.Name)
+		}
+		return lines
+	}
+	for _, f := range def.Fields {
+		lines = append(lines, f.Name+": "+f.Type.String())
+	}
+	return lines
+}
+
+// Render returns the diagram source in the named format
+func (d *Diagram) Render(format string) (string, error) {
+	switch strings.ToLower(format) {
+	case PlantUML:
+		return d.plantUML(), nil
+	case Mermaid:
+		return d.mermaid(), nil
+	}
+	return "", fmt.Errorf("unsupported diagram format '%s' (supported: %s)", format, strings.Join(Formats, ", "))
+}
+
+// plantUML writes the diagram as a PlantUML class diagram
+func (d *Diagram) plantUML() string {
+	var b strings.Builder
+	b.WriteString("@startuml\nhide empty members\n")
+	for _, def := range d.types {
+		switch def.Kind {
+		case ast.Interface:
+			fmt.Fprintf(&b, "interface %s", def.Name)
+		case ast.Enum:
+			fmt.Fprintf(&b, "enum %s", def.Name)
+		case ast.Union:
+			fmt.Fprintf(&b, "class %s <<union>>", def.Name)
+		case ast.InputObject:
+			fmt.Fprintf(&b, "class %s <<input>>", def.Name)
+		default:
+			fmt.Fprintf(&b, "class %s", def.Name)
+		}
+		if lines := members(def); len(lines) > 0 {
+			b.WriteString(" {\n")
+			for _, line := range lines {
+				b.WriteString("  " + line + "\n")
+			}
+			b.WriteString("}")
+		}
+		b.WriteString("\n")
+	}
+
+	for _, e := range d.edges() {
+		b.WriteString(relation(e) + "\n")
+	}
+	b.WriteString("@enduml")
+	return b.String()
+}
+
+// mermaid writes the diagram as a Mermaid class diagram
+func (d *Diagram) mermaid() string {
+	stereotypes := map[ast.DefinitionKind]string{
+		ast.Interface:   "interface",
+		ast.Enum:        "enumeration",
+		ast.Union:       "union",
+		ast.InputObject: "input",
+	}
+
+	var b strings.Builder
+	b.WriteString("classDiagram\n")
+	for _, def := range d.types {
+		fmt.Fprintf(&b, "  class %s {\n", def.Name)
+		if stereotype, ok := stereotypes[def.Kind]; ok {
+			fmt.Fprintf(&b, "    <<%s>>\n", stereotype)
+		}
+		for _, line := range members(def) {
+			b.WriteString("    " + line + "\n")
+		}
+		b.WriteString("  }\n")
+	}
+
+	for _, e := range d.edges() {
+		b.WriteString("  " + relation(e) + "\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// relation writes an edge; PlantUML and Mermaid share the arrow syntax
+func relation(e edge) string {
+	switch e.kind {
+	case realisation:
+		return fmt.Sprintf("%s <|.. %s", e.from, e.to)
+	case generalisation:
+		return fmt.Sprintf("%s <|-- %s", e.from, e.to)
+	case dependency:
+		return fmt.Sprintf("%s ..> %s : %s", e.from, e.to, e.label)
+	}
+	if e.many {
+		return fmt.Sprintf(`%s --> "*" %s : %s`, e.from, e.to, e.label)
+	}
+	return fmt.Sprintf("%s --> %s : %s", e.from, e.to, e.label)
+}
@@ -0,0 +1,146 @@
+package diagram
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+const testSchema = `
+type Query {
+  me: User
+  search(term: String!, filter: PostFilter): [SearchResult!]!
+  node(id: ID!): Node
+  version: String
+}
+type Mutation { ping: Boolean }
+interface Node { id: ID! }
+union SearchResult = User | Post
+type User implements Node { id: ID! name: String posts(filter: PostFilter): [Post!]! role: Role }
+type Post implements Node { id: ID! title: String author: User! }
+type Audit { at: String }
+enum Role { ADMIN USER }
+input PostFilter { role: Role since: String }
+scalar DateTime
+`
+
+func buildSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "test", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+func TestNewDrawsAllTypesExceptRoots(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+	source, err := New(schema).Render(PlantUML)
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"@startuml\nhide empty members\n",
+		"class Audit {\n  at: String\n}",
+		"interface Node {\n  id: ID!\n}",
+		"enum Role {\n  ADMIN\n  USER\n}",
+		"class PostFilter <<input>> {\n  role: Role\n  since: String\n}",
+		"class SearchResult <<union>>\n",
+		"class User {\n  id: ID!\n  name: String\n  posts: [Post!]!\n  role: Role\n}",
+		"Node <|.. User\n",
+		"SearchResult <|-- Post\n",
+		"User --> \"*\" Post : posts\n",
+		"User ..> PostFilter : posts(filter)\n",
+		"Post --> User : author\n",
+		"PostFilter --> Role : role\n",
+		"@enduml",
+	} {
+		if !strings.Contains(source, expected) {
+			t.Errorf("Expected diagram to contain %q, got:\n%s", expected, source)
+		}
+	}
+	for _, absent := range []string{"Query", "Mutation", "DateTime", "__"} {
+		if strings.Contains(source, absent) {
+			t.Errorf("Diagram should not contain %q, got:\n%s", absent, source)
+		}
+	}
+}
+
+func TestReachable(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+	testCases := []struct {
+		root     string
+		expected []string
+	}{
+		{root: "me", expected: []string{"Node", "Post", "PostFilter", "Role", "User"}},
+		{root: "Query.search", expected: []string{"Node", "Post", "PostFilter", "Role", "SearchResult", "User"}},
+		// An interface reaches its implementations
+		{root: "node", expected: []string{"Node", "Post", "PostFilter", "Role", "User"}},
+		{root: "version", expected: nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.root, func(t *testing.T) {
+			d, err := Reachable(schema, tc.root)
+			if err != nil {
+				t.Fatalf("Reachable returned error: %v", err)
+			}
+			var names []string
+			for _, def := range d.types {
+				names = append(names, def.Name)
+			}
+			if strings.Join(names, ",") != strings.Join(tc.expected, ",") {
+				t.Errorf("Expected types %v, got %v", tc.expected, names)
+			}
+		})
+	}
+
+	for _, root := range []string{"missing", "Query.missing", "Missing.me"} {
+		if _, err := Reachable(schema, root); err == nil {
+			t.Errorf("Expected error for root %q", root)
+		}
+	}
+}
+
+func TestRenderMermaid(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+	d, err := Reachable(schema, "me")
+	if err != nil {
+		t.Fatalf("Reachable returned error: %v", err)
+	}
+	source, err := d.Render("Mermaid")
+	if err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"classDiagram\n",
+		"  class Node {\n    <<interface>>\n    id: ID!\n  }",
+		"  class Role {\n    <<enumeration>>\n    ADMIN\n    USER\n  }",
+		"  class PostFilter {\n    <<input>>\n",
+		"  Node <|.. Post\n",
+		"  User --> \"*\" Post : posts\n",
+	} {
+		if !strings.Contains(source, expected) {
+			t.Errorf("Expected diagram to contain %q, got:\n%s", expected, source)
+		}
+	}
+	if strings.HasSuffix(source, "\n") {
+		t.Error("Diagram source should not end with a newline")
+	}
+}
+
+func TestRenderUnsupportedFormat(t *testing.T) {
+	_, err := New(buildSchema(t, testSchema)).Render("graphviz")
+	if err == nil || !strings.Contains(err.Error(), "mermaid, plantuml") {
+		t.Errorf("Expected error listing supported formats, got: %v", err)
+	}
+	if !IsFormat("PlantUML") || IsFormat("graphviz") {
+		t.Error("IsFormat should accept supported formats in any case only")
+	}
+}
@@ -0,0 +1,408 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity classifies a change by its effect on existing clients
+type Severity int
+
+const (
+	// NonBreaking changes keep every existing operation valid
+	NonBreaking Severity = iota
+	// Breaking changes can make existing operations fail validation or
+	// return data clients do not expect
+	Breaking
+)
+
+func (s Severity) String() string {
+	if s == Breaking {
+		return "breaking"
+	}
+	return "non-breaking"
+}
+
+// Action is what happened to a schema element
+type Action string
+
+const (
+	Added   Action = "Added"
+	Removed Action = "Removed"
+	Changed Action = "Changed"
+)
+
+// Change is a single difference between two schemas
+type Change struct {
+	Severity Severity
+	Action   Action
+	// Path names the element, e.g. "User", "User.email",
+	// "Query.users(limit)" or "Role.ADMIN"
+	Path string
+	// Description explains the change in a sentence, with names in backticks
+	Description string
+}
+
+// Report lists the differences between two schemas, sorted by path
+type Report struct {
+	Changes []Change
+}
+
+// Breaking returns the breaking changes
+func (r *Report) Breaking() []Change {
+	return r.filter(Breaking)
+}
+
+// NonBreaking returns the non-breaking changes
+func (r *Report) NonBreaking() []Change {
+	return r.filter(NonBreaking)
+}
+
+func (r *Report) filter(severity Severity) []Change {
+	var changes []Change
+	for _, c := range r.Changes {
+		if c.Severity == severity {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// Compare returns the changes from the old schema to the new one: added,
+// removed and changed types, fields, arguments, input fields, enum values,
+// union members and implemented interfaces, including nullability changes.
+// Built-in scalars and introspection types are ignored.
+func Compare(oldSchema, newSchema *ast.Schema) *Report {
+	c := &comparer{}
+
+	for _, name := range typeNames(oldSchema, newSchema) {
+		oldDef, newDef := oldSchema.Types[name], newSchema.Types[name]
+		switch {
+		case oldDef == nil:
+			c.add(NonBreaking, Added, name, "%s `%s` was added.", kindName(newDef.Kind), name)
+		case newDef == nil:
+			c.add(Breaking, Removed, name, "%s `%s` was removed.", kindName(oldDef.Kind), name)
+		case oldDef.Kind != newDef.Kind:
+			c.add(Breaking, Changed, name, "`%s` changed from %s to %s.",
+				name, strings.ToLower(kindName(oldDef.Kind)), strings.ToLower(kindName(newDef.Kind)))
+		default:
+			c.compareDefinition(oldDef, newDef)
+		}
+	}
+
+	sort.SliceStable(c.changes, func(i, j int) bool {
+		return c.changes[i].Path < c.changes[j].Path
+	})
+	return &Report{Changes: c.changes}
+}
+
+// comparer collects changes while walking two schemas
+type comparer struct {
+	changes []Change
+}
+
+func (c *comparer) add(severity Severity, action Action, path, format string, args ...interface{}) {
+	c.changes = append(c.changes, Change{
+		Severity:    severity,
+		Action:      action,
+		Path:        path,
+		Description: fmt.Sprintf(format, args...),
+	})
+}
+
+// compareDefinition compares two definitions of the same name and kind
+func (c *comparer) compareDefinition(oldDef, newDef *ast.Definition) {
+	switch oldDef.Kind {
+	case ast.Object, ast.Interface:
+		c.compareOutputFields(oldDef, newDef)
+		c.compareNames(oldDef.Name, "interface", oldDef.Interfaces, newDef.Interfaces)
+	case ast.InputObject:
+		c.compareInputFields(oldDef, newDef)
+	case ast.Union:
+		c.compareNames(oldDef.Name, "member", oldDef.Types, newDef.Types)
+	case ast.Enum:
+		c.compareEnumValues(oldDef, newDef)
+	}
+}
+
+// compareOutputFields compares the fields of object and interface types.
+// Output fields may become stricter (nullable to non-null) but not looser.
+func (c *comparer) compareOutputFields(oldDef, newDef *ast.Definition) {
+	kind := strings.ToLower(kindName(oldDef.Kind))
+	for _, name := range fieldNames(oldDef.Fields, newDef.Fields) {
+		oldField, newField := oldDef.Fields.ForName(name), newDef.Fields.ForName(name)
+		path := oldDef.Name + "." + name
+		switch {
+		case oldField == nil:
+			c.add(NonBreaking, Added, path, "Field `%s` was added to %s `%s`.", name, kind, oldDef.Name)
+		case newField == nil:
+			c.add(Breaking, Removed, path, "Field `%s` was removed from %s `%s`.", name, kind, oldDef.Name)
+		default:
+			c.compareType(path, "Field", oldField.Type, newField.Type, isSafeOutputChange)
+			c.compareDeprecation(path, "Field", oldField.Directives, newField.Directives)
+			c.compareArguments(path, oldField.Arguments, newField.Arguments)
+		}
+	}
+}
+
+// compareInputFields compares the fields of input types. Input fields may
+// become looser (non-null to nullable) but not stricter, and new required
+// fields break existing operations.
+func (c *comparer) compareInputFields(oldDef, newDef *ast.Definition) {
+	for _, name := range fieldNames(oldDef.Fields, newDef.Fields) {
+		oldField, newField := oldDef.Fields.ForName(name), newDef.Fields.ForName(name)
+		path := oldDef.Name + "." + name
+		switch {
+		case oldField == nil && isRequired(newField.Type, newField.DefaultValue):
+			c.add(Breaking, Added, path, "Required input field `%s` was added to `%s`.", name, oldDef.Name)
+		case oldField == nil:
+			c.add(NonBreaking, Added, path, "Optional input field `%s` was added to `%s`.", name, oldDef.Name)
+		case newField == nil:
+			c.add(Breaking, Removed, path, "Input field `%s` was removed from `%s`.", name, oldDef.Name)
+		default:
+			c.compareType(path, "Input field", oldField.Type, newField.Type, isSafeInputChange)
+			c.compareDefault(path, "Input field", oldField.DefaultValue, newField.DefaultValue)
+		}
+	}
+}
+
+// compareArguments compares the arguments of a field
+func (c *comparer) compareArguments(fieldPath string, oldArgs, newArgs ast.ArgumentDefinitionList) {
+	for _, name := range argumentNames(oldArgs, newArgs) {
+		oldArg, newArg := oldArgs.ForName(name), newArgs.ForName(name)
+		path := fieldPath + "(" + name + ")"
+		switch {
+		case oldArg == nil && isRequired(newArg.Type, newArg.DefaultValue):
+			c.add(Breaking, Added, path, "Required argument `%s` was added to `%s`.", name, fieldPath)
+		case oldArg == nil:
+			c.add(NonBreaking, Added, path, "Optional argument `%s` was added to `%s`.", name, fieldPath)
+		case newArg == nil:
+			c.add(Breaking, Removed, path, "Argument `%s` was removed from `%s`.", name, fieldPath)
+		default:
+			c.compareType(path, "Argument", oldArg.Type, newArg.Type, isSafeInputChange)
+			c.compareDefault(path, "Argument", oldArg.DefaultValue, newArg.DefaultValue)
+		}
+	}
+}
+
+// compareEnumValues compares enum values; clients may not handle new values
+// exhaustively, but existing operations stay valid, so additions are
+// non-breaking
+func (c *comparer) compareEnumValues(oldDef, newDef *ast.Definition) {
+	var oldNames, newNames []string
+	for _, v := range oldDef.EnumValues {
+		oldNames = append(oldNames, v.Name)
+	}
+	for _, v := range newDef.EnumValues {
+		newNames = append(newNames, v.Name)
+	}
+	c.compareNames(oldDef.Name, "value", oldNames, newNames)
+
+	for _, oldValue := range oldDef.EnumValues {
+		if newValue := newDef.EnumValues.ForName(oldValue.Name); newValue != nil {
+			c.compareDeprecation(oldDef.Name+"."+oldValue.Name, "Enum value", oldValue.Directives, newValue.Directives)
+		}
+	}
+}
+
+// compareNames compares lists of names on a definition: union members,
+// implemented interfaces or enum values. Additions are non-breaking and
+// removals breaking.
+func (c *comparer) compareNames(defName, what string, oldNames, newNames []string) {
+	oldSet, newSet := toSet(oldNames), toSet(newNames)
+	for _, name := range sortedUnion(oldNames, newNames) {
+		path := defName + "." + name
+		if what == "interface" || what == "member" {
+			path = defName + " " + what + " " + name
+		}
+		switch {
+		case !oldSet[name]:
+			c.add(NonBreaking, Added, path, "%s `%s` was added to `%s`.", capitalise(what), name, defName)
+		case !newSet[name]:
+			c.add(Breaking, Removed, path, "%s `%s` was removed from `%s`.", capitalise(what), name, defName)
+		}
+	}
+}
+
+// typeCheck reports whether a type change keeps existing clients working
+type typeCheck func(oldType, newType *ast.Type) bool
+
+// compareType reports a type change, classified by isSafe
+func (c *comparer) compareType(path, what string, oldType, newType *ast.Type, isSafe typeCheck) {
+	if oldType.String() == newType.String() {
+		return
+	}
+
+	severity := Breaking
+	if isSafe(oldType, newType) {
+		severity = NonBreaking
+	}
+	change := "type"
+	if sameNamedType(oldType, newType) {
+		change = "nullability"
+	}
+	c.add(severity, Changed, path, "%s `%s` changed %s from `%s` to `%s`.",
+		what, path, change, oldType.String(), newType.String())
+}
+
+// compareDefault reports a changed default value; defaults only apply when
+// a value is omitted, so the change is non-breaking
+func (c *comparer) compareDefault(path, what string, oldValue, newValue *ast.Value) {
+	oldDefault, newDefault := valueString(oldValue), valueString(newValue)
+	if oldDefault == newDefault {
+		return
+	}
+	switch {
+	case oldDefault == "":
+		c.add(NonBreaking, Changed, path, "%s `%s` now defaults to `%s`.", what, path, newDefault)
+	case newDefault == "":
+		c.add(NonBreaking, Changed, path, "%s `%s` no longer has a default (was `%s`).", what, path, oldDefault)
+	default:
+		c.add(NonBreaking, Changed, path, "%s `%s` default changed from `%s` to `%s`.", what, path, oldDefault, newDefault)
+	}
+}
+
+// compareDeprecation reports newly deprecated and undeprecated elements
+func (c *comparer) compareDeprecation(path, what string, oldDirectives, newDirectives ast.DirectiveList) {
+	wasDeprecated := oldDirectives.ForName("deprecated") != nil
+	isDeprecated := newDirectives.ForName("deprecated") != nil
+	switch {
+	case !wasDeprecated && isDeprecated:
+		c.add(NonBreaking, Changed, path, "%s `%s` was deprecated.", what, path)
+	case wasDeprecated && !isDeprecated:
+		c.add(NonBreaking, Changed, path, "%s `%s` is no longer deprecated.", what, path)
+	}
+}
+
+// isSafeOutputChange reports whether an output type change keeps existing
+// clients working: the new type may only add non-null wrappers
+func isSafeOutputChange(oldType, newType *ast.Type) bool {
+	switch {
+	case oldType.NonNull && !newType.NonNull:
+		return false
+	case newType.NonNull && !oldType.NonNull:
+		stripped := *newType
+		stripped.NonNull = false
+		return isSafeOutputChange(oldType, &stripped)
+	}
+	return sameShape(oldType, newType, isSafeOutputChange)
+}
+
+// isSafeInputChange reports whether an input type change keeps existing
+// operations valid: the new type may only drop non-null wrappers
+func isSafeInputChange(oldType, newType *ast.Type) bool {
+	return isSafeOutputChange(newType, oldType)
+}
+
+// sameShape compares the list and named parts of two types with equal
+// nullability, using isSafe for list elements
+func sameShape(oldType, newType *ast.Type, isSafe typeCheck) bool {
+	if (oldType.Elem == nil) != (newType.Elem == nil) {
+		return false
+	}
+	if oldType.Elem != nil {
+		return isSafe(oldType.Elem, newType.Elem)
+	}
+	return oldType.NamedType == newType.NamedType
+}
+
+// sameNamedType reports whether two types differ only in nullability
+func sameNamedType(oldType, newType *ast.Type) bool {
+	strip := func(s string) string { return strings.ReplaceAll(s, "!", "") }
+	return strip(oldType.String()) == strip(newType.String())
+}
+
+// isRequired reports whether an argument or input field must be supplied
+func isRequired(t *ast.Type, defaultValue *ast.Value) bool {
+	return t.NonNull && defaultValue == nil
+}
+
+func valueString(v *ast.Value) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// builtinScalars are defined by the GraphQL specification, not the schema
+var builtinScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+// typeNames returns the sorted names of all user-defined types in either
+// schema
+func typeNames(oldSchema, newSchema *ast.Schema) []string {
+	var names []string
+	for _, schema := range []*ast.Schema{oldSchema, newSchema} {
+		for name := range schema.Types {
+			if !strings.HasPrefix(name, "__") && !builtinScalars[name] {
+				names = append(names, name)
+			}
+		}
+	}
+	return sortedUnion(names, nil)
+}
+
+func fieldNames(oldFields, newFields ast.FieldList) []string {
+	var names []string
+	for _, f := range append(append(ast.FieldList{}, oldFields...), newFields...) {
+		names = append(names, f.Name)
+	}
+	return sortedUnion(names, nil)
+}
+
+func argumentNames(oldArgs, newArgs ast.ArgumentDefinitionList) []string {
+	var names []string
+	for _, a := range append(append(ast.ArgumentDefinitionList{}, oldArgs...), newArgs...) {
+		names = append(names, a.Name)
+	}
+	return sortedUnion(names, nil)
+}
+
+// sortedUnion returns the sorted, de-duplicated names from both lists
+func sortedUnion(a, b []string) []string {
+	set := toSet(append(append([]string{}, a...), b...))
+	names := make([]string, 0, len(set))
+	for name := range set {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func toSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// kindName returns the GraphQL keyword-style name of a definition kind
+func kindName(kind ast.DefinitionKind) string {
+	switch kind {
+	case ast.Object:
+		return "Type"
+	case ast.Interface:
+		return "Interface"
+	case ast.Union:
+		return "Union"
+	case ast.Enum:
+		return "Enum"
+	case ast.InputObject:
+		return "Input"
+	case ast.Scalar:
+		return "Scalar"
+	}
+	return string(kind)
+}
+
+func capitalise(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
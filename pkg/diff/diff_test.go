@@ -0,0 +1,225 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+func buildSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "test", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+// findChange returns the change for path, failing the test if there is none
+func findChange(t *testing.T, report *Report, path string) Change {
+	t.Helper()
+	for _, c := range report.Changes {
+		if c.Path == path {
+			return c
+		}
+	}
+	t.Fatalf("expected a change for %s, got %+v", path, report.Changes)
+	return Change{}
+}
+
+func TestCompare(t *testing.T) {
+	testCases := []struct {
+		name     string
+		old      string
+		new      string
+		path     string
+		severity Severity
+		action   Action
+	}{
+		{
+			name: "type added", old: "type A { a: Int }", new: "type A { a: Int } type B { b: Int }",
+			path: "B", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "type removed", old: "type A { a: Int } type B { b: Int }", new: "type A { a: Int }",
+			path: "B", severity: Breaking, action: Removed,
+		},
+		{
+			name: "kind changed", old: "type A { a: Int }", new: "input A { a: Int }",
+			path: "A", severity: Breaking, action: Changed,
+		},
+		{
+			name: "field added", old: "type A { a: Int }", new: "type A { a: Int b: Int }",
+			path: "A.b", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "field removed", old: "type A { a: Int b: Int }", new: "type A { a: Int }",
+			path: "A.b", severity: Breaking, action: Removed,
+		},
+		{
+			name: "output field becomes non-null", old: "type A { a: Int }", new: "type A { a: Int! }",
+			path: "A.a", severity: NonBreaking, action: Changed,
+		},
+		{
+			name: "output field becomes nullable", old: "type A { a: Int! }", new: "type A { a: Int }",
+			path: "A.a", severity: Breaking, action: Changed,
+		},
+		{
+			name: "output list element becomes non-null", old: "type A { a: [Int] }", new: "type A { a: [Int!]! }",
+			path: "A.a", severity: NonBreaking, action: Changed,
+		},
+		{
+			name: "output field type changed", old: "type A { a: Int }", new: "type A { a: String }",
+			path: "A.a", severity: Breaking, action: Changed,
+		},
+		{
+			name: "required argument added", old: "type Query { a: Int }", new: "type Query { a(x: Int!): Int }",
+			path: "Query.a(x)", severity: Breaking, action: Added,
+		},
+		{
+			name: "optional argument added", old: "type Query { a: Int }", new: "type Query { a(x: Int!= 1): Int }",
+			path: "Query.a(x)", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "argument removed", old: "type Query { a(x: Int): Int }", new: "type Query { a: Int }",
+			path: "Query.a(x)", severity: Breaking, action: Removed,
+		},
+		{
+			name: "argument becomes non-null", old: "type Query { a(x: Int): Int }", new: "type Query { a(x: Int!): Int }",
+			path: "Query.a(x)", severity: Breaking, action: Changed,
+		},
+		{
+			name: "argument becomes nullable", old: "type Query { a(x: Int!): Int }", new: "type Query { a(x: Int): Int }",
+			path: "Query.a(x)", severity: NonBreaking, action: Changed,
+		},
+		{
+			name: "argument default changed", old: "type Query { a(x: Int = 1): Int }", new: "type Query { a(x: Int = 2): Int }",
+			path: "Query.a(x)", severity: NonBreaking, action: Changed,
+		},
+		{
+			name: "required input field added", old: "input I { a: Int }", new: "input I { a: Int b: Int! }",
+			path: "I.b", severity: Breaking, action: Added,
+		},
+		{
+			name: "optional input field added", old: "input I { a: Int }", new: "input I { a: Int b: Int }",
+			path: "I.b", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "enum value added", old: "enum E { A }", new: "enum E { A B }",
+			path: "E.B", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "enum value removed", old: "enum E { A B }", new: "enum E { A }",
+			path: "E.B", severity: Breaking, action: Removed,
+		},
+		{
+			name: "union member removed", old: "type A { a: Int } type B { b: Int } union U = A | B",
+			new: "type A { a: Int } type B { b: Int } union U = A", path: "U member B", severity: Breaking, action: Removed,
+		},
+		{
+			name: "interface added", old: "interface N { id: ID } type A { id: ID }",
+			new: "interface N { id: ID } type A implements N { id: ID }", path: "A interface N", severity: NonBreaking, action: Added,
+		},
+		{
+			name: "field deprecated", old: "type A { a: Int }", new: "type A { a: Int @deprecated }",
+			path: "A.a", severity: NonBreaking, action: Changed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			report := Compare(buildSchema(t, tc.old), buildSchema(t, tc.new))
+			change := findChange(t, report, tc.path)
+			if change.Severity != tc.severity {
+				t.Errorf("Expected %s change, got %s: %s", tc.severity, change.Severity, change.Description)
+			}
+			if change.Action != tc.action {
+				t.Errorf("Expected action %s, got %s", tc.action, change.Action)
+			}
+		})
+	}
+}
+
+func TestCompareIdenticalSchemas(t *testing.T) {
+	sdl := `
+type Query { users(limit: Int = 10): [User!]! }
+type User { id: ID! name: String }
+extend type User { email: String }
+enum Role { ADMIN USER }
+`
+	report := Compare(buildSchema(t, sdl), buildSchema(t, sdl))
+	if len(report.Changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", report.Changes)
+	}
+}
+
+func TestCompareExtensions(t *testing.T) {
+	report := Compare(
+		buildSchema(t, "type User { id: ID! }"),
+		buildSchema(t, "type User { id: ID! }\nextend type User { email: String }"),
+	)
+	change := findChange(t, report, "User.email")
+	if change.Action != Added {
+		t.Errorf("Fields from type extensions should be compared, got %+v", change)
+	}
+}
+
+func TestNullabilityDescription(t *testing.T) {
+	report := Compare(buildSchema(t, "type A { a: Int! }"), buildSchema(t, "type A { a: Int }"))
+	expected := "Field `A.a` changed nullability from `Int!` to `Int`."
+	if got := findChange(t, report, "A.a").Description; got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestWriteAsciiDoc(t *testing.T) {
+	report := Compare(
+		buildSchema(t, "type Query { a: Int b: Int }"),
+		buildSchema(t, "type Query { a: Int c: Int }"),
+	)
+
+	var buf bytes.Buffer
+	err := report.WriteAsciiDoc(&buf, DocumentData{OldSchema: "v1.graphqls", NewSchema: "v2.graphqls", RevDate: "today"})
+	if err != nil {
+		t.Fatalf("WriteAsciiDoc() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContent := []string{
+		"= What's Changed\n",
+		":old-schema: v1.graphqls",
+		"* *1* breaking change\n",
+		"* *1* non-breaking change\n",
+		"== Breaking Changes\n",
+		"| Removed | Query.b | Field `b` was removed from type `Query`.",
+		"== Non-breaking Changes\n",
+		"| Added | Query.c | Field `c` was added to type `Query`.",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+}
+
+func TestWriteAsciiDocNoChanges(t *testing.T) {
+	var buf bytes.Buffer
+	if err := (&Report{}).WriteAsciiDoc(&buf, DocumentData{Title: "Release 2.0"}); err != nil {
+		t.Fatalf("WriteAsciiDoc() returned error: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{"= Release 2.0\n", "No breaking changes.", "No non-breaking changes."} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "|===") {
+		t.Errorf("Output should not contain empty tables, got:\n%s", output)
+	}
+}
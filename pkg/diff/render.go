@@ -0,0 +1,86 @@
+package diff
+
+import (
+	"io"
+	"strings"
+	"text/template"
+)
+
+// DefaultTitle is the title of the changes document when none is given
+const DefaultTitle = "What's Changed"
+
+// DocumentData is passed to the report template
+type DocumentData struct {
+	Title       string
+	OldSchema   string
+	NewSchema   string
+	RevDate     string
+	Breaking    []Change
+	NonBreaking []Change
+}
+
+// reportTemplate renders the "What's changed" document. Each table lists
+// the changes of one severity, sorted by element path.
+const reportTemplate = `= {{.Title}}
+:toc: left
+:revdate: {{.RevDate}}
+:old-schema: {{.OldSchema}}
+:new-schema: {{.NewSchema}}
+:table-caption!:
+:table-stripes: even
+
+Changes from ` + "`{old-schema}`" + ` to ` + "`{new-schema}`" + `:
+
+* *{{len .Breaking}}* breaking {{plural (len .Breaking)}}
+* *{{len .NonBreaking}}* non-breaking {{plural (len .NonBreaking)}}
+
+[[breaking_changes]]
+== Breaking Changes
+{{if .Breaking}}
+[WARNING]
+====
+These changes can break existing clients.
+====
+
+{{template "changes" .Breaking}}
+{{- else}}
+No breaking changes.
+{{end}}
+[[non_breaking_changes]]
+== Non-breaking Changes
+{{if .NonBreaking}}
+{{template "changes" .NonBreaking}}
+{{- else}}
+No non-breaking changes.
+{{end -}}
+{{define "changes"}}[options="header",cols="1,2m,5a"]
+|===
+| Change | Element | Description
+{{- range .}}
+| {{.Action}} | {{escape .Path}} | {{escape .Description}}
+{{- end}}
+|===
+{{end}}`
+
+var reportTmpl = template.Must(template.New("diff").Funcs(template.FuncMap{
+	"plural": func(n int) string {
+		if n == 1 {
+			return "change"
+		}
+		return "changes"
+	},
+	// Table cells must not contain an unescaped cell separator
+	"escape": func(s string) string {
+		return strings.ReplaceAll(s, "|", `\|`)
+	},
+}).Parse(reportTemplate))
+
+// WriteAsciiDoc writes the report as an AsciiDoc "What's changed" document
+func (r *Report) WriteAsciiDoc(w io.Writer, data DocumentData) error {
+	if data.Title == "" {
+		data.Title = DefaultTitle
+	}
+	data.Breaking = r.Breaking()
+	data.NonBreaking = r.NonBreaking()
+	return reportTmpl.Execute(w, data)
+}
@@ -0,0 +1,243 @@
+// Package example builds runnable example operations for the root fields of a
+// schema: a GraphQL document with variables declared from the field's
+// arguments, placeholder variable values, a selection set expanded to a
+// limited depth, and a plausible response to that selection set.
+package example
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Operation is the example for a single root field
+type Operation struct {
+	// Document is the GraphQL operation
+	Document string
+	// Variables is a JSON object with a value for every variable, or empty
+	// when the field takes no arguments
+	Variables string
+	// Response is a JSON response to Document, with a value for every field
+	// in the selection set
+	Response string
+}
+
+// Builder creates example operations for the fields of a schema
+type Builder struct {
+	schema  *ast.Schema
+	depth   int
+	samples map[string]interface{}
+}
+
+// New returns a Builder expanding selection sets to depth levels; depths
+// below one are treated as one. samples maps scalar names to the value used
+// for them in variables and responses, see ParseSample; it is merged over
+// DefaultSamples.
+func New(schema *ast.Schema, depth int, samples map[string]string) *Builder {
+	if depth < 1 {
+		depth = 1
+	}
+	merged := make(map[string]interface{}, len(DefaultSamples)+len(samples))
+	for name, sample := range DefaultSamples {
+		merged[name] = ParseSample(sample)
+	}
+	for name, sample := range samples {
+		merged[name] = ParseSample(sample)
+	}
+	return &Builder{schema: schema, depth: depth, samples: merged}
+}
+
+// Operation builds the example for a root field. kind is the operation type:
+// "query", "mutation" or "subscription".
+func (b *Builder) Operation(kind string, field *ast.FieldDefinition) Operation {
+	var doc strings.Builder
+	fmt.Fprintf(&doc, "%s %s", kind, operationName(field.Name))
+
+	var variables object
+	if len(field.Arguments) > 0 {
+		declarations := make([]string, 0, len(field.Arguments))
+		arguments := make([]string, 0, len(field.Arguments))
+		for _, arg := range field.Arguments {
+			declaration := fmt.Sprintf("$%s: %s", arg.Name, arg.Type.String())
+			value := b.inputValue(arg.Type, map[string]bool{})
+			if arg.DefaultValue != nil {
+				declaration += " = " + arg.DefaultValue.String()
+				value = valueToJSON(arg.DefaultValue)
+			}
+			declarations = append(declarations, declaration)
+			arguments = append(arguments, fmt.Sprintf("%s: $%s", arg.Name, arg.Name))
+			variables = append(variables, member{Key: arg.Name, Value: value})
+		}
+		fmt.Fprintf(&doc, "(%s)", strings.Join(declarations, ", "))
+		fmt.Fprintf(&doc, " {\n  %s(%s)", field.Name, strings.Join(arguments, ", "))
+	} else {
+		fmt.Fprintf(&doc, " {\n  %s", field.Name)
+	}
+
+	lines := b.selectionSet(field.Type.Name(), 1, map[string]bool{})
+	writeSelectionSet(&doc, lines, 1)
+	doc.WriteString("\n}")
+
+	op := Operation{Document: doc.String()}
+	op.Response = formatJSON(object{{
+		Key:   "data",
+		Value: object{{Key: field.Name, Value: b.outputValue(field.Type, 1, map[string]bool{})}},
+	}})
+	if len(variables) > 0 {
+		op.Variables = formatJSON(variables)
+	}
+	return op
+}
+
+// operationName derives the operation name from the field name, e.g.
+// "userById" becomes "UserById"
+func operationName(fieldName string) string {
+	r, size := utf8.DecodeRuneInString(fieldName)
+	return string(unicode.ToUpper(r)) + fieldName[size:]
+}
+
+// writeSelectionSet writes a selection set, if any, after the field on the
+// current line. Nested selections are returned as lines ending in "{" or "}".
+func writeSelectionSet(doc *strings.Builder, lines []string, level int) {
+	if len(lines) == 0 {
+		return
+	}
+	doc.WriteString(" {\n")
+	indent := level + 1
+	for _, line := range lines {
+		if line == "}" {
+			indent--
+		}
+		doc.WriteString(strings.Repeat("  ", indent) + line + "\n")
+		if strings.HasSuffix(line, "{") {
+			indent++
+		}
+	}
+	doc.WriteString(strings.Repeat("  ", level) + "}")
+}
+
+// selectionSet returns the selection lines for a field of the named type at
+// the given nesting level. Scalar and enum types have no selection set. path
+// holds the object types being expanded, so that cycles such as
+// User.friends: [User] stop instead of repeating until the depth limit.
+func (b *Builder) selectionSet(typeName string, level int, path map[string]bool) []string {
+	def := b.schema.Types[typeName]
+	if def == nil {
+		return nil
+	}
+
+	switch def.Kind {
+	case ast.Object, ast.Interface:
+		path[typeName] = true
+		defer delete(path, typeName)
+
+		var lines []string
+		for _, f := range b.selectedFields(def, level, path) {
+			if !b.isComposite(f.Type.Name()) {
+				lines = append(lines, f.Name)
+				continue
+			}
+			nested := b.selectionSet(f.Type.Name(), level+1, path)
+			lines = append(lines, f.Name+" {")
+			lines = append(lines, nested...)
+			lines = append(lines, "}")
+		}
+		if len(lines) == 0 {
+			lines = []string{"__typename"}
+		}
+		return lines
+	case ast.Union:
+		lines := []string{"__typename"}
+		for _, name := range def.Types {
+			if path[name] {
+				continue
+			}
+			nested := b.selectionSet(name, level, path)
+			lines = append(lines, "... on "+name+" {")
+			lines = append(lines, nested...)
+			lines = append(lines, "}")
+		}
+		return lines
+	}
+	return nil
+}
+
+// selectedFields returns the fields of an object or interface that examples
+// select at the given level: every scalar and enum field, and object fields
+// while the depth limit allows and their type is not already on path. Fields
+// with required arguments are skipped.
+func (b *Builder) selectedFields(def *ast.Definition, level int, path map[string]bool) []*ast.FieldDefinition {
+	var fields []*ast.FieldDefinition
+	for _, f := range def.Fields {
+		if strings.HasPrefix(f.Name, "__") || hasRequiredArguments(f) {
+			continue
+		}
+		if b.isComposite(f.Type.Name()) && (level >= b.depth || path[f.Type.Name()]) {
+			continue
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// isComposite reports whether the named type needs a selection set
+func (b *Builder) isComposite(typeName string) bool {
+	def := b.schema.Types[typeName]
+	return def != nil && (def.Kind == ast.Object || def.Kind == ast.Interface || def.Kind == ast.Union)
+}
+
+// hasRequiredArguments reports whether a field cannot be selected without
+// supplying arguments
+func hasRequiredArguments(f *ast.FieldDefinition) bool {
+	for _, arg := range f.Arguments {
+		if arg.Type.NonNull && arg.DefaultValue == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// inputValue returns a placeholder value for an argument or input field type.
+// path holds the input types being built; a recursive optional field is left
+// out and a recursive list is left empty so that the value stays finite.
+func (b *Builder) inputValue(t *ast.Type, path map[string]bool) interface{} {
+	if t.Elem != nil {
+		if path[t.Elem.Name()] {
+			return []interface{}{}
+		}
+		return []interface{}{b.inputValue(t.Elem, path)}
+	}
+
+	def := b.schema.Types[t.NamedType]
+	if def == nil {
+		return b.scalarValue(t.NamedType)
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		return enumValue(def)
+	case ast.InputObject:
+		if path[def.Name] {
+			return nil
+		}
+		path[def.Name] = true
+		defer delete(path, def.Name)
+
+		obj := object{}
+		for _, f := range def.Fields {
+			if path[f.Type.Name()] && !f.Type.NonNull {
+				continue
+			}
+			value := b.inputValue(f.Type, path)
+			if f.DefaultValue != nil {
+				value = valueToJSON(f.DefaultValue)
+			}
+			obj = append(obj, member{Key: f.Name, Value: value})
+		}
+		return obj
+	}
+	return b.scalarValue(def.Name)
+}
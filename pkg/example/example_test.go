@@ -0,0 +1,222 @@
+package example
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+const testSchema = `
+type Query {
+  me: User
+  search(term: String!, limit: Int = 10, filter: UserFilter): [SearchResult!]!
+  count: Int!
+}
+union SearchResult = User | Post
+type User {
+  id: ID!
+  name: String
+  friends: [User!]!
+  posts(first: Int): [Post!]!
+  avatar(size: Int!): String
+}
+type Post { id: ID! title: String author: User! created: DateTime role: Role }
+scalar DateTime
+enum Role { ADMIN USER }
+input UserFilter { role: Role since: DateTime and: [UserFilter!] not: UserFilter tags: [String!]! }
+`
+
+func buildSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "test", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+func TestOperation(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	op := New(schema, 2, nil).Operation("query", schema.Query.Fields.ForName("me"))
+
+	expected := `query Me {
+  me {
+    id
+    name
+    posts {
+      id
+      title
+      created
+      role
+    }
+  }
+}`
+	if op.Document != expected {
+		t.Errorf("Expected document:\n%s\ngot:\n%s", expected, op.Document)
+	}
+	if op.Variables != "" {
+		t.Errorf("A field without arguments should have no variables, got %s", op.Variables)
+	}
+}
+
+func TestOperationScalarResult(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	op := New(schema, 2, nil).Operation("query", schema.Query.Fields.ForName("count"))
+	if expected := "query Count {\n  count\n}"; op.Document != expected {
+		t.Errorf("Expected %q, got %q", expected, op.Document)
+	}
+}
+
+func TestOperationVariables(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	op := New(schema, 1, nil).Operation("query", schema.Query.Fields.ForName("search"))
+
+	expectedDocument := []string{
+		"query Search($term: String!, $limit: Int = 10, $filter: UserFilter) {\n",
+		"  search(term: $term, limit: $limit, filter: $filter) {\n",
+		"    __typename\n    ... on User {\n      id\n      name\n    }\n",
+		"    ... on Post {\n      id\n      title\n      created\n      role\n    }\n",
+	}
+	for _, expected := range expectedDocument {
+		if !strings.Contains(op.Document, expected) {
+			t.Errorf("Document should contain %q, got:\n%s", expected, op.Document)
+		}
+	}
+
+	expectedVariables := `{
+  "term": "example",
+  "limit": 10,
+  "filter": {
+    "role": "ADMIN",
+    "since": "2024-01-15T09:30:00Z",
+    "tags": [
+      "example"
+    ]
+  }
+}`
+	if op.Variables != expectedVariables {
+		t.Errorf("Expected variables:\n%s\ngot:\n%s", expectedVariables, op.Variables)
+	}
+}
+
+func TestOperationDepth(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+	me := schema.Query.Fields.ForName("me")
+
+	if doc := New(schema, 1, nil).Operation("query", me).Document; strings.Contains(doc, "posts") {
+		t.Errorf("Depth 1 should not expand object fields, got:\n%s", doc)
+	}
+	// Depths below one are treated as one
+	if doc := New(schema, 0, nil).Operation("query", me).Document; !strings.Contains(doc, "    name\n") {
+		t.Errorf("Depth 0 should still select scalar fields, got:\n%s", doc)
+	}
+}
+
+func TestOperationCycles(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	doc := New(schema, 10, nil).Operation("query", schema.Query.Fields.ForName("me")).Document
+	// friends and Post.author lead back to User, which is already expanded
+	for _, notExpected := range []string{"friends", "author"} {
+		if strings.Contains(doc, notExpected) {
+			t.Errorf("Cyclic field %s should not be expanded, got:\n%s", notExpected, doc)
+		}
+	}
+	// Fields with required arguments cannot be selected without values
+	if strings.Contains(doc, "avatar") {
+		t.Errorf("Field with required arguments should be skipped, got:\n%s", doc)
+	}
+}
+
+func TestValueToJSON(t *testing.T) {
+	doc, err := gqlparser.ParseSchema(&ast.Source{
+		Name:  "test",
+		Input: `input I { v: I = {a: 1, b: 2.5, c: "x", d: [true, null], e: ASC} }`,
+	})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+
+	got := formatJSON(valueToJSON(doc.Definitions[0].Fields[0].DefaultValue))
+	expected := `{
+  "a": 1,
+  "b": 2.5,
+  "c": "x",
+  "d": [
+    true,
+    null
+  ],
+  "e": "ASC"
+}`
+	if got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
+
+func TestOperationResponse(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	op := New(schema, 2, map[string]string{"DateTime": "2030-06-01T12:00:00Z"}).
+		Operation("query", schema.Query.Fields.ForName("search"))
+
+	// The first union member is shown, with the fields its fragment selects
+	expected := `{
+  "data": {
+    "search": [
+      {
+        "__typename": "User",
+        "id": "1",
+        "name": "example",
+        "posts": [
+          {
+            "id": "1",
+            "title": "example",
+            "created": "2030-06-01T12:00:00Z",
+            "role": "ADMIN"
+          }
+        ]
+      }
+    ]
+  }
+}`
+	if op.Response != expected {
+		t.Errorf("Expected response:\n%s\ngot:\n%s", expected, op.Response)
+	}
+}
+
+func TestOperationResponseScalar(t *testing.T) {
+	schema := buildSchema(t, testSchema)
+
+	op := New(schema, 2, nil).Operation("query", schema.Query.Fields.ForName("count"))
+	if expected := "{\n  \"data\": {\n    \"count\": 1\n  }\n}"; op.Response != expected {
+		t.Errorf("Expected %q, got %q", expected, op.Response)
+	}
+}
+
+func TestParseSample(t *testing.T) {
+	testCases := []struct {
+		sample   string
+		expected interface{}
+	}{
+		{"2024-01-15T09:30:00Z", "2024-01-15T09:30:00Z"},
+		{"42", float64(42)},
+		{"true", true},
+		{`"quoted"`, "quoted"},
+		{"null", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.sample, func(t *testing.T) {
+			if got := ParseSample(tc.sample); got != tc.expected {
+				t.Errorf("Expected %#v, got %#v", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package example
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// member is a single key/value pair of an object
+type member struct {
+	Key   string
+	Value interface{}
+}
+
+// object is a JSON object that keeps its keys in schema order, unlike a map
+type object []member
+
+// MarshalJSON writes the members in order
+func (o object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, m := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		key, err := json.Marshal(m.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.Value)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(value)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// formatJSON renders a value as indented JSON
+func formatJSON(v interface{}) string {
+	out, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// valueToJSON converts a GraphQL literal, such as an argument default, to the
+// equivalent JSON value
+func valueToJSON(v *ast.Value) interface{} {
+	switch v.Kind {
+	case ast.IntValue:
+		if n, err := strconv.ParseInt(v.Raw, 10, 64); err == nil {
+			return n
+		}
+		return json.Number(v.Raw)
+	case ast.FloatValue:
+		if f, err := strconv.ParseFloat(v.Raw, 64); err == nil {
+			return f
+		}
+		return json.Number(v.Raw)
+	case ast.BooleanValue:
+		return v.Raw == "true"
+	case ast.NullValue:
+		return nil
+	case ast.ListValue:
+		list := make([]interface{}, 0, len(v.Children))
+		for _, child := range v.Children {
+			list = append(list, valueToJSON(child.Value))
+		}
+		return list
+	case ast.ObjectValue:
+		obj := make(object, 0, len(v.Children))
+		for _, child := range v.Children {
+			obj = append(obj, member{Key: child.Name, Value: valueToJSON(child.Value)})
+		}
+		return obj
+	}
+	// Strings, block strings, enum values and variables
+	return v.Raw
+}
@@ -0,0 +1,114 @@
+package example
+
+import (
+	"encoding/json"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// DefaultSamples are the sample values of common custom scalars. Values are
+// parsed with ParseSample, so "1" is a number and text is a string.
+var DefaultSamples = map[string]string{
+	"Date":          "2024-01-15",
+	"DateTime":      "2024-01-15T09:30:00Z",
+	"LocalDate":     "2024-01-15",
+	"LocalDateTime": "2024-01-15T09:30:00",
+	"LocalTime":     "09:30:00",
+	"Time":          "09:30:00Z",
+	"UUID":          "123e4567-e89b-12d3-a456-426614174000",
+	"URL":           "https://example.com",
+	"Email":         "user@example.com",
+	"Long":          "1",
+	"BigDecimal":    "1.5",
+	"JSON":          "{}",
+}
+
+// builtinSamples are the values of the scalars defined by the specification
+var builtinSamples = map[string]interface{}{
+	"Int":     1,
+	"Float":   1.5,
+	"Boolean": true,
+	"ID":      "1",
+	"String":  "example",
+}
+
+// ParseSample converts a configured sample value to JSON: text that is valid
+// JSON, such as 42, true or {"a": 1}, is used as it is and anything else, such
+// as 2024-01-15T09:30:00Z, becomes a string
+func ParseSample(sample string) interface{} {
+	var value interface{}
+	if err := json.Unmarshal([]byte(sample), &value); err == nil {
+		return value
+	}
+	return sample
+}
+
+// scalarValue returns the sample value of a scalar. Custom scalars without a
+// sample are serialised as strings by most servers, so they get their own
+// name as a reminder of the expected format.
+func (b *Builder) scalarValue(name string) interface{} {
+	if value, ok := b.samples[name]; ok {
+		return value
+	}
+	if value, ok := builtinSamples[name]; ok {
+		return value
+	}
+	return name
+}
+
+// enumValue returns the first value of an enum
+func enumValue(def *ast.Definition) interface{} {
+	if len(def.EnumValues) > 0 {
+		return def.EnumValues[0].Name
+	}
+	return def.Name
+}
+
+// outputValue returns a response value for a field of type t at the given
+// nesting level. It includes exactly the fields selectionSet selects, so the
+// response matches the example operation. Lists hold a single element.
+func (b *Builder) outputValue(t *ast.Type, level int, path map[string]bool) interface{} {
+	if t.Elem != nil {
+		return []interface{}{b.outputValue(t.Elem, level, path)}
+	}
+
+	def := b.schema.Types[t.NamedType]
+	if def == nil {
+		return b.scalarValue(t.NamedType)
+	}
+
+	switch def.Kind {
+	case ast.Enum:
+		return enumValue(def)
+	case ast.Object, ast.Interface:
+		return b.objectValue(def, level, path, false)
+	case ast.Union:
+		// The response shows the first member not already being expanded
+		for _, name := range def.Types {
+			if member := b.schema.Types[name]; member != nil && !path[name] {
+				return b.objectValue(member, level, path, true)
+			}
+		}
+		return object{{Key: "__typename", Value: def.Name}}
+	}
+	return b.scalarValue(def.Name)
+}
+
+// objectValue returns the response object for the selected fields of an
+// object or interface type, starting with __typename when it was selected
+func (b *Builder) objectValue(def *ast.Definition, level int, path map[string]bool, typename bool) object {
+	path[def.Name] = true
+	defer delete(path, def.Name)
+
+	obj := object{}
+	if typename {
+		obj = append(obj, member{Key: "__typename", Value: def.Name})
+	}
+	for _, f := range b.selectedFields(def, level, path) {
+		obj = append(obj, member{Key: f.Name, Value: b.outputValue(f.Type, level+1, path)})
+	}
+	if len(obj) == 0 {
+		obj = append(obj, member{Key: "__typename", Value: def.Name})
+	}
+	return obj
+}
@@ -0,0 +1,67 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Supported output formats, as accepted by --format
+const (
+	AsciiDoc = "asciidoc"
+	HTML     = "html"
+	Markdown = "markdown"
+)
+
+// Renderer writes a document produced by the generator in one output format.
+// The generator always builds AsciiDoc; renderers for other formats convert
+// the constructs it emits (sections, anchors, tables, source blocks,
+// admonitions and cross-references) to their own syntax.
+type Renderer interface {
+	// Render writes doc, a complete generated AsciiDoc document, to w
+	Render(w io.Writer, doc string) error
+	// Extension returns the conventional file extension, including the dot
+	Extension() string
+}
+
+var renderers = map[string]Renderer{
+	AsciiDoc: asciiDocRenderer{},
+	HTML:     htmlRenderer{},
+	Markdown: markdownRenderer{},
+}
+
+// New returns the renderer for the named format. An empty name selects
+// AsciiDoc.
+func New(name string) (Renderer, error) {
+	if name == "" {
+		name = AsciiDoc
+	}
+	r, ok := renderers[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unsupported output format '%s' (supported: %s)", name, strings.Join(Names(), ", "))
+	}
+	return r, nil
+}
+
+// Names returns the sorted names of all supported formats
+func Names() []string {
+	names := make([]string, 0, len(renderers))
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// asciiDocRenderer writes the generated document unchanged
+type asciiDocRenderer struct{}
+
+func (asciiDocRenderer) Render(w io.Writer, doc string) error {
+	_, err := io.WriteString(w, doc)
+	return err
+}
+
+func (asciiDocRenderer) Extension() string {
+	return ".adoc"
+}
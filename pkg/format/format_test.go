@@ -0,0 +1,36 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", "asciidoc", "html", "markdown", "Markdown"} {
+		if _, err := New(name); err != nil {
+			t.Errorf("New(%q) returned error: %v", name, err)
+		}
+	}
+
+	_, err := New("docx")
+	if err == nil {
+		t.Fatal("Expected error for unsupported format")
+	}
+	if !strings.Contains(err.Error(), "asciidoc, html, markdown") {
+		t.Errorf("Error should list supported formats, got: %v", err)
+	}
+}
+
+func TestAsciiDocRendererPassthrough(t *testing.T) {
+	doc := "= Title\n\n== Section\n"
+	r, _ := New(AsciiDoc)
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, doc); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+	if buf.String() != doc {
+		t.Errorf("AsciiDoc renderer should not change the document, got %q", buf.String())
+	}
+}
@@ -0,0 +1,716 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/antora"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/asciidoc"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+var (
+	reXrefMacro      = regexp.MustCompile(`xref:([^\s\[]+)\[([^\]]*)\]`)
+	reBareURL        = regexp.MustCompile(`(^|[\s(])((?:https?|ftp)://[^\s<>\[\]()]+[^\s<>\[\]().,;:!?])`)
+	reEmphasisConstr = regexp.MustCompile(`(^|[^\w_\\])_([^_\s](?:[^_]*[^_\s])?)_([^\w_]|$)`)
+	rePlaceholder    = regexp.MustCompile("\x00(\\d+)\x00")
+	reIDStrip        = regexp.MustCompile(`[^\p{L}\p{N}]+`)
+)
+
+// htmlRenderer converts the generated AsciiDoc to a single self-contained
+// HTML page
+type htmlRenderer struct{}
+
+func (htmlRenderer) Render(w io.Writer, doc string) error {
+	_, err := io.WriteString(w, ToHTML(doc))
+	return err
+}
+
+func (htmlRenderer) Extension() string {
+	return ".html"
+}
+
+// ToHTML converts an AsciiDoc document produced by the generator to a single
+// static HTML page with a sidebar listing the sections, cross-references as
+// links and a client-side search over the names and first sentences of the
+// documented items. Styles, scripts and the search index are embedded, so the
+// page loads nothing over the network. Only the AsciiDoc subset the
+// generator emits is supported, as for ToMarkdown; docs/FORMATS.md lists it.
+func ToHTML(doc string) string {
+	lines := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+	c := newHTMLConverter(lines, "", nil)
+	c.collect = true
+	c.convert(lines)
+	c.finish()
+
+	return renderPage(htmlPage{
+		Title:    c.docTitle(),
+		DocTitle: c.docTitle(),
+		Home:     "#",
+		Nav:      c.sectionNav(),
+		Body:     c.body(),
+		Index:    c.entries,
+	})
+}
+
+// searchEntry is one item in the client-side search index
+type searchEntry struct {
+	Name    string `json:"name"`
+	Kind    string `json:"kind"`
+	URL     string `json:"url"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// htmlSection is a section title, recorded for the sidebar
+type htmlSection struct {
+	level int
+	id    string
+	title string
+}
+
+// listFrame is one open list; the last item of every open list is still open
+type listFrame struct {
+	tag   string
+	class string
+	level int
+}
+
+// htmlConverter holds the state of one conversion. Like mdConverter, block
+// metadata (title, attribute list) applies to the next block only.
+type htmlConverter struct {
+	out   []string
+	attrs map[string]string // document attributes, for {name} references
+	xrefs map[string]string // cross-reference target -> anchor id
+	root  string            // relative path from the page to the site root
+	ids   map[string]int    // ids already used, to keep generated ids unique
+
+	title     string   // pending block title (.Title)
+	blockAttr string   // pending block attribute list, without brackets
+	anchor    string   // pending block anchor ([[id]])
+	para      []string // lines of the paragraph being read
+	lists     []listFrame
+	continued bool // a list continuation (+) attaches the next block to the open item
+	afterGap  bool // the last line was blank
+
+	sections []htmlSection
+
+	// Search index collection; only the top-level converter collects
+	collect     bool
+	item        string // navigation group of the item whose tag was just opened
+	entries     []searchEntry
+	summaryOpen bool // the next paragraph is the summary of the last entry
+}
+
+// newHTMLConverter returns a converter for the lines of one page. root is the
+// path from the page to the site root, used for links between pages. ids is
+// shared by nested converters so that ids stay unique across the page.
+func newHTMLConverter(lines []string, root string, ids map[string]int) *htmlConverter {
+	if ids == nil {
+		ids = make(map[string]int)
+	}
+	return &htmlConverter{
+		attrs: make(map[string]string),
+		xrefs: asciidoc.XrefTargets(lines, htmlID),
+		root:  root,
+		ids:   ids,
+	}
+}
+
+// nested returns a converter for the content of a delimited block or table
+// cell, sharing the document state but with its own block and list state
+func (c *htmlConverter) nested() *htmlConverter {
+	return &htmlConverter{attrs: c.attrs, xrefs: c.xrefs, root: c.root, ids: c.ids}
+}
+
+// convertNested converts lines with a nested converter and returns the HTML
+func (c *htmlConverter) convertNested(lines []string) []string {
+	inner := c.nested()
+	inner.convert(lines)
+	inner.finish()
+	return inner.out
+}
+
+func (c *htmlConverter) convert(lines []string) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		gap := c.afterGap
+		c.afterGap = false
+
+		switch {
+		case trimmed == "":
+			c.flushPara()
+			c.afterGap = true
+
+		case strings.HasPrefix(trimmed, "////"):
+			i = skipUntil(lines, i, trimmed)
+
+		case asciidoc.IsComment(trimmed):
+			if group, ok := antora.ItemGroup(trimmed); ok && c.collect {
+				c.item = group
+			}
+			c.afterGap = gap
+
+		case reAttributeEntry.MatchString(line):
+			m := reAttributeEntry.FindStringSubmatch(line)
+			if name, unset := strings.CutSuffix(m[1], "!"); unset {
+				delete(c.attrs, name)
+			} else {
+				c.attrs[name] = m[2]
+			}
+
+		case isAnchorLine(trimmed):
+			c.flushPara()
+			c.flushAnchor()
+			c.anchor, _ = asciidoc.AnchorID(trimmed)
+			c.afterGap = gap
+
+		case isBlockAttributeLine(trimmed):
+			c.flushPara()
+			c.blockAttr, _ = asciidoc.IsBlockAttributeLine(trimmed)
+
+		case reBlockTitle.MatchString(trimmed):
+			c.flushPara()
+			c.title = reBlockTitle.FindStringSubmatch(trimmed)[1]
+
+		case isSectionTitle(line):
+			level, title, _ := asciidoc.SectionTitle(line)
+			c.section(level, title)
+
+		case trimmed == "----" || trimmed == "....":
+			end := skipUntil(lines, i, trimmed)
+			c.codeBlock(lines[i+1:end], trimmed == "----")
+			i = end
+
+		case trimmed == "====" || trimmed == "****" || trimmed == "--":
+			end := skipUntil(lines, i, trimmed)
+			c.delimitedBlock(lines[i+1:end], trimmed)
+			i = end
+
+		case trimmed == "|===":
+			end := skipUntil(lines, i, trimmed)
+			c.table(lines[i+1 : end])
+			i = end
+
+		case reAdmonitionPara.MatchString(trimmed):
+			m := reAdmonitionPara.FindStringSubmatch(trimmed)
+			para := []string{m[2]}
+			for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				i++
+				para = append(para, lines[i])
+			}
+			c.admonition(m[1], para)
+
+		case trimmed == "+":
+			c.flushPara()
+			c.continued = len(c.lists) > 0
+
+		case trimmed == "'''":
+			c.startBlock()
+			c.emit("<hr>")
+
+		case trimmed == "<<<" || strings.HasPrefix(trimmed, "include::") || strings.HasPrefix(trimmed, "toc::"):
+			continue
+
+		case reCalloutItem.MatchString(trimmed):
+			m := reCalloutItem.FindStringSubmatch(trimmed)
+			c.listItem(listFrame{tag: "ol", class: "colist", level: 0}, m[2])
+
+		case reUnorderedItem.MatchString(line):
+			m := reUnorderedItem.FindStringSubmatch(line)
+			c.listItem(listFrame{tag: "ul", level: listLevel(m[1], m[2])}, m[3])
+
+		case reOrderedItem.MatchString(line):
+			m := reOrderedItem.FindStringSubmatch(line)
+			c.listItem(listFrame{tag: "ol", level: listLevel(m[1], m[2])}, m[3])
+
+		default:
+			c.paragraphLine(line, gap)
+		}
+	}
+}
+
+// finish closes everything still open at the end of the input
+func (c *htmlConverter) finish() {
+	c.flushPara()
+	c.closeLists()
+	c.flushAnchor()
+}
+
+// emit appends a line to the output
+func (c *htmlConverter) emit(line string) {
+	c.out = append(c.out, line)
+}
+
+// body returns the converted page content
+func (c *htmlConverter) body() template.HTML {
+	return template.HTML(strings.Join(c.out, "\n")) //nolint:gosec // markup built by the converter, text is escaped
+}
+
+// flushAnchor writes a pending anchor that no section title consumed
+func (c *htmlConverter) flushAnchor() {
+	if c.anchor == "" {
+		return
+	}
+	c.emit(fmt.Sprintf(`<a id="%s"></a>`, html.EscapeString(c.anchor)))
+	c.anchor = ""
+}
+
+// openBlock ends the current paragraph and, unless a list continuation
+// attaches the new block to the open list item, closes all open lists
+func (c *htmlConverter) openBlock() {
+	c.flushPara()
+	c.flushAnchor()
+	c.item = ""
+	if c.continued {
+		c.continued = false
+		return
+	}
+	c.closeLists()
+}
+
+// startBlock opens a block other than a paragraph and writes its title
+func (c *htmlConverter) startBlock() {
+	c.openBlock()
+	c.summaryOpen = false
+	c.emitTitle()
+}
+
+// resetBlock clears block metadata once the block it belongs to is written
+func (c *htmlConverter) resetBlock() {
+	c.title = ""
+	c.blockAttr = ""
+}
+
+// emitTitle writes a pending block title
+func (c *htmlConverter) emitTitle() {
+	if c.title == "" {
+		return
+	}
+	c.emit(`<div class="title">` + c.inline(c.title) + "</div>")
+	c.title = ""
+}
+
+// section writes a section title. Its id is the anchor written before it or,
+// like Asciidoctor, one derived from the title.
+func (c *htmlConverter) section(level int, title string) {
+	c.flushPara()
+	c.closeLists()
+	c.continued = false
+	c.resetBlock()
+
+	id := c.anchor
+	if id == "" {
+		id = htmlID(title)
+	}
+	c.anchor = ""
+	c.ids[id]++
+	if n := c.ids[id]; n > 1 {
+		id += "_" + strconv.Itoa(n)
+	}
+
+	tag := "h" + strconv.Itoa(min(level, 6))
+	c.emit(fmt.Sprintf(`<%s id="%s">%s</%s>`, tag, html.EscapeString(id), c.inline(title), tag))
+	c.sections = append(c.sections, htmlSection{level: level, id: id, title: plainText(title)})
+
+	if c.item != "" {
+		c.entries = append(c.entries, searchEntry{Name: plainText(title), Kind: c.item, URL: "#" + id})
+		c.summaryOpen = true
+		c.item = ""
+	}
+}
+
+func (c *htmlConverter) paragraphLine(line string, gap bool) {
+	if len(c.para) == 0 {
+		if len(c.lists) > 0 && !gap && !c.continued {
+			// Text directly below a list item continues the item
+			c.emit(c.inline(strings.TrimSpace(line)))
+			return
+		}
+		c.openBlock()
+		c.emitTitle()
+		c.blockAttr = ""
+	}
+	c.para = append(c.para, line)
+}
+
+// flushPara writes the paragraph being read, if any. The first paragraph
+// after an item's title becomes the item's summary in the search index.
+func (c *htmlConverter) flushPara() {
+	if len(c.para) == 0 {
+		return
+	}
+	parts := make([]string, len(c.para))
+	for i, line := range c.para {
+		text := strings.TrimSpace(line)
+		if strings.HasSuffix(text, " +") {
+			// AsciiDoc hard line break
+			parts[i] = c.inline(strings.TrimSuffix(text, " +")) + "<br>"
+			continue
+		}
+		parts[i] = c.inline(text)
+	}
+	c.emit("<p>" + strings.Join(parts, "\n") + "</p>")
+
+	if c.summaryOpen && len(c.entries) > 0 {
+		c.entries[len(c.entries)-1].Summary = summary(strings.Join(c.para, " "))
+		c.summaryOpen = false
+	}
+	c.para = nil
+}
+
+// listItem opens a list item at the nesting level of frame, closing deeper
+// lists and the previous item at the same level
+func (c *htmlConverter) listItem(frame listFrame, text string) {
+	if len(c.lists) == 0 {
+		c.startBlock()
+	} else {
+		c.flushPara()
+		c.continued = false
+	}
+
+	for len(c.lists) > 0 {
+		top := c.lists[len(c.lists)-1]
+		if top.level < frame.level {
+			break
+		}
+		if top.level == frame.level && top.tag == frame.tag && top.class == frame.class {
+			c.emit("</li>")
+			c.emit("<li>" + c.inline(text))
+			c.blockAttr = ""
+			return
+		}
+		c.closeList()
+	}
+
+	if frame.class != "" {
+		c.emit(fmt.Sprintf(`<%s class="%s">`, frame.tag, frame.class))
+	} else {
+		c.emit("<" + frame.tag + ">")
+	}
+	c.lists = append(c.lists, frame)
+	c.emit("<li>" + c.inline(text))
+	c.blockAttr = ""
+}
+
+// closeList closes the innermost open list and its last item
+func (c *htmlConverter) closeList() {
+	top := c.lists[len(c.lists)-1]
+	c.lists = c.lists[:len(c.lists)-1]
+	c.emit("</li>")
+	c.emit("</" + top.tag + ">")
+}
+
+func (c *htmlConverter) closeLists() {
+	for len(c.lists) > 0 {
+		c.closeList()
+	}
+}
+
+// codeBlock writes a listing or literal block as preformatted code. Callout
+// markers such as <1> are shown as (1), matching the callout list after the
+// block.
+func (c *htmlConverter) codeBlock(lines []string, listing bool) {
+	lang := ""
+	if listing {
+		lang = sourceLanguage(c.blockAttr)
+	}
+	c.startBlock()
+
+	var b strings.Builder
+	b.WriteString(`<pre class="listing"><code`)
+	if lang != "" {
+		fmt.Fprintf(&b, ` class="language-%s"`, html.EscapeString(lang))
+	}
+	b.WriteString(">")
+	for i, line := range lines {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		callouts := ""
+		if loc := reCodeCallouts.FindStringIndex(line); loc != nil {
+			callouts = reCalloutNumber.ReplaceAllString(line[loc[0]:], `<b class="conum">($1)</b>`)
+			line = line[:loc[0]]
+		}
+		b.WriteString(html.EscapeString(line) + callouts)
+	}
+	b.WriteString("</code></pre>")
+	c.emit(b.String())
+	c.resetBlock()
+}
+
+// delimitedBlock writes an example, sidebar or open block. With an
+// admonition style ([NOTE] etc.) it becomes an admonition.
+func (c *htmlConverter) delimitedBlock(lines []string, delimiter string) {
+	style := strings.TrimSpace(strings.Split(c.blockAttr, ",")[0])
+	if admonitionTypes[style] {
+		c.admonition(style, lines)
+		return
+	}
+
+	class := map[string]string{"====": "example", "****": "sidebar-block", "--": "openblock"}[delimiter]
+	c.startBlock()
+	c.resetBlock()
+	c.emit(`<div class="` + class + `">`)
+	c.out = append(c.out, c.convertNested(lines)...)
+	c.emit("</div>")
+}
+
+// admonition writes lines as an admonition block of the given type
+func (c *htmlConverter) admonition(kind string, lines []string) {
+	c.openBlock()
+	c.summaryOpen = false
+	title := c.title
+	c.resetBlock()
+
+	c.emit(fmt.Sprintf(`<div class="admonition %s">`, strings.ToLower(kind)))
+	label := kind[:1] + strings.ToLower(kind[1:])
+	if title != "" {
+		label += ": " + c.inline(title)
+	}
+	c.emit(`<div class="admonition-label">` + label + "</div>")
+	c.out = append(c.out, c.convertNested(lines)...)
+	c.emit("</div>")
+}
+
+// table writes an AsciiDoc PSV table. Cell content is converted as AsciiDoc,
+// so cells may hold paragraphs, lists and source blocks.
+func (c *htmlConverter) table(body []string) {
+	attrs := c.blockAttr
+	colStyles := parseColStyles(attrs)
+	cells := splitCells(strings.Join(body, "\n"))
+
+	ncols := len(colStyles)
+	if ncols == 0 {
+		ncols = cellsOnFirstLine(body)
+	}
+	title := c.title
+	c.title = ""
+	c.startBlock()
+	c.resetBlock()
+	if ncols == 0 {
+		return
+	}
+	hasHeader := strings.Contains(attrs, "header") || implicitHeader(body)
+
+	c.emit(`<table class="tableblock">`)
+	if title != "" {
+		c.emit("<caption>" + c.inline(title) + "</caption>")
+	}
+
+	column, row := 0, 0
+	for i, cell := range cells {
+		if column == 0 {
+			if row == 0 && hasHeader {
+				c.emit("<thead>")
+			} else if row == 0 || (row == 1 && hasHeader) {
+				c.emit("<tbody>")
+			}
+			c.emit("<tr>")
+		}
+
+		header := hasHeader && row == 0
+		style := cell.style
+		if style == 0 && column < len(colStyles) && !header {
+			style = colStyles[column]
+		}
+		c.emit(c.tableCell(cell, style, header))
+
+		column += max(cell.colspan, 1)
+		if column >= ncols || i == len(cells)-1 {
+			c.emit("</tr>")
+			if header {
+				c.emit("</thead>")
+			}
+			column = 0
+			row++
+		}
+	}
+	if row > 1 || (row == 1 && !hasHeader) {
+		c.emit("</tbody>")
+	}
+	c.emit("</table>")
+}
+
+// tableCell renders one cell; a cell holding a single paragraph is written
+// without the paragraph element
+func (c *htmlConverter) tableCell(cell tableCell, style byte, header bool) string {
+	content := strings.Join(c.convertNested(strings.Split(strings.TrimSpace(cell.text), "\n")), "\n")
+	if strings.HasPrefix(content, "<p>") && strings.HasSuffix(content, "</p>") &&
+		strings.Count(content, "<p>") == 1 {
+		content = strings.TrimSuffix(strings.TrimPrefix(content, "<p>"), "</p>")
+	}
+
+	switch style {
+	case 'm':
+		if content != "" && !strings.Contains(content, "<") {
+			content = "<code>" + content + "</code>"
+		}
+	case 's':
+		content = "<strong>" + content + "</strong>"
+	case 'e':
+		content = "<em>" + content + "</em>"
+	}
+
+	tag := "td"
+	if header || style == 'h' {
+		tag = "th"
+	}
+	span := ""
+	if cell.colspan > 1 {
+		span = fmt.Sprintf(` colspan="%d"`, cell.colspan)
+	}
+	return fmt.Sprintf("<%s%s>%s</%s>", tag, span, content, tag)
+}
+
+// inline converts inline AsciiDoc markup in a single line of text to HTML.
+// Links and code spans are converted first and held back as placeholders, so
+// that the remaining text can be escaped and formatted without touching them.
+func (c *htmlConverter) inline(text string) string {
+	var held []string
+	hold := func(s string) string {
+		held = append(held, s)
+		return fmt.Sprintf("\x00%d\x00", len(held)-1)
+	}
+
+	text = asciidoc.XrefPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := asciidoc.XrefPattern.FindStringSubmatch(m)
+		target, label := strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+		if label == "" {
+			label = target
+		}
+		return hold(fmt.Sprintf(`<a href="#%s">%s</a>`, html.EscapeString(c.resolve(target)), c.inline(label)))
+	})
+	text = reXrefMacro.ReplaceAllStringFunc(text, func(m string) string {
+		parts := reXrefMacro.FindStringSubmatch(m)
+		return hold(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(c.pageLink(parts[1])), c.inline(parts[2])))
+	})
+	text = reCodeSpan.ReplaceAllStringFunc(text, func(code string) string {
+		code = code[1 : len(code)-1]
+		if strings.HasPrefix(code, "+") && strings.HasSuffix(code, "+") && len(code) > 2 {
+			code = code[1 : len(code)-1]
+		}
+		return hold("<code>" + html.EscapeString(code) + "</code>")
+	})
+	text = reURLMacro.ReplaceAllStringFunc(text, func(m string) string {
+		parts := reURLMacro.FindStringSubmatch(m)
+		label := parts[2]
+		if label == "" {
+			label = parts[1]
+		}
+		return hold(fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(parts[1]), html.EscapeString(label)))
+	})
+	text = reBareURL.ReplaceAllStringFunc(text, func(m string) string {
+		parts := reBareURL.FindStringSubmatch(m)
+		url := html.EscapeString(parts[2])
+		return parts[1] + hold(fmt.Sprintf(`<a href="%s">%s</a>`, url, url))
+	})
+	text = reInlineAnchor.ReplaceAllStringFunc(text, func(m string) string {
+		return hold(fmt.Sprintf(`<a id="%s"></a>`, html.EscapeString(m[2:len(m)-2])))
+	})
+	text = reAttributeRef.ReplaceAllStringFunc(text, func(m string) string {
+		if value, ok := c.attrs[m[1:len(m)-1]]; ok {
+			return value
+		}
+		return m
+	})
+
+	text = html.EscapeString(text)
+	text = reStrongUnconstr.ReplaceAllString(text, "<strong>$1</strong>")
+	for i := 0; i < 2; i++ {
+		text = reStrongConstr.ReplaceAllString(text, "$1<strong>$2</strong>$3")
+		text = reEmphasisConstr.ReplaceAllString(text, "$1<em>$2</em>$3")
+	}
+	text = reEmphasisDouble.ReplaceAllString(text, "<em>$1</em>")
+
+	// Held fragments may themselves contain placeholders, e.g. a link inside
+	// a code span, so restore until none are left
+	for i := 0; i <= len(held) && strings.Contains(text, "\x00"); i++ {
+		text = rePlaceholder.ReplaceAllStringFunc(text, func(m string) string {
+			n, _ := strconv.Atoi(m[1 : len(m)-1])
+			return held[n]
+		})
+	}
+	return text
+}
+
+// resolve maps a cross-reference target to the anchor id it points at
+func (c *htmlConverter) resolve(target string) string {
+	if id, ok := c.xrefs[target]; ok {
+		return id
+	}
+	return target
+}
+
+// pageLink turns the target of an xref: macro between split pages, such as
+// types/User.adoc#type_user, into a relative link to the HTML page
+func (c *htmlConverter) pageLink(target string) string {
+	page, fragment, _ := strings.Cut(target, "#")
+	link := c.root + htmlPagePath(page)
+	if fragment != "" {
+		link += "#" + fragment
+	}
+	return link
+}
+
+// docTitle returns the level-0 title of the document
+func (c *htmlConverter) docTitle() string {
+	for _, s := range c.sections {
+		if s.level == 1 {
+			return s.title
+		}
+	}
+	return "GraphQL Documentation"
+}
+
+// sectionNav returns the sidebar links of a single page: the top-level
+// sections, each with the sections directly below it
+func (c *htmlConverter) sectionNav() []navLink {
+	var nav []navLink
+	for _, s := range c.sections {
+		link := navLink{Title: s.title, URL: "#" + s.id}
+		switch {
+		case s.level == 2:
+			nav = append(nav, link)
+		case s.level == 3 && len(nav) > 0:
+			nav[len(nav)-1].Children = append(nav[len(nav)-1].Children, link)
+		}
+	}
+	return nav
+}
+
+// htmlID derives a section id from its title the way Asciidoctor does, e.g.
+// "Query Name" becomes "_query_name"
+func htmlID(title string) string {
+	id := reIDStrip.ReplaceAllString(strings.ToLower(plainText(title)), "_")
+	return "_" + strings.Trim(id, "_")
+}
+
+// plainText removes inline markup from a title or description, keeping the
+// text of cross-references
+func plainText(text string) string {
+	text = asciidoc.XrefPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := asciidoc.XrefPattern.FindStringSubmatch(m)
+		if parts[2] != "" {
+			return parts[2]
+		}
+		return parts[1]
+	})
+	text = reXrefMacro.ReplaceAllString(text, "$2")
+	text = reURLMacro.ReplaceAllString(text, "$2")
+	text = strings.NewReplacer("`", "", "**", "", "__", "").Replace(text)
+	text = reStrongConstr.ReplaceAllString(text, "$1$2$3")
+	text = reEmphasisConstr.ReplaceAllString(text, "$1$2$3")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// summary returns the first sentence of a description paragraph, without
+// markup, for the search index
+func summary(paragraph string) string {
+	return parser.ExtractFirstSentence(plainText(paragraph))
+}
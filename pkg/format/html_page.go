@@ -0,0 +1,212 @@
+package format
+
+import (
+	"html/template"
+	"strings"
+)
+
+// navLink is one sidebar link, optionally with links nested below it
+type navLink struct {
+	Title    string
+	URL      string
+	Current  bool
+	Children []navLink
+}
+
+// htmlPage is the data of one HTML page
+type htmlPage struct {
+	Title    string // page title
+	DocTitle string // documentation title, shown at the top of the sidebar
+	Home     string // link to the start of the documentation
+	Root     string // relative path from the page to the site root
+	Nav      []navLink
+	Body     template.HTML
+	// Index is the embedded search index. Pages of a site leave it empty and
+	// load IndexScript instead, so that the index is written only once.
+	Index       []searchEntry
+	IndexScript string
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>{{.Style}}</style>
+</head>
+<body data-root="{{.Root}}">
+<nav class="sidebar">
+<a class="doc-title" href="{{.Home}}">{{.DocTitle}}</a>
+<input id="search" type="search" placeholder="Search (press /)" aria-label="Search" autocomplete="off">
+<ul id="search-results" class="search-results" hidden></ul>
+<ul id="nav" class="nav">
+{{- range .Nav}}
+<li{{if .Current}} class="current"{{end}}>
+{{- if .URL}}<a href="{{.URL}}">{{.Title}}</a>{{else}}<span class="group">{{.Title}}</span>{{end}}
+{{- if .Children}}
+<ul>
+{{- range .Children}}
+<li{{if .Current}} class="current"{{end}}><a href="{{.URL}}">{{.Title}}</a></li>
+{{- end}}
+</ul>
+{{- end}}
+</li>
+{{- end}}
+</ul>
+</nav>
+<main class="content">
+{{.Body}}
+</main>
+{{if .IndexScript}}<script src="{{.IndexScript}}"></script>
+{{else}}<script>window.searchIndex = {{.Index}};</script>
+{{end}}<script>{{.Script}}</script>
+</body>
+</html>
+`))
+
+// renderPage writes a page with the sidebar, search box, styles and scripts
+// around its content
+func renderPage(page htmlPage) string {
+	data := struct {
+		htmlPage
+		Style  template.CSS
+		Script template.JS
+	}{htmlPage: page, Style: pageStyle, Script: pageScript}
+
+	var b strings.Builder
+	if err := pageTemplate.Execute(&b, data); err != nil {
+		// The template is fixed and its data cannot make it fail
+		panic(err)
+	}
+	return b.String()
+}
+
+// pageStyle is the embedded style sheet. It uses system fonts only, so the
+// page needs no network access.
+const pageStyle = `
+:root { --sidebar: 18rem; --accent: #1565c0; --border: #dde1e6; --muted: #5f6b7a; --code-bg: #f5f7f9; }
+* { box-sizing: border-box; }
+body { margin: 0; font: 16px/1.6 -apple-system, BlinkMacSystemFont, "Segoe UI", Roboto, Helvetica, Arial, sans-serif;
+  color: #1f2328; background: #fff; }
+a { color: var(--accent); text-decoration: none; }
+a:hover { text-decoration: underline; }
+code, pre { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, "Liberation Mono", monospace;
+  font-size: 0.9em; }
+code { background: var(--code-bg); padding: 0.1em 0.3em; border-radius: 3px; }
+.sidebar { position: fixed; top: 0; bottom: 0; left: 0; width: var(--sidebar); overflow-y: auto; padding: 1rem;
+  border-right: 1px solid var(--border); background: #fafbfc; font-size: 0.9rem; }
+.doc-title { display: block; font-weight: 600; font-size: 1.1rem; color: inherit; margin-bottom: 0.75rem; }
+#search { width: 100%; padding: 0.4rem 0.5rem; border: 1px solid var(--border); border-radius: 4px; font: inherit; }
+.nav, .nav ul, .search-results { list-style: none; margin: 0.5rem 0; padding: 0; }
+.nav ul { margin: 0.2rem 0 0.4rem; padding-left: 0.9rem; }
+.nav li { margin: 0.15rem 0; }
+.nav > li > a, .nav .group { font-weight: 600; }
+.nav .current > a { color: inherit; font-weight: 700; }
+.search-results li { padding: 0.35rem 0; border-bottom: 1px solid var(--border); }
+.search-results .kind { color: var(--muted); font-size: 0.8em; margin-left: 0.4rem; }
+.search-results .summary { margin: 0.1rem 0 0; color: var(--muted); font-size: 0.85em; }
+.content { margin-left: var(--sidebar); padding: 1rem 2.5rem 4rem; max-width: calc(var(--sidebar) + 60rem); }
+h1, h2, h3, h4, h5, h6 { line-height: 1.25; margin: 1.6em 0 0.6em; }
+h2 { border-bottom: 1px solid var(--border); padding-bottom: 0.3em; }
+.title, caption { font-weight: 600; font-style: italic; text-align: left; margin: 1rem 0 0.4rem; }
+pre.listing { background: var(--code-bg); border: 1px solid var(--border); border-radius: 4px; padding: 0.8rem 1rem;
+  overflow-x: auto; line-height: 1.45; }
+pre.listing code { background: none; padding: 0; }
+.conum { color: var(--accent); font-weight: 600; }
+table.tableblock { border-collapse: collapse; width: 100%; margin: 0.5rem 0 1rem; }
+table.tableblock th, table.tableblock td { border: 1px solid var(--border); padding: 0.4rem 0.6rem; text-align: left;
+  vertical-align: top; }
+table.tableblock th { background: #f0f2f5; }
+table.tableblock td > p:first-child, .admonition > p:last-child { margin-top: 0; }
+.admonition { border-left: 4px solid var(--muted); background: #f6f8fa; padding: 0.6rem 1rem; margin: 1rem 0;
+  border-radius: 0 4px 4px 0; }
+.admonition-label { font-weight: 700; margin-bottom: 0.3rem; }
+.admonition.note { border-color: #1f6feb; } .admonition.tip { border-color: #1a7f37; }
+.admonition.important { border-color: #8250df; } .admonition.warning { border-color: #bf8700; }
+.admonition.caution { border-color: #cf222e; }
+.example, .sidebar-block { border: 1px solid var(--border); border-radius: 4px; padding: 0.5rem 1rem; margin: 1rem 0; }
+.colist { padding-left: 1.5rem; }
+@media (max-width: 50rem) {
+  .sidebar { position: static; width: auto; border-right: none; border-bottom: 1px solid var(--border); }
+  .content { margin-left: 0; padding: 1rem; }
+}
+`
+
+// pageScript filters the search index as the user types. Results replace
+// the navigation while the search box is not empty; Enter opens the first
+// result and / focuses the search box.
+const pageScript = `
+(function () {
+  var input = document.getElementById('search');
+  var results = document.getElementById('search-results');
+  var nav = document.getElementById('nav');
+  var root = document.body.getAttribute('data-root') || '';
+
+  function search(query) {
+    var index = window.searchIndex || [];
+    var names = [], summaries = [];
+    index.forEach(function (entry) {
+      if (entry.name.toLowerCase().indexOf(query) >= 0) {
+        names.push(entry);
+      } else if ((entry.summary || '').toLowerCase().indexOf(query) >= 0) {
+        summaries.push(entry);
+      }
+    });
+    return names.concat(summaries).slice(0, 50);
+  }
+
+  function show() {
+    var query = input.value.trim().toLowerCase();
+    results.innerHTML = '';
+    if (!query) {
+      results.hidden = true;
+      nav.hidden = false;
+      return;
+    }
+    var matches = search(query);
+    matches.forEach(function (entry) {
+      var item = document.createElement('li');
+      var link = document.createElement('a');
+      link.href = entry.url.charAt(0) === '#' ? entry.url : root + entry.url;
+      link.textContent = entry.name;
+      item.appendChild(link);
+      var kind = document.createElement('span');
+      kind.className = 'kind';
+      kind.textContent = entry.kind;
+      item.appendChild(kind);
+      if (entry.summary) {
+        var summary = document.createElement('p');
+        summary.className = 'summary';
+        summary.textContent = entry.summary;
+        item.appendChild(summary);
+      }
+      results.appendChild(item);
+    });
+    if (matches.length === 0) {
+      var none = document.createElement('li');
+      none.textContent = 'No results';
+      results.appendChild(none);
+    }
+    results.hidden = false;
+    nav.hidden = true;
+  }
+
+  input.addEventListener('input', show);
+  input.addEventListener('keydown', function (event) {
+    var first = results.querySelector('a');
+    if (event.key === 'Enter' && first) {
+      window.location.href = first.href;
+    } else if (event.key === 'Escape') {
+      input.value = '';
+      show();
+    }
+  });
+  document.addEventListener('keydown', function (event) {
+    if (event.key === '/' && document.activeElement !== input) {
+      event.preventDefault();
+      input.focus();
+    }
+  });
+})();
+`
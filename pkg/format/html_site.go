@@ -0,0 +1,96 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/antora"
+)
+
+// SearchIndexFile is the script holding the search index of an HTML site
+const SearchIndexFile = "search-index.js"
+
+// WriteHTMLSite writes a split document as a static HTML site below dir: one
+// page per item, e.g. dir/types/User.html, plus dir/index.html for the
+// overview, mirroring the pages of the Antora split. Every page has the full
+// navigation in its sidebar; the search index is written once, to
+// dir/search-index.js, and loaded with a relative script link so the site
+// also works when opened from disk. Existing files are overwritten.
+func WriteHTMLSite(site *antora.Site, dir string) error {
+	var index []searchEntry
+	pages := make([]string, len(site.Pages))
+	for i, p := range site.Pages {
+		root := strings.Repeat("../", strings.Count(p.Path, "/"))
+		lines := strings.Split(p.Content, "\n")
+		c := newHTMLConverter(lines, root, nil)
+		if p.Group != "" {
+			// An item page is its own search entry, summarised by its first
+			// paragraph
+			c.entries = []searchEntry{{Name: p.Title, Kind: p.Group, URL: htmlPagePath(p.Path)}}
+			c.summaryOpen = true
+		}
+		c.convert(lines)
+		c.finish()
+		index = append(index, c.entries...)
+
+		pages[i] = renderPage(htmlPage{
+			Title:       p.Title,
+			DocTitle:    site.Title,
+			Home:        root + htmlPagePath(antora.IndexPage),
+			Root:        root,
+			Nav:         siteNav(site, p.Path, root),
+			Body:        c.body(),
+			IndexScript: root + SearchIndexFile,
+		})
+	}
+
+	for i, p := range site.Pages {
+		file := filepath.Join(dir, filepath.FromSlash(htmlPagePath(p.Path)))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return fmt.Errorf("failed to create page directory: %v", err)
+		}
+		if err := os.WriteFile(file, []byte(pages[i]), 0o644); err != nil { //nolint:gosec // documentation output
+			return fmt.Errorf("failed to write page '%s': %v", file, err)
+		}
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return fmt.Errorf("failed to encode search index: %v", err)
+	}
+	indexFile := filepath.Join(dir, SearchIndexFile)
+	script := "window.searchIndex = " + string(data) + ";\n"
+	if err := os.WriteFile(indexFile, []byte(script), 0o644); err != nil { //nolint:gosec // documentation output
+		return fmt.Errorf("failed to write search index '%s': %v", indexFile, err)
+	}
+	return nil
+}
+
+// siteNav returns the sidebar links of a site page: the overview, then one
+// entry per group with its item pages, linked relative to the page
+func siteNav(site *antora.Site, current, root string) []navLink {
+	var nav []navLink
+	for _, p := range site.Pages {
+		link := navLink{Title: p.Title, URL: root + htmlPagePath(p.Path), Current: p.Path == current}
+		if p.Group == "" {
+			nav = append(nav, link)
+			continue
+		}
+		if len(nav) == 0 || nav[len(nav)-1].Title != p.Group || nav[len(nav)-1].URL != "" {
+			nav = append(nav, navLink{Title: p.Group})
+		}
+		group := &nav[len(nav)-1]
+		group.Children = append(group.Children, link)
+		group.Current = group.Current || link.Current
+	}
+	return nav
+}
+
+// htmlPagePath returns the path of the HTML page for a split page, e.g.
+// "types/User.adoc" becomes "types/User.html"
+func htmlPagePath(page string) string {
+	return strings.TrimSuffix(page, ".adoc") + ".html"
+}
@@ -0,0 +1,249 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/antora"
+)
+
+func TestToHTML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "header and attributes",
+			input:    "= API Docs\n:toc: left\n:revdate: Mon\n\nGenerated _{revdate}_",
+			expected: []string{"<title>API Docs</title>", `<h1 id="_api_docs">API Docs</h1>`, "<p>Generated <em>Mon</em></p>"},
+			absent:   []string{":toc:"},
+		},
+		{
+			name:     "sections with anchors",
+			input:    "== Types\n\n// tag::type-User[]\n[[type_user]]\n=== User\n// end::type-User[]",
+			expected: []string{`<h2 id="_types">Types</h2>`, `<h3 id="type_user">User</h3>`},
+			absent:   []string{"tag::", "[[type_user]]"},
+		},
+		{
+			name:     "cross-references by id and by title",
+			input:    "[[type_user]]\n=== User\n\nSee <<User,`User`>> and <<type_user>>.",
+			expected: []string{`See <a href="#type_user"><code>User</code></a> and <a href="#type_user">type_user</a>.`},
+		},
+		{
+			name:     "cross-reference inside a code span",
+			input:    "[[input_page]]\n=== Page\n\n* `page : <<Page,`Page`>>`",
+			expected: []string{`<li><code>page : <a href="#input_page"><code>Page</code></a></code>`},
+		},
+		{
+			name:     "strong and emphasis",
+			input:    "*Query Name:* _users_ and **already** strong, but not first_name",
+			expected: []string{"<strong>Query Name:</strong> <em>users</em> and <strong>already</strong> strong, but not first_name"},
+		},
+		{
+			name:     "text is escaped",
+			input:    "Returns List<String> & `Map<K,V>` <script>",
+			expected: []string{"Returns List&lt;String&gt; &amp; <code>Map&lt;K,V&gt;</code> &lt;script&gt;"},
+		},
+		{
+			name: "source block with callouts",
+			input: ".query: users\n[source, kotlin]\n----\nusers(\n  limit: Int <1>\n): [User<T>] <2>\n----\n\n" +
+				"<1> `limit`: page size\n<2> _RETURNS_: the users",
+			expected: []string{
+				`<div class="title">query: users</div>`,
+				"<pre class=\"listing\"><code class=\"language-kotlin\">users(\n  limit: Int <b class=\"conum\">(1)</b>\n" +
+					"): [User&lt;T&gt;] <b class=\"conum\">(2)</b></code></pre>",
+				"<ol class=\"colist\">\n<li><code>limit</code>: page size\n</li>\n<li><em>RETURNS</em>: the users\n</li>\n</ol>",
+			},
+		},
+		{
+			name:     "admonition block",
+			input:    "[WARNING]\n====\nFirst line. +\nSecond line.\n====",
+			expected: []string{"<div class=\"admonition warning\">\n<div class=\"admonition-label\">Warning</div>\n<p>First line.<br>\nSecond line.</p>\n</div>"},
+		},
+		{
+			name:     "admonition paragraph",
+			input:    "NOTE: Read this.",
+			expected: []string{"<div class=\"admonition note\">\n<div class=\"admonition-label\">Note</div>\n<p>Read this.</p>\n</div>"},
+		},
+		{
+			name: "list item continuations",
+			input: ".Arguments\n* `id : ID!`\n+\n--\nThe user id\n--\n+\n[WARNING]\n====\n*Deprecated:* gone\n====\n" +
+				"* `name : String`\n\nAfter the list",
+			expected: []string{
+				"<div class=\"title\">Arguments</div>\n<ul>\n<li><code>id : ID!</code>\n<div class=\"openblock\">\n<p>The user id</p>\n</div>\n" +
+					"<div class=\"admonition warning\">",
+				"</div>\n</li>\n<li><code>name : String</code>\n</li>\n</ul>\n<p>After the list</p>",
+			},
+		},
+		{
+			name:  "table with header, column styles and spans",
+			input: "[options=\"header\",cols=\"2m,5a\"]\n|===\n| Name | Description\n2+^h| Group\n| users | All *users*\n|===",
+			expected: []string{
+				"<thead>\n<tr>\n<th>Name</th>\n<th>Description</th>\n</tr>\n</thead>",
+				"<tr>\n<th colspan=\"2\">Group</th>\n</tr>",
+				"<tr>\n<td><code>users</code></td>\n<td>All <strong>users</strong></td>\n</tr>\n</tbody>",
+			},
+		},
+		{
+			name: "list of an object type in a table cell",
+			input: "[[type_post]]\n=== Post\n\n[options=\"header\",cols=\"2a,2m,5a\"]\n|===\n" +
+				"| Type | Field | Description\n\n| [<<Post,`Post`>>]\n| posts\n| Posts by this user\n|===",
+			expected: []string{"<td>[<a href=\"#type_post\"><code>Post</code></a>]</td>\n<td><code>posts</code></td>"},
+			absent:   []string{"<td></td>"},
+		},
+		{
+			name:     "comments and includes are dropped",
+			input:    "// tag::x[]\nText\n////\nsecret\n////\ninclude::other.adoc[]\n<<<\ntoc::[]\n// end::x[]",
+			expected: []string{"<p>Text</p>"},
+			absent:   []string{"tag::", "secret", "include::", "&lt;&lt;&lt;", "toc::"},
+		},
+		{
+			name:     "unset attribute",
+			input:    ":product: Shop\n:product!:\n\n{product} API",
+			expected: []string{"<p>{product} API</p>"},
+		},
+		{
+			name:  "literal, sidebar and open blocks",
+			input: "....\n<raw> *text*\n....\n\n****\nAside\n****\n\n--\nOpen\n--",
+			expected: []string{
+				"<pre class=\"listing\"><code>&lt;raw&gt; *text*</code></pre>",
+				"<div class=\"sidebar-block\">\n<p>Aside</p>\n</div>",
+				"<div class=\"openblock\">\n<p>Open</p>\n</div>",
+			},
+		},
+		{
+			name:     "nested ordered list",
+			input:    ". First\n.. Inner\n. Second",
+			expected: []string{"<ol>\n<li>First\n<ol>\n<li>Inner\n</li>\n</ol>\n</li>\n<li>Second\n</li>\n</ol>"},
+		},
+		{
+			name:     "inline anchor, passthrough and double emphasis",
+			input:    "[[note_a]]Read `+*raw*+` and __this__",
+			expected: []string{`<p><a id="note_a"></a>Read <code>*raw*</code> and <em>this</em></p>`},
+		},
+		{
+			name:     "thematic break",
+			input:    "Above\n\n'''\n\nBelow",
+			expected: []string{"<p>Above</p>\n<hr>\n<p>Below</p>"},
+		},
+		{
+			name:     "links",
+			input:    "See https://example.com/docs. and link:https://example.com[Example]",
+			expected: []string{`See <a href="https://example.com/docs">https://example.com/docs</a>. and <a href="https://example.com">Example</a>`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ToHTML(tc.input)
+			for _, expected := range tc.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("Expected output to contain %q, got:\n%s", expected, result)
+				}
+			}
+			for _, absent := range tc.absent {
+				if strings.Contains(result, absent) {
+					t.Errorf("Expected output not to contain %q, got:\n%s", absent, result)
+				}
+			}
+		})
+	}
+}
+
+// TestToHTMLGeneratedDocuments converts the committed sample documents and
+// checks that no AsciiDoc markup is left in the page text outside code
+// blocks, as TestToMarkdownGeneratedDocuments does for Markdown
+func TestToHTMLGeneratedDocuments(t *testing.T) {
+	files, err := filepath.Glob("../../test/*/*.adoc")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No sample documents found: %v", err)
+	}
+	code := regexp.MustCompile(`(?s)<pre.*?</pre>|<code>.*?</code>`)
+	tags := regexp.MustCompile(`<[^>]*>`)
+	leftovers := regexp.MustCompile(
+		`^(\|===|//|----|\.\.\.\.|====|\*\*\*\*|:[\w-]+:|\[(\w+|\w*[,=].*)\]$|\+$)|&lt;&lt;|\[\[[\w:.-]+\]\]|\{[\w-]+\}`)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			doc, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			page := ToHTML(string(doc))
+			start, end := strings.Index(page, "<main"), strings.Index(page, "</main>")
+			text := tags.ReplaceAllString(code.ReplaceAllString(page[start:end], "(code)"), "")
+			for i, line := range strings.Split(text, "\n") {
+				if line = strings.TrimSpace(line); leftovers.MatchString(line) {
+					t.Errorf("Line %d still contains AsciiDoc markup: %s", i+1, line)
+				}
+			}
+		})
+	}
+}
+
+func TestToHTMLSidebarAndSearchIndex(t *testing.T) {
+	doc := "= API\n\n== Query\n\n// tag::query-users[]\n[[query_users]]\n=== users\n\n" +
+		"Returns all <<User,`User`>> accounts. Paged by default.\n\n.query: users\n// end::query-users[]\n\n" +
+		"== Types\n\n// tag::type-User[]\n[[type_user]]\n=== User\n.type: User\n|===\n| a | b\n|===\n// end::type-User[]\n"
+	result := ToHTML(doc)
+
+	for _, expected := range []string{
+		"<li><a href=\"#_query\">Query</a>\n<ul>\n<li><a href=\"#query_users\">users</a></li>\n</ul>\n</li>",
+		`{"name":"users","kind":"Queries","url":"#query_users","summary":"Returns all User accounts."}`,
+		`{"name":"User","kind":"Types","url":"#type_user"}`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, result)
+		}
+	}
+
+	// Everything the page needs is embedded
+	for _, external := range []string{"<link", "src=", "http://", "https://"} {
+		if strings.Contains(result, external) {
+			t.Errorf("Page should not load external resources, found %q", external)
+		}
+	}
+}
+
+func TestWriteHTMLSite(t *testing.T) {
+	doc := "= API\n\n== Query\n\n// tag::query-users[]\n[[query_users]]\n=== users\n\n" +
+		"Returns all <<User,`User`>> accounts.\n// end::query-users[]\n\n" +
+		"== Types\n\n// tag::type-User[]\n[[type_user]]\n=== User\n\nA user.\n// end::type-User[]\n"
+	dir := t.TempDir()
+	if err := WriteHTMLSite(antora.Split(doc), dir); err != nil {
+		t.Fatalf("WriteHTMLSite returned error: %v", err)
+	}
+
+	page, err := os.ReadFile(filepath.Join(dir, "queries", "users.html"))
+	if err != nil {
+		t.Fatalf("Expected query page to be written: %v", err)
+	}
+	for _, expected := range []string{
+		`<a href="../types/User.html"><code>User</code></a>`,
+		`<li class="current"><a href="../queries/users.html">users</a></li>`,
+		`<body data-root="../">`,
+		`<script src="../search-index.js"></script>`,
+	} {
+		if !strings.Contains(string(page), expected) {
+			t.Errorf("Query page should contain %q, got:\n%s", expected, page)
+		}
+	}
+
+	index, err := os.ReadFile(filepath.Join(dir, SearchIndexFile))
+	if err != nil {
+		t.Fatalf("Expected search index to be written: %v", err)
+	}
+	expected := `{"name":"users","kind":"Queries","url":"queries/users.html","summary":"Returns all User accounts."}`
+	if !strings.Contains(string(index), expected) {
+		t.Errorf("Search index should contain %q, got:\n%s", expected, index)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "index.html")); err != nil {
+		t.Errorf("Expected index.html to be written: %v", err)
+	}
+}
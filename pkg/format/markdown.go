@@ -0,0 +1,689 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/asciidoc"
+)
+
+var (
+	reAttributeEntry = regexp.MustCompile(`^:([\w-]+!?):\s*(.*)$`)
+	reBlockTitle     = regexp.MustCompile(`^\.([^.\s].*)$`)
+	reAdmonitionPara = regexp.MustCompile(`^(NOTE|TIP|IMPORTANT|WARNING|CAUTION):\s+(.*)$`)
+	reUnorderedItem  = regexp.MustCompile(`^(\s*)(\*{1,5}|-)\s+(.*)$`)
+	reOrderedItem    = regexp.MustCompile(`^(\s*)(\.{1,5})\s+(.*)$`)
+	reCalloutItem    = regexp.MustCompile(`^<(\d+)>\s+(.*)$`)
+	reCodeCallouts   = regexp.MustCompile(`((?:\s*<\d+>)+)\s*$`)
+	reCalloutNumber  = regexp.MustCompile(`<(\d+)>`)
+	reCodeWithXref   = regexp.MustCompile("`([^`<]*)<<([^,>]+),`([^`]+)`>>([^`]*)`")
+	reInlineAnchor   = regexp.MustCompile(`\[\[([\w:.-]+)\]\]`)
+	reURLMacro       = regexp.MustCompile(`(?:link:)?((?:https?|ftp|mailto):[^\s\[]+)\[([^\]]*)\]`)
+	reAttributeRef   = regexp.MustCompile(`\{([\w-]+)\}`)
+	reCodeSpan       = regexp.MustCompile("`[^`]+`")
+	reStrongUnconstr = regexp.MustCompile(`\*\*(.+?)\*\*`)
+	reStrongConstr   = regexp.MustCompile(`(^|[^\w*\\])\*([^*\s](?:[^*]*[^*\s])?)\*([^\w*]|$)`)
+	reEmphasisDouble = regexp.MustCompile(`__(.+?)__`)
+	reCellSpec       = regexp.MustCompile(`^(?:\d+\*|\d*(?:\.\d+)?\+)?[<^>]?(?:\.[<^>])?[adehlmsv]?$`)
+	reColSpec        = regexp.MustCompile(`^(\d+)\*(.*)$`)
+	reSlugStrip      = regexp.MustCompile(`[^\p{L}\p{N}\s_-]`)
+)
+
+// admonitionTypes are the AsciiDoc admonitions, which map one-to-one onto
+// GitHub alert blocks
+var admonitionTypes = map[string]bool{
+	"NOTE": true, "TIP": true, "IMPORTANT": true, "WARNING": true, "CAUTION": true,
+}
+
+// diagramStyles are the asciidoctor-diagram block styles the generator emits
+var diagramStyles = map[string]bool{"mermaid": true, "plantuml": true}
+
+// markdownRenderer converts the generated AsciiDoc to GitHub-flavoured Markdown
+type markdownRenderer struct{}
+
+func (markdownRenderer) Render(w io.Writer, doc string) error {
+	_, err := io.WriteString(w, ToMarkdown(doc))
+	return err
+}
+
+func (markdownRenderer) Extension() string {
+	return ".md"
+}
+
+// ToMarkdown converts an AsciiDoc document produced by the generator to
+// GitHub-flavoured Markdown. Only the AsciiDoc subset the generator emits is
+// supported: tag and comment lines are dropped, anchors become <a id>
+// elements, cross-references become links, tables become pipe tables (cells
+// with several lines are joined with <br>), source blocks become fenced code
+// blocks and admonitions become GitHub alerts. docs/FORMATS.md lists every
+// supported construct; anything else is passed through as paragraph text.
+func ToMarkdown(doc string) string {
+	lines := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+	c := &mdConverter{
+		attrs: make(map[string]string),
+		xrefs: asciidoc.XrefTargets(lines, githubSlug),
+	}
+	c.convert(lines)
+	return strings.TrimLeft(strings.Join(c.out, "\n"), "\n") + "\n"
+}
+
+// mdConverter holds the state of one conversion. Block metadata (title,
+// attribute list) applies to the next block only, as in AsciiDoc.
+type mdConverter struct {
+	out   []string
+	attrs map[string]string // document attributes, for {name} references
+	xrefs map[string]string // cross-reference target -> anchor id
+
+	title     string // pending block title (.Title)
+	blockAttr string // pending block attribute list, without brackets
+	lastList  bool   // whether the last emitted line was a list item
+}
+
+func (c *mdConverter) convert(lines []string) {
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			c.blank()
+			c.lastList = false
+
+		case strings.HasPrefix(trimmed, "////"):
+			i = skipUntil(lines, i, trimmed)
+
+		case asciidoc.IsComment(trimmed):
+			continue
+
+		case reAttributeEntry.MatchString(line):
+			m := reAttributeEntry.FindStringSubmatch(line)
+			if name, unset := strings.CutSuffix(m[1], "!"); unset {
+				delete(c.attrs, name)
+			} else {
+				c.attrs[name] = m[2]
+			}
+
+		case isAnchorLine(trimmed):
+			id, _ := asciidoc.AnchorID(trimmed)
+			c.blank()
+			c.emit(fmt.Sprintf(`<a id="%s"></a>`, id))
+			c.blank()
+
+		case isBlockAttributeLine(trimmed):
+			c.blockAttr, _ = asciidoc.IsBlockAttributeLine(trimmed)
+
+		case reBlockTitle.MatchString(trimmed):
+			c.title = reBlockTitle.FindStringSubmatch(trimmed)[1]
+
+		case isSectionTitle(line):
+			level, title, _ := asciidoc.SectionTitle(line)
+			c.blank()
+			c.emit(strings.Repeat("#", level) + " " + c.inline(title))
+			c.blank()
+			c.resetBlock()
+
+		case trimmed == "----" || trimmed == "....":
+			end := skipUntil(lines, i, trimmed)
+			c.codeBlock(lines[i+1:end], trimmed == "----")
+			i = end
+
+		case trimmed == "====" || trimmed == "****":
+			end := skipUntil(lines, i, trimmed)
+			c.delimitedBlock(lines[i+1 : end])
+			i = end
+
+		case trimmed == "--":
+			// Open block delimiters carry no formatting of their own
+
+		case trimmed == "|===":
+			end := skipUntil(lines, i, trimmed)
+			c.table(lines[i+1 : end])
+			i = end
+
+		case reAdmonitionPara.MatchString(trimmed):
+			m := reAdmonitionPara.FindStringSubmatch(trimmed)
+			para := []string{m[2]}
+			for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				i++
+				para = append(para, lines[i])
+			}
+			c.alert(m[1], para)
+
+		case trimmed == "+":
+			// List continuation; Markdown continues the item after a blank line
+			c.blank()
+
+		case trimmed == "'''":
+			c.blank()
+			c.emit("---")
+			c.blank()
+
+		case trimmed == "<<<" || strings.HasPrefix(trimmed, "include::") || strings.HasPrefix(trimmed, "toc::"):
+			continue
+
+		case reCalloutItem.MatchString(trimmed):
+			m := reCalloutItem.FindStringSubmatch(trimmed)
+			c.listItem(0, m[1]+". "+c.inline(m[2]))
+
+		case reUnorderedItem.MatchString(line):
+			m := reUnorderedItem.FindStringSubmatch(line)
+			c.listItem(listLevel(m[1], m[2]), "- "+c.inline(m[3]))
+
+		case reOrderedItem.MatchString(line):
+			m := reOrderedItem.FindStringSubmatch(line)
+			c.listItem(listLevel(m[1], m[2]), "1. "+c.inline(m[3]))
+
+		default:
+			c.paragraphLine(line)
+		}
+	}
+}
+
+// emit appends a line to the output
+func (c *mdConverter) emit(line string) {
+	c.out = append(c.out, line)
+}
+
+// blank ends the current block with a single empty line
+func (c *mdConverter) blank() {
+	if len(c.out) > 0 && c.out[len(c.out)-1] != "" {
+		c.out = append(c.out, "")
+	}
+}
+
+// resetBlock clears block metadata once the block it belongs to is written
+func (c *mdConverter) resetBlock() {
+	c.title = ""
+	c.blockAttr = ""
+	c.lastList = false
+}
+
+// emitTitle writes a pending block title as a bold line
+func (c *mdConverter) emitTitle() {
+	if c.title == "" {
+		return
+	}
+	c.blank()
+	c.emit("**" + c.inline(c.title) + "**")
+	c.blank()
+	c.title = ""
+}
+
+func (c *mdConverter) paragraphLine(line string) {
+	if c.title != "" {
+		c.emitTitle()
+	}
+	c.blockAttr = ""
+	text := strings.TrimSpace(line)
+	if strings.HasSuffix(text, " +") {
+		// AsciiDoc hard line break
+		text = strings.TrimSuffix(text, " +") + `\`
+	}
+	c.emit(c.inline(text))
+}
+
+func (c *mdConverter) listItem(level int, item string) {
+	if !c.lastList {
+		c.emitTitle()
+		c.blank()
+	}
+	c.emit(strings.Repeat("  ", level) + item)
+	c.blockAttr = ""
+	c.lastList = true
+}
+
+// listLevel returns the zero-based nesting level of a list item from its
+// marker (* / ** or . / ..) and indentation
+func listLevel(indent, marker string) int {
+	level := len(marker) - 1
+	if marker == "-" {
+		level = 0
+	}
+	if byIndent := len(indent) / 2; byIndent > level {
+		level = byIndent
+	}
+	return level
+}
+
+// codeBlock writes a listing or literal block as a fenced code block.
+// Callout markers such as <1> are kept as (1) so they still line up with the
+// numbered callout list that follows the block.
+func (c *mdConverter) codeBlock(lines []string, listing bool) {
+	lang := ""
+	if listing {
+		lang = sourceLanguage(c.blockAttr)
+	}
+	c.emitTitle()
+	c.blank()
+	c.emit("```" + lang)
+	for _, line := range lines {
+		line = reCodeCallouts.ReplaceAllStringFunc(line, func(callouts string) string {
+			return reCalloutNumber.ReplaceAllString(callouts, "($1)")
+		})
+		c.emit(line)
+	}
+	c.emit("```")
+	c.blank()
+	c.resetBlock()
+}
+
+// sourceLanguage extracts the language from a [source,lang] attribute list.
+// Diagram blocks such as [mermaid,name,svg] keep their diagram language, which
+// GitHub renders for Mermaid.
+func sourceLanguage(attrList string) string {
+	parts := strings.Split(attrList, ",")
+	style := strings.TrimSpace(parts[0])
+	if diagramStyles[style] {
+		return style
+	}
+	if len(parts) < 2 || style != "source" {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// delimitedBlock writes an example or sidebar block. With an admonition
+// style ([NOTE] etc.) it becomes a GitHub alert, otherwise its content is
+// written inline.
+func (c *mdConverter) delimitedBlock(lines []string) {
+	style := strings.TrimSpace(strings.Split(c.blockAttr, ",")[0])
+	if admonitionTypes[style] {
+		c.alert(style, lines)
+		return
+	}
+	c.emitTitle()
+	c.resetBlock()
+	c.blank()
+	c.convert(lines)
+	c.blank()
+}
+
+// alert writes lines as a GitHub alert block of the given admonition type
+func (c *mdConverter) alert(kind string, lines []string) {
+	title := c.title
+	c.resetBlock()
+
+	inner := &mdConverter{attrs: c.attrs, xrefs: c.xrefs}
+	inner.convert(lines)
+	for len(inner.out) > 0 && inner.out[len(inner.out)-1] == "" {
+		inner.out = inner.out[:len(inner.out)-1]
+	}
+
+	c.blank()
+	c.emit("> [!" + kind + "]")
+	if title != "" {
+		c.emit("> **" + c.inline(title) + "**")
+	}
+	for _, line := range inner.out {
+		if line == "" {
+			c.emit(">")
+		} else {
+			c.emit("> " + line)
+		}
+	}
+	c.blank()
+}
+
+// tableCell is one parsed cell of an AsciiDoc table
+type tableCell struct {
+	text    string
+	colspan int
+	style   byte
+}
+
+// table writes an AsciiDoc PSV table as a pipe table. Markdown cannot span
+// columns, so spanned cells are padded with empty cells.
+func (c *mdConverter) table(body []string) {
+	attrs := c.blockAttr
+	colStyles := parseColStyles(attrs)
+	cells := splitCells(strings.Join(body, "\n"))
+
+	ncols := len(colStyles)
+	if ncols == 0 {
+		ncols = cellsOnFirstLine(body)
+	}
+	if ncols == 0 {
+		c.resetBlock()
+		return
+	}
+
+	hasHeader := strings.Contains(attrs, "header") || implicitHeader(body)
+
+	var rows [][]string
+	var row []string
+	for _, cell := range cells {
+		style := cell.style
+		if style == 0 && len(row) < len(colStyles) {
+			style = colStyles[len(row)]
+		}
+		if hasHeader && len(rows) == 0 {
+			// Column styles do not apply to the header row
+			style = 0
+		}
+		row = append(row, c.cellText(cell.text, style))
+		for i := 1; i < cell.colspan; i++ {
+			row = append(row, "")
+		}
+		if len(row) >= ncols {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		for len(row) < ncols {
+			row = append(row, "")
+		}
+		rows = append(rows, row)
+	}
+
+	c.emitTitle()
+	c.blank()
+	if !hasHeader || len(rows) == 0 {
+		rows = append([][]string{make([]string, ncols)}, rows...)
+	}
+	c.emit(tableRow(rows[0]))
+	c.emit("|" + strings.Repeat(" --- |", ncols))
+	for _, r := range rows[1:] {
+		c.emit(tableRow(r))
+	}
+	c.blank()
+	c.resetBlock()
+}
+
+func tableRow(cells []string) string {
+	if len(cells) == 0 {
+		return "|"
+	}
+	return "| " + strings.Join(cells, " | ") + " |"
+}
+
+// cellText flattens the content of a cell to a single Markdown line
+func (c *mdConverter) cellText(text string, style byte) string {
+	var parts []string
+	inCode := false
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "----" || trimmed == "....":
+			inCode = !inCode
+		case inCode:
+			if trimmed != "" {
+				parts = append(parts, "`"+trimmed+"`")
+			}
+		case trimmed == "":
+			if len(parts) > 0 && parts[len(parts)-1] != "" {
+				parts = append(parts, "")
+			}
+		case asciidoc.IsComment(trimmed), isBlockAttributeLine(trimmed):
+			continue
+		case reBlockTitle.MatchString(trimmed):
+			parts = append(parts, "**"+c.inline(reBlockTitle.FindStringSubmatch(trimmed)[1])+"**")
+		case reUnorderedItem.MatchString(trimmed):
+			parts = append(parts, "• "+c.inline(reUnorderedItem.FindStringSubmatch(trimmed)[3]))
+		default:
+			parts = append(parts, c.inline(strings.TrimSuffix(trimmed, " +")))
+		}
+	}
+	for len(parts) > 0 && parts[len(parts)-1] == "" {
+		parts = parts[:len(parts)-1]
+	}
+
+	result := strings.Join(parts, "<br>")
+	result = strings.ReplaceAll(result, "<br><br><br>", "<br><br>")
+	result = strings.ReplaceAll(result, "|", `\|`)
+
+	switch style {
+	case 'm':
+		if result != "" && !strings.Contains(result, "`") && !strings.Contains(result, "<br>") {
+			result = "`" + result + "`"
+		}
+	case 'h', 's':
+		if result != "" {
+			result = "**" + result + "**"
+		}
+	case 'e':
+		if result != "" {
+			result = "_" + result + "_"
+		}
+	}
+	return result
+}
+
+// parseColStyles returns the style letter of each column declared in the
+// cols attribute, e.g. cols="2m,5a" gives ['m', 'a']. Columns without a style
+// get 0.
+func parseColStyles(attrs string) []byte {
+	start := strings.Index(attrs, `cols="`)
+	if start < 0 {
+		return nil
+	}
+	rest := attrs[start+len(`cols="`):]
+	end := strings.Index(rest, `"`)
+	if end < 0 {
+		return nil
+	}
+
+	var styles []byte
+	for _, spec := range strings.Split(rest[:end], ",") {
+		spec = strings.TrimSpace(spec)
+		repeat := 1
+		if m := reColSpec.FindStringSubmatch(spec); m != nil {
+			repeat, _ = strconv.Atoi(m[1])
+			spec = m[2]
+		}
+		var style byte
+		if n := len(spec); n > 0 && strings.IndexByte("adehlmsv", spec[n-1]) >= 0 {
+			style = spec[n-1]
+		}
+		for i := 0; i < repeat; i++ {
+			styles = append(styles, style)
+		}
+	}
+	return styles
+}
+
+// splitCells splits the body of a PSV table into cells. A cell specifier
+// such as "2+^h" must directly precede its separator and follow whitespace.
+func splitCells(body string) []tableCell {
+	var cells []tableCell
+	var current *tableCell
+	var buf strings.Builder
+
+	flush := func(spec string) {
+		if current != nil {
+			current.text = buf.String()
+			cells = append(cells, *current)
+		}
+		buf.Reset()
+		current = &tableCell{colspan: 1}
+		if spec == "" {
+			return
+		}
+		if plus := strings.IndexByte(spec, '+'); plus > 0 && !strings.Contains(spec[:plus], ".") {
+			current.colspan, _ = strconv.Atoi(spec[:plus])
+		}
+		if last := spec[len(spec)-1]; strings.IndexByte("adehlmsv", last) >= 0 {
+			current.style = last
+		}
+	}
+
+	for i := 0; i < len(body); i++ {
+		ch := body[i]
+		if ch == '\\' && i+1 < len(body) && body[i+1] == '|' {
+			buf.WriteByte('|')
+			i++
+			continue
+		}
+		if ch != '|' {
+			buf.WriteByte(ch)
+			continue
+		}
+
+		text := buf.String()
+		spec := ""
+		if cut := strings.LastIndexAny(text, " \t\n"); cut < len(text)-1 {
+			candidate := text[cut+1:]
+			if reCellSpec.MatchString(candidate) {
+				spec = candidate
+				buf.Reset()
+				buf.WriteString(text[:cut+1])
+			}
+		}
+		flush(spec)
+	}
+	if current != nil {
+		current.text = buf.String()
+		cells = append(cells, *current)
+	}
+	return cells
+}
+
+// cellsOnFirstLine counts the cells on the first non-empty table line
+func cellsOnFirstLine(body []string) int {
+	for _, line := range body {
+		if strings.TrimSpace(line) != "" {
+			return len(splitCells(line))
+		}
+	}
+	return 0
+}
+
+// implicitHeader reports whether the first table line is followed by a blank
+// line, which makes it the header row in AsciiDoc
+func implicitHeader(body []string) bool {
+	return len(body) > 1 && strings.HasPrefix(strings.TrimSpace(body[0]), "|") && strings.TrimSpace(body[1]) == ""
+}
+
+// inline converts inline AsciiDoc markup in a single line of text
+func (c *mdConverter) inline(text string) string {
+	// A cross-reference inside a code span, e.g. `input : <<T,`T`>>`, is
+	// split into code / link / code since Markdown code spans cannot nest
+	text = reCodeWithXref.ReplaceAllStringFunc(text, func(m string) string {
+		parts := reCodeWithXref.FindStringSubmatch(m)
+		link := fmt.Sprintf("<<%s,`%s`>>", parts[2], parts[3])
+		return codeSpan(parts[1], true) + link + codeSpan(parts[4], false)
+	})
+
+	text = asciidoc.XrefPattern.ReplaceAllStringFunc(text, func(m string) string {
+		parts := asciidoc.XrefPattern.FindStringSubmatch(m)
+		target, label := strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+		if label == "" {
+			label = target
+		}
+		return fmt.Sprintf("[%s](#%s)", label, c.resolve(target))
+	})
+
+	// Leave code spans alone and convert the text between them
+	var b strings.Builder
+	last := 0
+	for _, span := range reCodeSpan.FindAllStringIndex(text, -1) {
+		b.WriteString(c.inlineText(text[last:span[0]]))
+		code := text[span[0]:span[1]]
+		if strings.HasPrefix(code, "`+") && strings.HasSuffix(code, "+`") && len(code) > 4 {
+			code = "`" + code[2:len(code)-2] + "`"
+		}
+		b.WriteString(code)
+		last = span[1]
+	}
+	b.WriteString(c.inlineText(text[last:]))
+	return b.String()
+}
+
+// codeSpan wraps text in backticks, keeping the whitespace that separates it
+// from a neighbouring link outside the span
+func codeSpan(text string, before bool) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	if before {
+		return "`" + trimmed + "`" + text[len(strings.TrimRight(text, " ")):]
+	}
+	return text[:len(text)-len(strings.TrimLeft(text, " "))] + "`" + trimmed + "`"
+}
+
+// inlineText converts markup in text that contains no code spans
+func (c *mdConverter) inlineText(text string) string {
+	if text == "" {
+		return text
+	}
+
+	// AsciiDoc escapes angle brackets; Markdown would treat them as HTML.
+	// Links produced from cross-references contain no angle brackets.
+	text = strings.ReplaceAll(text, "<", "&lt;")
+
+	text = reInlineAnchor.ReplaceAllString(text, `<a id="$1"></a>`)
+	text = reURLMacro.ReplaceAllStringFunc(text, func(m string) string {
+		parts := reURLMacro.FindStringSubmatch(m)
+		if parts[2] == "" {
+			return parts[1]
+		}
+		return fmt.Sprintf("[%s](%s)", parts[2], parts[1])
+	})
+	text = reAttributeRef.ReplaceAllStringFunc(text, func(m string) string {
+		if value, ok := c.attrs[m[1:len(m)-1]]; ok {
+			return value
+		}
+		return m
+	})
+
+	// Constrained *strong* becomes **strong**; protect existing **strong**
+	// first so it is not converted twice
+	var strong []string
+	text = reStrongUnconstr.ReplaceAllStringFunc(text, func(m string) string {
+		strong = append(strong, m)
+		return fmt.Sprintf("\x00%d\x00", len(strong)-1)
+	})
+	for i := 0; i < 2; i++ {
+		text = reStrongConstr.ReplaceAllString(text, "$1**$2**$3")
+	}
+	for i, s := range strong {
+		text = strings.Replace(text, fmt.Sprintf("\x00%d\x00", i), s, 1)
+	}
+
+	return reEmphasisDouble.ReplaceAllString(text, "_${1}_")
+}
+
+// resolve maps a cross-reference target to the anchor id it points at
+func (c *mdConverter) resolve(target string) string {
+	if id, ok := c.xrefs[target]; ok {
+		return id
+	}
+	return target
+}
+
+func isAnchorLine(trimmed string) bool {
+	_, ok := asciidoc.AnchorID(trimmed)
+	return ok
+}
+
+func isBlockAttributeLine(trimmed string) bool {
+	_, ok := asciidoc.IsBlockAttributeLine(trimmed)
+	return ok
+}
+
+func isSectionTitle(line string) bool {
+	_, _, ok := asciidoc.SectionTitle(line)
+	return ok
+}
+
+// skipUntil returns the index of the line closing the delimited block opened
+// at lines[start], or the last line if the block is never closed
+func skipUntil(lines []string, start int, delimiter string) int {
+	for i := start + 1; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == delimiter {
+			return i
+		}
+	}
+	return len(lines) - 1
+}
+
+// githubSlug returns the id GitHub generates for a heading
+func githubSlug(title string) string {
+	slug := strings.ToLower(strings.TrimSpace(title))
+	slug = reSlugStrip.ReplaceAllString(slug, "")
+	return strings.ReplaceAll(slug, " ", "-")
+}
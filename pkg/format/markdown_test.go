@@ -0,0 +1,190 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestToMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		input    string
+		expected []string
+		absent   []string
+	}{
+		{
+			name:     "header and attributes",
+			input:    "= API Docs\n:toc: left\n:revdate: Mon\n\nGenerated _{revdate}_",
+			expected: []string{"# API Docs\n", "Generated _Mon_"},
+			absent:   []string{":toc:"},
+		},
+		{
+			name:     "sections with anchors",
+			input:    "== Types\n\n// tag::type-User[]\n[[type_user]]\n=== User\n// end::type-User[]",
+			expected: []string{"## Types\n", "<a id=\"type_user\"></a>\n\n### User\n"},
+			absent:   []string{"tag::", "[[type_user]]"},
+		},
+		{
+			name:     "cross-references by id and by title",
+			input:    "[[type_user]]\n=== User\n\nSee <<User,`User`>> and <<type_user>>.",
+			expected: []string{"See [`User`](#type_user) and [type_user](#type_user)."},
+		},
+		{
+			name:     "cross-reference inside a code span",
+			input:    "[[input_page]]\n=== Page\n\n* `page : <<Page,`Page`>>`",
+			expected: []string{"- `page :` [`Page`](#input_page)"},
+		},
+		{
+			name:     "strong and emphasis",
+			input:    "*Query Name:* _users_ and **already** strong",
+			expected: []string{"**Query Name:** _users_ and **already** strong"},
+		},
+		{
+			name:     "angle brackets are escaped outside code",
+			input:    "Returns List<String> or `Map<K,V>`",
+			expected: []string{"Returns List&lt;String> or `Map<K,V>`"},
+		},
+		{
+			name: "source block with callouts",
+			input: ".query: users\n[source, kotlin]\n----\nusers(\n  limit: Int <1>\n): [User] <2>\n----\n\n" +
+				"<1> `limit`: page size\n<2> _RETURNS_: the users",
+			expected: []string{
+				"**query: users**\n\n```kotlin\nusers(\n  limit: Int (1)\n): [User] (2)\n```",
+				"1. `limit`: page size\n2. _RETURNS_: the users",
+			},
+		},
+		{
+			name:     "admonition block",
+			input:    "[WARNING]\n====\nFirst line. +\nSecond line.\n====",
+			expected: []string{"> [!WARNING]\n> First line.\\\n> Second line.\n"},
+		},
+		{
+			name:     "admonition paragraph",
+			input:    "NOTE: Remember this.",
+			expected: []string{"> [!NOTE]\n> Remember this."},
+		},
+		{
+			name:     "nested lists",
+			input:    ".Arguments\n* `id`\n** nested\n  * indented",
+			expected: []string{"**Arguments**\n\n- `id`\n  - nested\n  - indented"},
+		},
+		{
+			name: "table with styles, spans and multi-line cells",
+			input: ".type: User\n[options=\"header\",cols=\"2a,2m,5a\"]\n|===\n| Type | Field | Description\n\n" +
+				"| `ID` | id | The id\n\n| `String` | name | The name\n\n.Changelog\n* add: 1.0.0\n" +
+				"3+^h| Group\n|===",
+			expected: []string{
+				"**type: User**\n\n| Type | Field | Description |\n| --- | --- | --- |\n",
+				"| `ID` | `id` | The id |\n",
+				"| `String` | `name` | The name<br><br>**Changelog**<br>• add: 1.0.0 |\n",
+				"| **Group** |  |  |\n",
+			},
+		},
+		{
+			name: "list of an object type in a table cell",
+			input: "[[type_post]]\n=== Post\n\n[options=\"header\",cols=\"2a,2m,5a\"]\n|===\n" +
+				"| Type | Field | Description\n\n| [<<Post,`Post`>>]\n| posts\n| Posts by this user\n|===",
+			expected: []string{"| [[`Post`](#type_post)] | `posts` | Posts by this user |\n"},
+		},
+		{
+			name:     "diagram block keeps its language",
+			input:    "[mermaid, type-diagram, svg]\n----\nclassDiagram\n----",
+			expected: []string{"```mermaid\nclassDiagram\n```"},
+		},
+		{
+			name:     "comments, includes and page breaks are dropped",
+			input:    "////\nblock comment\n////\n// line comment\ninclude::attrs.adoc[]\ntoc::[]\n<<<\nText",
+			expected: []string{"Text\n"},
+			absent:   []string{"comment", "include::", "toc::", "&lt;"},
+		},
+		{
+			name:     "unset attribute",
+			input:    ":name: value\n{name}\n:name!:\n\n{name}",
+			expected: []string{"value\n\n{name}"},
+		},
+		{
+			name:     "literal, sidebar and open blocks",
+			input:    "....\nraw <1>\n....\n\n.Aside\n****\nSide text\n****\n\n--\nOpen text\n--",
+			expected: []string{"```\nraw (1)\n```", "**Aside**\n\nSide text", "Open text"},
+			absent:   []string{"....", "****", "--\n"},
+		},
+		{
+			name:     "ordered list with continuation",
+			input:    ". one\n.. two\n+\ncontinued",
+			expected: []string{"1. one\n  1. two\n\ncontinued"},
+		},
+		{
+			name:     "links, inline anchors and line breaks",
+			input:    "See https://example.com[the site], link:mailto:api@example.com[] and [[here]]here +\nnext",
+			expected: []string{"See [the site](https://example.com), mailto:api@example.com and <a id=\"here\"></a>here\\\nnext"},
+		},
+		{
+			name:     "double emphasis, passthrough and thematic break",
+			input:    "__double__ and `+{literal}+`\n\n'''\n\nAfter",
+			expected: []string{"_double_ and `{literal}`\n\n---\n\nAfter"},
+		},
+		{
+			name:     "table without header and with escaped pipe",
+			input:    "|===\n| a \\| b | c\n|===",
+			expected: []string{"|  |  |\n| --- | --- |\n| a \\| b | c |"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := ToMarkdown(tc.input)
+			for _, expected := range tc.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("Expected output to contain %q, got:\n%s", expected, result)
+				}
+			}
+			for _, absent := range tc.absent {
+				if strings.Contains(result, absent) {
+					t.Errorf("Expected output NOT to contain %q, got:\n%s", absent, result)
+				}
+			}
+		})
+	}
+}
+
+// TestToMarkdownGeneratedDocuments converts the committed sample documents
+// and checks that no AsciiDoc markup is left outside code blocks, so that a
+// template using a construct the converter does not handle is caught here
+func TestToMarkdownGeneratedDocuments(t *testing.T) {
+	files, err := filepath.Glob("../../test/*/*.adoc")
+	if err != nil || len(files) == 0 {
+		t.Fatalf("No sample documents found: %v", err)
+	}
+	leftovers := regexp.MustCompile(
+		`^(\|===|//|----|\.\.\.\.|====|\*\*\*\*|:[\w-]+:|\[\w*[,=]?.*\]$|\+$)|<<|&lt;&lt;|\[\[[\w:.-]+\]\]`)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			doc, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fenced := false
+			for i, line := range strings.Split(ToMarkdown(string(doc)), "\n") {
+				if strings.HasPrefix(line, "```") {
+					fenced = !fenced
+					continue
+				}
+				if !fenced && leftovers.MatchString(line) {
+					t.Errorf("Line %d still contains AsciiDoc markup: %s", i+1, line)
+				}
+			}
+		})
+	}
+}
+
+func TestToMarkdownLastSectionWinsForTitleXref(t *testing.T) {
+	input := "[[query_user]]\n=== User\n\n[[type_user]]\n=== User\n\nReturns <<User,`User`>>"
+	result := ToMarkdown(input)
+	if !strings.Contains(result, "[`User`](#type_user)") {
+		t.Errorf("Title cross-reference should resolve to the type section, got:\n%s", result)
+	}
+}
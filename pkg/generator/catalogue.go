@@ -5,14 +5,11 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/template"
-	"time"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/changelog"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
-	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/templates"
 )
 
 // collectCatalogueEntries collects catalogue entries from a schema definition's fields
@@ -71,8 +68,8 @@ func (g *Generator) collectCatalogueData() CatalogueData {
 
 	return CatalogueData{
 		SubTitle:       g.config.SubTitle,
-		RevDate:        time.Now().Format("Mon, 02 Jan 2006 15:04:05 MST"),
-		CommandLine:    strings.Join(os.Args, " "),
+		RevDate:        g.revisionDate(),
+		CommandLine:    g.commandLine(),
 		Queries:        queries,
 		Mutations:      mutations,
 		MutationGroups: mutationGroups,
@@ -90,126 +87,33 @@ func (g *Generator) writeCatalogueSection() error {
 
 	data := g.collectCatalogueData()
 
-	// Build template based on what's enabled
-	var templateParts []string
+	// Render each enabled section from its own template
+	var sections []string
 
 	// Add queries section if enabled and schema defines queries
 	if g.config.IncludeQueries && g.schema.Query != nil {
-		querySection := `== Queries
-
-*Queries* are how clients *read or fetch data* in GraphQL.
-They describe _what_ data the client wants, not _how_ to get it.
-
-The following table provides a quick reference to all available queries in the GraphQL API.
-
-{{- if .Queries }}
-
-[options="header",cols="2m,5a"]
-|===
-| Name | Description
-{{- range .Queries }}
-| {{.Name}} | {{.Description}}{{if .Changelog}}
-{{.Changelog}}{{end}}
-{{- end }}
-|===
-{{- else }}
-
-[NOTE]
-====
-No queries exist in this schema.
-====
-{{- end }}
-
-`
-		templateParts = append(templateParts, querySection)
+		sections = append(sections, "catalogue-queries")
 	}
 
 	// Add mutations section if enabled and schema defines mutations
 	if g.config.IncludeMutations && g.schema.Mutation != nil {
-		mutationSection := `
-== Mutations
-
-
-*Mutations* are how clients *write or modify data* for example, creating, updating, or deleting records.
-
-A mutation looks similar to a query, but it describes an action that changes data.
-
-The following table provides a quick reference to all available mutations in the GraphQL API.
-
-{{- if .MutationGroups }}
-
-[options="header",cols="2m,5a"]
-|===
-| Name | Description
-{{- range .MutationGroups }}
-2+^h| {{.GroupName}}
-{{- range .Mutations }}
-| {{.Name}} | {{.Description}}{{if .Changelog}}
-{{.Changelog}}{{end}}
-{{- end }}
-{{- end }}
-|===
-{{- else if .Mutations }}
-
-[options="header",cols="2m,5a"]
-|===
-| Name | Description
-{{- range .Mutations }}
-| {{.Name}} | {{.Description}}{{if .Changelog}}
-{{.Changelog}}{{end}}
-{{- end }}
-|===
-{{- else }}
-
-[NOTE]
-====
-No mutations exist in this schema.
-====
-{{- end }}
-
-`
-		templateParts = append(templateParts, mutationSection)
+		sections = append(sections, "catalogue-mutations")
 	}
 
 	// Add subscriptions section if enabled and schema defines subscriptions
 	if g.config.IncludeSubscriptions && g.schema.Subscription != nil {
-		subscriptionSection := `== Subscriptions
-
-{{- if .Subscriptions }}
-
-The following table provides a quick reference to all available subscriptions in the GraphQL API.
-
-[options="header",cols="2m,5a"]
-|===
-| Name | Description
-{{- range .Subscriptions }}
-| {{.Name}} | {{.Description}}{{if .Changelog}}
-{{.Changelog}}{{end}}
-{{- end }}
-|===
-{{- else }}
-
-[NOTE]
-====
-No subscriptions exist in this schema.
-====
-{{- end }}
-
-`
-		templateParts = append(templateParts, subscriptionSection)
+		sections = append(sections, "catalogue-subscriptions")
 	}
 
-	// Combine all enabled sections
-	catalogueBody := strings.Join(templateParts, "")
-
-	tmpl, err := template.New("catalogue-body").Parse(catalogueBody)
-	if err != nil {
-		return fmt.Errorf("error parsing catalogue body template: %v", err)
-	}
+	for _, name := range sections {
+		tmpl, err := g.parseTemplate(name)
+		if err != nil {
+			return fmt.Errorf("error parsing %s template: %v", name, err)
+		}
 
-	err = tmpl.Execute(g.writer, data)
-	if err != nil {
-		return fmt.Errorf("error executing catalogue body template: %v", err)
+		if err := tmpl.Execute(g.writer, data); err != nil {
+			return fmt.Errorf("error executing %s template: %v", name, err)
+		}
 	}
 
 	return nil
@@ -219,7 +123,22 @@ No subscriptions exist in this schema.
 func (g *Generator) generateCatalogue() error {
 	data := g.collectCatalogueData()
 
-	tmpl, err := template.New("catalogue").Parse(templates.CatalogueTemplate)
+	title := g.config.Title
+	if title == "" {
+		title = defaultCatalogueTitle
+	}
+	if g.config.SubTitle != "" {
+		title += ": " + g.config.SubTitle
+	}
+	header, err := g.headerData(title)
+	if err != nil {
+		return err
+	}
+	if data.Header, err = g.renderTemplate("header", header); err != nil {
+		return fmt.Errorf("error executing header template: %v", err)
+	}
+
+	tmpl, err := g.parseTemplate("catalogue")
 	if err != nil {
 		return fmt.Errorf("error parsing catalogue template: %v", err)
 	}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// defaultDeprecationReason is the reason the GraphQL specification assigns to
+// a @deprecated directive given without one
+const defaultDeprecationReason = "No longer supported"
+
+// replacementPattern matches a replacement hint in a deprecation reason, such
+// as "Use newField instead" or "use `User.fullName`", capturing the name
+var replacementPattern = regexp.MustCompile(
+	"\\b([Uu]se)\\s+`?([_A-Za-z][_0-9A-Za-z]*(?:\\.[_A-Za-z][_0-9A-Za-z]*)?)`?",
+)
+
+// deprecationReason returns the reason given by a @deprecated directive and
+// whether the directive is present at all
+func deprecationReason(directives ast.DirectiveList) (string, bool) {
+	d := directives.ForName("deprecated")
+	if d == nil {
+		return "", false
+	}
+	if arg := d.Arguments.ForName("reason"); arg != nil && arg.Value != nil && strings.TrimSpace(arg.Value.Raw) != "" {
+		return strings.TrimSpace(arg.Value.Raw), true
+	}
+	return defaultDeprecationReason, true
+}
+
+// getDeprecationWarning renders a WARNING admonition with the deprecation
+// reason, or an empty string if the element has no @deprecated directive
+func (g *Generator) getDeprecationWarning(
+	directives ast.DirectiveList,
+	definitionsMap map[string]*ast.Definition,
+) string {
+	reason, ok := deprecationReason(directives)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("[WARNING]\n====\n*Deprecated:* %s\n====", g.linkReplacement(reason, definitionsMap))
+}
+
+// getArgumentDeprecation renders the deprecation warning of an argument as a
+// continuation of its bullet list item
+func (g *Generator) getArgumentDeprecation(
+	directives ast.DirectiveList,
+	definitionsMap map[string]*ast.Definition,
+) string {
+	warning := g.getDeprecationWarning(directives, definitionsMap)
+	if warning == "" {
+		return ""
+	}
+	return "+\n" + warning + "\n"
+}
+
+// appendDeprecationWarning adds the deprecation warning, if any, to a table
+// cell description
+func (g *Generator) appendDeprecationWarning(
+	desc string,
+	directives ast.DirectiveList,
+	definitionsMap map[string]*ast.Definition,
+) string {
+	warning := g.getDeprecationWarning(directives, definitionsMap)
+	if warning == "" {
+		return desc
+	}
+	if strings.TrimSpace(desc) == "" {
+		return warning
+	}
+	return desc + "\n\n" + warning
+}
+
+// linkReplacement turns the name in a "use X instead" hint into a
+// cross-reference when X is a documented type, an operation, or a field
+// written as Type.field, which links to the section of its type. Names that
+// cannot be resolved are left as they are.
+func (g *Generator) linkReplacement(reason string, definitionsMap map[string]*ast.Definition) string {
+	return replacementPattern.ReplaceAllStringFunc(reason, func(match string) string {
+		groups := replacementPattern.FindStringSubmatch(match)
+		ref := g.replacementReference(groups[2], definitionsMap)
+		if ref == "" {
+			return match
+		}
+		return groups[1] + " " + ref
+	})
+}
+
+// replacementReference returns a cross-reference to the named type,
+// operation or Type.field, or an empty string if there is nothing to link to
+func (g *Generator) replacementReference(name string, definitionsMap map[string]*ast.Definition) string {
+	typeName, fieldName, isField := strings.Cut(name, ".")
+	if !isField {
+		if _, ok := definitionsMap[name]; ok {
+			return parser.ProcessTypeName(name, definitionsMap)
+		}
+		return g.operationReference(name, name)
+	}
+
+	if g.isRootType(typeName) {
+		return g.operationReference(fieldName, name)
+	}
+	def, ok := definitionsMap[typeName]
+	if !ok || def.Fields.ForName(fieldName) == nil {
+		return ""
+	}
+	// Fields have no anchors of their own, so the link goes to the type
+	return fmt.Sprintf("<<%s,`%s`>>", typeName, name)
+}
+
+// isRootType reports whether name is the schema's query, mutation or
+// subscription type
+func (g *Generator) isRootType(name string) bool {
+	for _, root := range []*ast.Definition{g.schema.Query, g.schema.Mutation, g.schema.Subscription} {
+		if root != nil && root.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// operationReference returns a cross-reference to the documented query,
+// mutation or subscription with the given name, labelled with label
+func (g *Generator) operationReference(name, label string) string {
+	operations := []struct {
+		kind     ast.Operation
+		root     *ast.Definition
+		included bool
+	}{
+		{ast.Query, g.schema.Query, g.config.IncludeQueries},
+		{ast.Mutation, g.schema.Mutation, g.config.IncludeMutations},
+		{ast.Subscription, g.schema.Subscription, g.config.IncludeSubscriptions},
+	}
+	for _, op := range operations {
+		if op.root == nil || !op.included {
+			continue
+		}
+		f := op.root.Fields.ForName(name)
+		if f == nil || !g.shouldIncludeField(f.Name, f.Description, f.Directives) {
+			continue
+		}
+		return fmt.Sprintf("<<%s,`%s`>>", operationAnchor(op.kind, f.Name), label)
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/diagram"
+)
+
+// generateDiagram writes the type relationship diagram, either as a section
+// of the document or, when a diagram file is configured, to that file alone.
+// It returns the number of types drawn.
+func (g *Generator) generateDiagram() (int, error) {
+	d := diagram.New(g.schema)
+	if g.config.DiagramRoot != "" {
+		var err error
+		if d, err = diagram.Reachable(g.schema, g.config.DiagramRoot); err != nil {
+			return 0, err
+		}
+	}
+
+	style := strings.ToLower(g.config.Diagram)
+	source, err := d.Render(style)
+	if err != nil {
+		return 0, err
+	}
+
+	if g.config.DiagramFile != "" {
+		//nolint:gosec // documentation output
+		if err := os.WriteFile(g.config.DiagramFile, []byte(source+"\n"), 0o644); err != nil {
+			return 0, fmt.Errorf("failed to write diagram '%s': %v", g.config.DiagramFile, err)
+		}
+		g.metrics.LogProgress("Diagram", fmt.Sprintf("Wrote %d types to %s", d.Len(), g.config.DiagramFile))
+		return d.Len(), nil
+	}
+
+	data := DiagramData{Style: style, Root: g.config.DiagramRoot}
+	if d.Len() > 0 {
+		data.Source = source
+	}
+	if err := g.executeTemplate("diagram", data); err != nil {
+		return 0, err
+	}
+	return d.Len(), nil
+}
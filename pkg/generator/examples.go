@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/example"
+)
+
+// getExampleBlock renders the example operation for a root field, with its
+// variables and a sample response, as titled source blocks. It is empty unless
+// examples are enabled.
+// kind is "query", "mutation" or "subscription".
+func (g *Generator) getExampleBlock(kind string, f *ast.FieldDefinition) string {
+	if !g.config.Examples {
+		return ""
+	}
+	op := example.New(g.schema, g.config.ExampleDepth, g.config.ScalarSamples).Operation(kind, f)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ".Example %s\n[source, graphql]\n----\n%s\n----", kind, op.Document)
+	if op.Variables != "" {
+		fmt.Fprintf(&b, "\n\n.Variables\n[source, json]\n----\n%s\n----", op.Variables)
+	}
+	fmt.Fprintf(&b, "\n\n.Response\n[source, json]\n----\n%s\n----", op.Response)
+	return b.String()
+}
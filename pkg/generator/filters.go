@@ -1,9 +1,12 @@
 package generator
 
 import (
+	"path"
 	"strings"
 
 	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
 )
 
 // isBuiltInScalar checks if a type name is a built-in GraphQL scalar
@@ -113,5 +116,26 @@ func (g *Generator) shouldIncludeField(name, description string, directives ast.
 	if !g.config.IncludeZeroVersion && isZeroVersion(description) {
 		return false
 	}
-	return true
+	return matchesFilterRules(name, g.config.Filters)
+}
+
+// matchesFilterRules checks a name against the configured filter rules.
+// With include patterns present the name must match one of them; a name
+// matching any exclude pattern is always rejected.
+func matchesFilterRules(name string, rules config.FilterRules) bool {
+	if len(rules.Include) > 0 && !matchesAnyPattern(name, rules.Include) {
+		return false
+	}
+	return !matchesAnyPattern(name, rules.Exclude)
+}
+
+// matchesAnyPattern reports whether name matches any of the path.Match
+// patterns. Malformed patterns never match; Config.Validate rejects them.
+func matchesAnyPattern(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
 }
@@ -1,36 +1,54 @@
 package generator
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
 	"sort"
 	"strings"
 	"text/template"
-	"time"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/antora"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/diagram"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/format"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/metrics"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/templates"
 )
 
+// defaultTitle is the document title used when none is configured
+const defaultTitle = "GraphQL Documentation"
+
+// defaultCatalogueTitle is the title of the catalogue when none is configured
+const defaultCatalogueTitle = "GraphQL API Catalogue"
+
 // Generator handles AsciiDoc generation from GraphQL schemas
 type Generator struct {
-	config  *config.Config
-	schema  *ast.Schema
-	writer  io.Writer
-	metrics *metrics.Metrics
+	config    *config.Config
+	schema    *ast.Schema
+	writer    io.Writer
+	metrics   *metrics.Metrics
+	templates *templates.Set
+	// sourceNames maps schema file names to their names in "Defined in"
+	// notes and file groups; it is filled on first use
+	sourceNames map[string]string
+	// group limits the sections to one --group-by group while it is written
+	group *group
 }
 
-// New creates a new Generator instance
+// New creates a new Generator instance using the built-in templates. Any
+// template directory named in the configuration is loaded by Generate.
 func New(cfg *config.Config, schema *ast.Schema, writer io.Writer) *Generator {
 	return &Generator{
-		config:  cfg,
-		schema:  schema,
-		writer:  writer,
-		metrics: metrics.New(cfg),
+		config:    cfg,
+		schema:    schema,
+		writer:    writer,
+		metrics:   metrics.New(cfg),
+		templates: templates.Default(),
 	}
 }
 
@@ -54,6 +72,29 @@ func formatArgumentListItem(name, typeName string, defaultValue *ast.Value, dire
 	return fmt.Sprintf("* `%s`\n", base)
 }
 
+// formatFieldArgumentListItem returns the bullet point of an object or interface field argument. typeName is
+// AsciiDoc markup from parser.ProcessTypeName, which may hold a cross-reference, so only the name, default value
+// and directives are put in code spans.
+func formatFieldArgumentListItem(name, typeName string, defaultValue *ast.Value, directives ast.DirectiveList) string {
+	item := "* `" + name + "` : " + typeName
+	if defaultValue != nil {
+		item += " = `" + defaultValue.String() + "`"
+	}
+	if len(directives) > 0 {
+		item += " `" + formatDirectiveList(directives) + "`"
+	}
+	return item + "\n"
+}
+
+// operationAnchor returns the id of the section documenting a query, mutation
+// or subscription. Mutation anchors are snake case, the others lower case.
+func operationAnchor(kind ast.Operation, name string) string {
+	if kind == ast.Mutation {
+		return "mutation_" + parser.CamelToSnake(name)
+	}
+	return string(kind) + "_" + strings.ToLower(name)
+}
+
 // formatDirectiveList formats a list of directives as a string, e.g. "@maxElements(max: 50) @length(max: 100)".
 func formatDirectiveList(directives ast.DirectiveList) string {
 	var parts []string
@@ -123,18 +164,44 @@ func splitOnArgumentsMarker(processedDesc string) (mainDesc, numberedRefs string
 	return mainDesc, numberedRefs
 }
 
-// defaultFuncMap returns the standard template function map used by most templates.
+// defaultFuncMap returns the template function map available to every template,
+// including user-supplied overrides.
 func defaultFuncMap() template.FuncMap {
 	return template.FuncMap{
+		"processDescription":             parser.ProcessDescription,
 		"printAsciiDocTagsTmpl":          func(s string) string { return s },
 		"convertDescriptionToRefNumbers": func(desc string, _ bool) string { return desc },
 	}
 }
 
+// loadTemplates overlays the configured template directory (if any) onto the
+// built-in templates and checks that every override parses.
+func (g *Generator) loadTemplates() error {
+	set, err := templates.Load(g.config.TemplatesDir)
+	if err != nil {
+		return err
+	}
+
+	for name, path := range set.Overrides() {
+		if _, err := template.New(name).Funcs(defaultFuncMap()).Parse(set.Source(name)); err != nil {
+			return fmt.Errorf("invalid template '%s': %w", path, err)
+		}
+	}
+
+	g.templates = set
+	return nil
+}
+
+// parseTemplate parses the named template from the generator's template set
+// with the default function map.
+func (g *Generator) parseTemplate(name string) (*template.Template, error) {
+	return template.New(name).Funcs(defaultFuncMap()).Parse(g.templates.Source(name))
+}
+
 // executeTemplate parses and executes a named template with the default function map,
 // writing to the generator's writer. Errors are logged to stderr.
-func (g *Generator) executeTemplate(name, tmplStr string, data interface{}) error {
-	tmpl, err := template.New(name).Funcs(defaultFuncMap()).Parse(tmplStr)
+func (g *Generator) executeTemplate(name string, data interface{}) error {
+	tmpl, err := g.parseTemplate(name)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing %s template: %v\n", name, err)
 		return err
@@ -147,8 +214,77 @@ func (g *Generator) executeTemplate(name, tmplStr string, data interface{}) erro
 	return nil
 }
 
-// Generate generates the complete AsciiDoc documentation
+// renderTemplate executes a named template and returns the result as a string,
+// for templates whose output is embedded in another template.
+func (g *Generator) renderTemplate(name string, data interface{}) (string, error) {
+	tmpl, err := g.parseTemplate(name)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// CheckFormats reports an unsupported output or diagram format in the
+// configuration, and a split output in a format that cannot be split. The
+// configuration keeps formats as plain names, so they are checked here
+// rather than when the flags are validated.
+func CheckFormats(cfg *config.Config) error {
+	if _, err := format.New(cfg.Format); err != nil {
+		return err
+	}
+	splittable := strings.EqualFold(cfg.Format, format.AsciiDoc) || strings.EqualFold(cfg.Format, format.HTML)
+	if cfg.SplitDir != "" && !splittable {
+		return fmt.Errorf("--split-dir requires the asciidoc or html format")
+	}
+	if cfg.Diagram != "" && !diagram.IsFormat(cfg.Diagram) {
+		return fmt.Errorf("unsupported diagram format '%s' (supported: %s)", cfg.Diagram, strings.Join(diagram.Formats, ", "))
+	}
+	return nil
+}
+
+// Generate generates the complete documentation in the configured output
+// format. The document is always built as AsciiDoc and then handed to the
+// format's renderer, which writes it to the generator's writer.
 func (g *Generator) Generate() error {
+	if err := CheckFormats(g.config); err != nil {
+		return err
+	}
+	renderer, err := format.New(g.config.Format)
+	if err != nil {
+		return err
+	}
+	if err := g.loadTemplates(); err != nil {
+		return err
+	}
+
+	out := g.writer
+	var doc bytes.Buffer
+	g.writer = &doc
+	err = g.generate()
+	g.writer = out
+	if err != nil {
+		return err
+	}
+
+	if g.config.SplitDir != "" {
+		site := antora.Split(doc.String())
+		g.metrics.LogProgress("Output", fmt.Sprintf("Writing %d pages to %s", len(site.Pages), g.config.SplitDir))
+		if strings.EqualFold(g.config.Format, format.HTML) {
+			return format.WriteHTMLSite(site, g.config.SplitDir)
+		}
+		return site.Write(g.config.SplitDir)
+	}
+	return renderer.Render(out, doc.String())
+}
+
+// generate writes the complete AsciiDoc documentation to g.writer
+func (g *Generator) generate() error {
+
 	// Check if catalogue mode is enabled
 	if g.config.Catalogue {
 		return g.generateCatalogue()
@@ -159,7 +295,9 @@ func (g *Generator) Generate() error {
 
 	// Print header
 	headerTimer := g.metrics.StartSection("Header")
-	g.printHeader()
+	if err := g.printHeader(); err != nil {
+		return fmt.Errorf("error generating header: %w", err)
+	}
 	headerTimer.AddCount(1)
 	headerTimer.Finish()
 
@@ -171,6 +309,17 @@ func (g *Generator) Generate() error {
 	catalogueTimer.AddCount(1)
 	catalogueTimer.Finish()
 
+	// Write the type diagram
+	if g.config.Diagram != "" {
+		timer := g.metrics.StartSection("Diagram")
+		count, err := g.generateDiagram()
+		if err != nil {
+			return fmt.Errorf("error generating diagram: %w", err)
+		}
+		timer.AddCount(count)
+		timer.Finish()
+	}
+
 	// Create definitions map for type processing
 	g.metrics.LogProgress("Setup", "Creating definitions map")
 	definitionsMap := make(map[string]*ast.Definition)
@@ -189,90 +338,191 @@ func (g *Generator) Generate() error {
 
 	g.metrics.LogProgress("Setup", fmt.Sprintf("Found %d total definitions", len(g.schema.Types)))
 
-	// Generate sections based on configuration
-	if g.config.IncludeQueries && g.schema.Query != nil {
+	// Generate sections based on configuration, for the whole schema or
+	// for each group in turn
+	if g.config.GroupBy != "" {
+		if err := g.generateGroups(sortedDefs, definitionsMap); err != nil {
+			return fmt.Errorf("error generating groups: %w", err)
+		}
+	} else {
+		g.generateSections(sortedDefs, definitionsMap)
+	}
+
+	// Log final metrics table
+	g.metrics.LogMetricsTable()
+
+	return nil
+}
+
+// generateSections writes the operation, type, directive and scalar
+// sections enabled by the configuration, limited to the current group when
+// there is one
+func (g *Generator) generateSections(sortedDefs []*ast.Definition, definitionsMap map[string]*ast.Definition) {
+	if g.config.IncludeQueries && g.schema.Query != nil && g.group.hasFieldsOf(g.schema.Query) {
 		timer := g.metrics.StartSection("Queries")
 		count := g.generateQueries(definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeMutations && g.schema.Mutation != nil {
+	if g.config.IncludeMutations && g.schema.Mutation != nil && g.group.hasFieldsOf(g.schema.Mutation) {
 		timer := g.metrics.StartSection("Mutations")
 		count := g.generateMutations(definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeSubscriptions && g.schema.Subscription != nil {
+	if g.config.IncludeSubscriptions && g.schema.Subscription != nil && g.group.hasFieldsOf(g.schema.Subscription) {
 		timer := g.metrics.StartSection("Subscriptions")
 		count := g.generateSubscriptions(definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeTypes {
+	if g.config.IncludeTypes && g.group.hasKind(ast.Object) {
 		timer := g.metrics.StartSection("Types")
 		count := g.generateTypes(sortedDefs, definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeEnums {
+	if g.config.IncludeInterfaces && g.group.hasKind(ast.Interface) {
+		timer := g.metrics.StartSection("Interfaces")
+		count := g.generateInterfaces(sortedDefs, definitionsMap)
+		timer.AddCount(count)
+		timer.Finish()
+	}
+
+	if g.config.IncludeUnions && g.group.hasKind(ast.Union) {
+		timer := g.metrics.StartSection("Unions")
+		count := g.generateUnions(sortedDefs, definitionsMap)
+		timer.AddCount(count)
+		timer.Finish()
+	}
+
+	if g.config.IncludeEnums && g.group.hasKind(ast.Enum) {
 		timer := g.metrics.StartSection("Enums")
-		count := g.generateEnums(sortedDefs)
+		count := g.generateEnums(sortedDefs, definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeInputs {
+	if g.config.IncludeInputs && g.group.hasKind(ast.InputObject) {
 		timer := g.metrics.StartSection("Inputs")
 		count := g.generateInputs(sortedDefs, definitionsMap)
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeDirectives {
+	if g.config.IncludeDirectives && g.group.hasDirectives() {
 		timer := g.metrics.StartSection("Directives")
 		count := g.generateDirectives()
 		timer.AddCount(count)
 		timer.Finish()
 	}
 
-	if g.config.IncludeScalars {
+	if g.config.IncludeScalars && g.group.hasKind(ast.Scalar) {
 		timer := g.metrics.StartSection("Scalars")
 		count := g.generateScalars(sortedDefs)
 		timer.AddCount(count)
 		timer.Finish()
 	}
+}
 
-	// Log final metrics table
-	g.metrics.LogMetricsTable()
+// printHeader prints the AsciiDoc document header
+func (g *Generator) printHeader() error {
+	title := g.config.Title
+	if title == "" {
+		title = defaultTitle
+	}
+	data, err := g.headerData(title)
+	if err != nil {
+		return err
+	}
+	return g.executeTemplate("header", data)
+}
 
-	return nil
+// headerData returns the header of the full documentation and the catalogue,
+// with the configured attributes merged into the built-in ones and the
+// banner and preamble read or rendered
+func (g *Generator) headerData(title string) (HeaderData, error) {
+	data := HeaderData{
+		Title:          title,
+		Author:         g.config.Author,
+		Version:        g.config.DocVersion,
+		SchemaFile:     g.schemaSource(),
+		RevDate:        g.revisionDate(),
+		CommandLine:    g.commandLine(),
+		AttributesFile: g.config.AttributesFile,
+	}
+	data.Attributes = mergeAttributes([]config.Attribute{
+		{Name: "toc", Value: "left"},
+		{Name: "revdate", Value: data.RevDate},
+		{Name: "commandline", Value: data.CommandLine},
+		{Name: "sourceFile", Value: data.SchemaFile},
+		{Name: "reproducible"},
+		{Name: "page-partial"},
+		{Name: "sect-anchors"},
+		{Name: "table-caption!"},
+		{Name: "table-stripes", Value: "even"},
+		{Name: "pdf-page-size", Value: "A4"},
+		{Name: "tags", Value: "api, GraphQL, nodes, types, query"},
+	}, g.config.HeaderAttributes)
+
+	switch {
+	case g.config.NoBanner:
+	case g.config.BannerFile != "":
+		banner, err := readHeaderFile(g.config.BannerFile)
+		if err != nil {
+			return data, err
+		}
+		data.Banner = banner
+	default:
+		banner, err := g.renderTemplate("banner", data)
+		if err != nil {
+			return data, fmt.Errorf("error rendering banner template: %v", err)
+		}
+		data.Banner = strings.TrimRight(banner, "\n")
+	}
+
+	if g.config.PreambleFile != "" {
+		preamble, err := readHeaderFile(g.config.PreambleFile)
+		if err != nil {
+			return data, err
+		}
+		data.Preamble = preamble
+	}
+	return data, nil
 }
 
-// printHeader prints the AsciiDoc document header
-func (g *Generator) printHeader() {
-	fmt.Fprintln(g.writer, "= GraphQL Documentation")
-	fmt.Fprintln(g.writer, ":toc: left")
-	fmt.Fprintf(g.writer, ":revdate: %s\n", time.Now().Format("Mon, 02 Jan 2006 15:04:05 MST"))
-	fmt.Fprintf(g.writer, ":commandline: %s\n", strings.Join(os.Args, " "))
-	fmt.Fprintf(g.writer, ":sourceFile: %s\n", g.config.SchemaFile)
-	fmt.Fprintln(g.writer, ":reproducible:")
-	fmt.Fprintln(g.writer, ":page-partial:")
-	fmt.Fprintln(g.writer, ":sect-anchors:")
-	fmt.Fprintln(g.writer, ":table-caption!:")
-	fmt.Fprintln(g.writer, ":table-stripes: even")
-	fmt.Fprintln(g.writer, ":pdf-page-size: A4")
-	fmt.Fprintln(g.writer, ":tags: api, GraphQL, nodes, types, query")
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer, "[IMPORTANT]")
-	fmt.Fprintln(g.writer, "====")
-	fmt.Fprintf(g.writer, "This is automatically generated from the schema file `%s`. +\n", g.config.SchemaFile)
-	fmt.Fprintln(g.writer, "Do not edit this file directly. +")
-	fmt.Fprintln(g.writer, "Last generated _{revdate}_")
-	fmt.Fprintln(g.writer, "====")
-	fmt.Fprintln(g.writer)
+// mergeAttributes returns the built-in attributes with each configured one
+// replacing the built-in attribute of the same name in place, so ":toc: right"
+// moves nothing, and "toc!" unsets it. Other configured attributes follow in
+// order.
+func mergeAttributes(builtin, configured []config.Attribute) []config.Attribute {
+	merged := append([]config.Attribute(nil), builtin...)
+	for _, attr := range configured {
+		name := strings.TrimSuffix(attr.Name, "!")
+		replaced := false
+		for i := range merged {
+			if strings.TrimSuffix(merged[i].Name, "!") == name {
+				merged[i] = attr
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, attr)
+		}
+	}
+	return merged
+}
+
+// readHeaderFile reads a banner or preamble file without trailing blank lines
+func readHeaderFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read header file '%s': %v", path, err)
+	}
+	return strings.TrimRight(string(content), " \t\r\n"), nil
 }
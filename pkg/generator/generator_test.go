@@ -3,10 +3,14 @@ package generator
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
@@ -69,6 +73,80 @@ func TestGeneratorPrintHeader(t *testing.T) {
 	}
 }
 
+func TestGeneratorPrintHeaderReproducible(t *testing.T) {
+	t.Setenv(config.SourceDateEpochEnv, "")
+	dir := t.TempDir()
+	for name, modified := range map[string]time.Time{
+		"users.graphqls": time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+		"posts.graphqls": time.Date(2024, 5, 17, 14, 30, 0, 500, time.UTC),
+	} {
+		file := filepath.Join(dir, name)
+		if err := os.WriteFile(file, []byte("type Query { ok: Boolean }"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(file, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	originalArgs := os.Args
+	os.Args = []string{"/usr/local/bin/graphqls-to-asciidoc", "-p", "*.graphqls"}
+	defer func() { os.Args = originalArgs }()
+
+	header := func(cfg *config.Config) string {
+		var buf bytes.Buffer
+		if err := New(cfg, &ast.Schema{Types: make(map[string]*ast.Definition)}, &buf).printHeader(); err != nil {
+			t.Fatalf("printHeader returned error: %v", err)
+		}
+		return buf.String()
+	}
+
+	// The newest schema file dates the document
+	cfg := &config.Config{SchemaPattern: filepath.Join(dir, "*.graphqls"), Reproducible: true}
+	output := header(cfg)
+	for _, expected := range []string{
+		":revdate: Fri, 17 May 2024 14:30:00 UTC\n",
+		":commandline: graphqls-to-asciidoc -p *.graphqls\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Header should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+
+	// A supplied date wins
+	cfg.RevDate = "2025-01-01"
+	if output := header(cfg); !strings.Contains(output, ":revdate: Wed, 01 Jan 2025 00:00:00 UTC\n") {
+		t.Errorf("Expected the supplied revision date, got:\n%s", output)
+	}
+
+	// Absolute paths are made relative to the working directory
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := filepath.Join(wd, "schemas", "api.graphqls")
+	os.Args = []string{"/usr/local/bin/graphqls-to-asciidoc", "-s", schema, "-o=" + filepath.Join(wd, "api.adoc")}
+	output = header(&config.Config{SchemaFile: schema, Reproducible: true, RevDate: "2025-01-01"})
+	for _, expected := range []string{
+		":commandline: graphqls-to-asciidoc -s schemas/api.graphqls -o=api.adoc\n",
+		":sourceFile: schemas/api.graphqls\n",
+		"generated from the schema file `schemas/api.graphqls`",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Header should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, wd) {
+		t.Errorf("Expected no absolute paths in reproducible mode, got:\n%s", output)
+	}
+
+	// Outside reproducible mode the full command line and paths are kept
+	output = header(&config.Config{SchemaFile: schema})
+	if !strings.Contains(output, "/usr/local/bin/") || !strings.Contains(output, ":sourceFile: "+schema) {
+		t.Errorf("Expected the program path and absolute paths, got:\n%s", output)
+	}
+}
+
 func TestGenerateWithEmptySchema(t *testing.T) {
 	cfg := config.NewConfig()
 	cfg.SchemaFile = "empty.graphql"
@@ -437,6 +515,245 @@ func TestGenerateTypes(t *testing.T) {
 	}
 }
 
+func TestGenerateInterfaces(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+
+	nodeDef := &ast.Definition{
+		Kind:        ast.Interface,
+		Name:        "Node",
+		Description: "An object with a globally unique ID",
+		Fields: ast.FieldList{
+			&ast.FieldDefinition{
+				Name:        "id",
+				Description: "Unique identifier",
+				Type:        &ast.Type{NamedType: "ID", NonNull: true},
+			},
+		},
+	}
+	auditableDef := &ast.Definition{
+		Kind: ast.Interface,
+		Name: "Auditable",
+		Fields: ast.FieldList{
+			&ast.FieldDefinition{
+				Name: "createdAt",
+				Type: &ast.Type{NamedType: "String"},
+			},
+		},
+	}
+	userDef := &ast.Definition{
+		Kind:       ast.Object,
+		Name:       "User",
+		Interfaces: []string{"Node", "Auditable"},
+		Fields: ast.FieldList{
+			&ast.FieldDefinition{Name: "id", Type: &ast.Type{NamedType: "ID", NonNull: true}},
+			&ast.FieldDefinition{Name: "createdAt", Type: &ast.Type{NamedType: "String"}},
+		},
+	}
+	postDef := &ast.Definition{
+		Kind:       ast.Object,
+		Name:       "Post",
+		Interfaces: []string{"Node"},
+		Fields: ast.FieldList{
+			&ast.FieldDefinition{Name: "id", Type: &ast.Type{NamedType: "ID", NonNull: true}},
+		},
+	}
+
+	schema := &ast.Schema{
+		Types: map[string]*ast.Definition{
+			"Node":      nodeDef,
+			"Auditable": auditableDef,
+			"User":      userDef,
+			"Post":      postDef,
+		},
+	}
+
+	var buf bytes.Buffer
+	gen := New(cfg, schema, &buf)
+
+	_ = gen.Generate()
+	output := buf.String()
+
+	expectedContent := []string{
+		"== Interfaces",
+		"// tag::interface-Node[]",
+		"[[interface_node]]",
+		"=== Node",
+		"An object with a globally unique ID",
+		".interface: Node",
+		"// tag::interface-implementors-Node[]",
+		".Implemented by\n* <<Post,`Post`>>\n* <<User,`User`>>\n",
+		"// end::interface-Node[]",
+		".Implemented by\n* <<User,`User`>>\n",
+		"// tag::type-implements-User[]",
+		"*Implements:* <<Node,`Node`>>, <<Auditable,`Auditable`>>",
+		"*Implements:* <<Node,`Node`>>\n",
+	}
+
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Interface output should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+
+	// Interfaces must not be listed as object types
+	if strings.Contains(output, "// tag::type-Node[]") {
+		t.Error("Interface Node should not be rendered in the Types section")
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := config.NewConfig()
+		cfg.SchemaFile = testSchemaFile
+		cfg.IncludeInterfaces = false
+
+		var buf bytes.Buffer
+		_ = New(cfg, schema, &buf).Generate()
+		if strings.Contains(buf.String(), "== Interfaces") {
+			t.Error("Output should NOT contain Interfaces section when disabled")
+		}
+	})
+
+	t.Run("no interfaces", func(t *testing.T) {
+		var buf bytes.Buffer
+		_ = New(cfg, &ast.Schema{Types: map[string]*ast.Definition{"Post": postDef}}, &buf).Generate()
+		if strings.Contains(buf.String(), "== Interfaces") {
+			t.Error("Output should NOT contain Interfaces section when schema has none")
+		}
+	})
+}
+
+func TestGenerateUnions(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+
+	userDef := &ast.Definition{
+		Kind:   ast.Object,
+		Name:   "User",
+		Fields: ast.FieldList{&ast.FieldDefinition{Name: "name", Type: &ast.Type{NamedType: "String"}}},
+	}
+	postDef := &ast.Definition{
+		Kind:   ast.Object,
+		Name:   "Post",
+		Fields: ast.FieldList{&ast.FieldDefinition{Name: "title", Type: &ast.Type{NamedType: "String"}}},
+	}
+	searchResultDef := &ast.Definition{
+		Kind:        ast.Union,
+		Name:        "SearchResult",
+		Description: "Anything that can be returned from a search",
+		Types:       []string{"User", "Post"},
+	}
+	feedItemDef := &ast.Definition{
+		Kind:  ast.Union,
+		Name:  "FeedItem",
+		Types: []string{"Post"},
+	}
+
+	schema := &ast.Schema{
+		Types: map[string]*ast.Definition{
+			"User":         userDef,
+			"Post":         postDef,
+			"SearchResult": searchResultDef,
+			"FeedItem":     feedItemDef,
+		},
+	}
+
+	var buf bytes.Buffer
+	gen := New(cfg, schema, &buf)
+
+	_ = gen.Generate()
+	output := buf.String()
+
+	expectedContent := []string{
+		"== Unions",
+		"// tag::union-SearchResult[]",
+		"[[union_search_result]]",
+		"=== SearchResult",
+		"// tag::union-description-SearchResult[]",
+		"Anything that can be returned from a search",
+		"// tag::union-members-SearchResult[]",
+		".Possible types\n* <<User,`User`>>\n* <<Post,`Post`>>\n",
+		"// end::union-SearchResult[]",
+		"// tag::type-unions-Post[]",
+		"*Member of:* <<FeedItem,`FeedItem`>>, <<SearchResult,`SearchResult`>>",
+		"*Member of:* <<SearchResult,`SearchResult`>>\n",
+	}
+
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Union output should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg := config.NewConfig()
+		cfg.SchemaFile = testSchemaFile
+		cfg.IncludeUnions = false
+
+		var buf bytes.Buffer
+		_ = New(cfg, schema, &buf).Generate()
+		if strings.Contains(buf.String(), "== Unions") {
+			t.Error("Output should NOT contain Unions section when disabled")
+		}
+	})
+}
+
+func TestGenerateWithTemplateOverrides(t *testing.T) {
+	dir := t.TempDir()
+	queryTmpl := "== Custom Queries\n{{range .Queries}}* {{.Name}} returns {{.TypeName}}\n{{end}}"
+	if err := os.WriteFile(filepath.Join(dir, "query.tmpl"), []byte(queryTmpl), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	headerTmpl := "= House Style Docs\n:source: {{.SchemaFile}}\n\n"
+	if err := os.WriteFile(filepath.Join(dir, "header.tmpl"), []byte(headerTmpl), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.TemplatesDir = dir
+
+	var buf bytes.Buffer
+	gen := New(cfg, createTestSchema(), &buf)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContent := []string{
+		"= House Style Docs\n:source: test.graphql",
+		"== Custom Queries",
+		"* hello returns `String`",
+		// Sections without an override keep the built-in template
+		"== Types",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+
+	for _, notExpected := range []string{"= GraphQL Documentation", "// tag::query-hello[]"} {
+		if strings.Contains(output, notExpected) {
+			t.Errorf("Output should NOT contain %q when the template is overridden", notExpected)
+		}
+	}
+
+	t.Run("invalid override", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "enum-section.tmpl"), []byte("{{range .Enums}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		cfg := config.NewConfig()
+		cfg.TemplatesDir = dir
+
+		var buf bytes.Buffer
+		err := New(cfg, createTestSchema(), &buf).Generate()
+		if err == nil || !strings.Contains(err.Error(), "enum-section.tmpl") {
+			t.Errorf("Expected error naming the invalid template file, got %v", err)
+		}
+	})
+}
+
 func TestIsBuiltInType(t *testing.T) {
 	testCases := []struct {
 		typeName string
@@ -565,6 +882,333 @@ func TestExcludeInternal(t *testing.T) {
 	}
 }
 
+func TestGeneratorPrintHeaderTitleAndAttributes(t *testing.T) {
+	cfg := &config.Config{
+		SchemaFile: "test/schema.graphql",
+		Title:      "Orders API",
+		HeaderAttributes: config.Attributes{
+			{Name: "toc", Value: "right"},
+			{Name: "experimental"},
+		},
+	}
+	schema := &ast.Schema{Types: make(map[string]*ast.Definition)}
+	var buf bytes.Buffer
+	gen := New(cfg, schema, &buf)
+
+	if err := gen.printHeader(); err != nil {
+		t.Fatalf("printHeader() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContains := []string{
+		"= Orders API\n:toc: right\n:revdate: ",
+		":tags: api, GraphQL, nodes, types, query\n:experimental:\n\n",
+	}
+	for _, expected := range expectedContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Header should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "= GraphQL Documentation") {
+		t.Error("Configured title should replace the default title")
+	}
+	if strings.Contains(output, ":toc: left") {
+		t.Error("Configured toc attribute should replace the built-in one")
+	}
+}
+
+func TestGeneratorPrintHeaderOptions(t *testing.T) {
+	dir := t.TempDir()
+	banner := filepath.Join(dir, "banner.adoc")
+	preamble := filepath.Join(dir, "preamble.adoc")
+	if err := os.WriteFile(banner, []byte("NOTE: Generated nightly.\n\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(preamble, []byte("Welcome to the Orders API.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	header := func(cfg *config.Config) string {
+		var buf bytes.Buffer
+		if err := New(cfg, &ast.Schema{Types: make(map[string]*ast.Definition)}, &buf).printHeader(); err != nil {
+			t.Fatalf("printHeader() returned error: %v", err)
+		}
+		return buf.String()
+	}
+
+	cfg := &config.Config{
+		SchemaFile:     "test/schema.graphql",
+		Author:         "API Team",
+		DocVersion:     "2.1",
+		AttributesFile: "_attributes.adoc",
+		BannerFile:     banner,
+		PreambleFile:   preamble,
+		HeaderAttributes: config.Attributes{
+			{Name: "pdf-page-size", Value: "Letter"},
+			{Name: "tags!"},
+		},
+	}
+	output := header(cfg)
+	expectedContains := []string{
+		"= GraphQL Documentation\n:author: API Team\n:revnumber: 2.1\n:toc: left\n",
+		":pdf-page-size: Letter\n:tags!:\n\ninclude::_attributes.adoc[]\n\n\n",
+		"\nNOTE: Generated nightly.\n\nWelcome to the Orders API.\n\n",
+	}
+	for _, expected := range expectedContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Header should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "[IMPORTANT]") || strings.Contains(output, ":tags: api") {
+		t.Errorf("Expected the banner and tags to be replaced, got:\n%s", output)
+	}
+
+	// Without a banner the preamble follows the attributes
+	cfg.BannerFile, cfg.NoBanner = "", true
+	if output := header(cfg); !strings.Contains(output, "include::_attributes.adoc[]\n\nWelcome") {
+		t.Errorf("Expected the preamble right after the attributes, got:\n%s", output)
+	}
+
+	cfg.PreambleFile = filepath.Join(dir, "missing.adoc")
+	var buf bytes.Buffer
+	if err := New(cfg, &ast.Schema{Types: make(map[string]*ast.Definition)}, &buf).printHeader(); err == nil {
+		t.Error("Expected an error for a missing preamble file")
+	}
+}
+
+func TestGenerateCatalogueHeader(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.Catalogue = true
+	cfg.SubTitle = "Activities"
+	cfg.Author = "API Team"
+	cfg.AttributesFile = "_attributes.adoc"
+	cfg.HeaderAttributes = config.Attributes{{Name: "toc", Value: "right"}}
+
+	var buf bytes.Buffer
+	if err := New(cfg, createTestSchema(), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContains := []string{
+		"= GraphQL API Catalogue: Activities\n:author: API Team\n:toc: right\n",
+		"\ninclude::_attributes.adoc[]\n",
+		"[IMPORTANT]\n====\nThis is automatically generated",
+		"====\n\nGraphQL is a modern API query language",
+	}
+	for _, expected := range expectedContains {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Catalogue should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+
+	cfg.Title = "Orders"
+	buf.Reset()
+	if err := New(cfg, createTestSchema(), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "= Orders: Activities\n") {
+		t.Errorf("Expected the configured title, got:\n%s", buf.String())
+	}
+}
+
+func TestFilterRules(t *testing.T) {
+	queryDef := &ast.Definition{
+		Kind: ast.Object,
+		Name: "Query",
+		Fields: ast.FieldList{
+			&ast.FieldDefinition{Name: "orders", Type: &ast.Type{NamedType: "String"}},
+			&ast.FieldDefinition{Name: "orderDebugInfo", Type: &ast.Type{NamedType: "String"}},
+			&ast.FieldDefinition{Name: "customers", Type: &ast.Type{NamedType: "String"}},
+		},
+	}
+	schema := &ast.Schema{
+		Query: queryDef,
+		Types: map[string]*ast.Definition{"Query": queryDef},
+	}
+
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.Filters = config.FilterRules{
+		Include: []string{"order*"},
+		Exclude: []string{"*Debug*"},
+	}
+
+	var buf bytes.Buffer
+	if err := New(cfg, schema, &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	if !strings.Contains(output, "// tag::query-orders[]") {
+		t.Error("Output should contain query matching an include pattern")
+	}
+	for _, excluded := range []string{"orderDebugInfo", "customers"} {
+		if strings.Contains(output, excluded) {
+			t.Errorf("Output should NOT contain filtered query %q", excluded)
+		}
+	}
+}
+
+func TestGenerateMarkdownFormat(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.Format = "markdown"
+
+	var buf bytes.Buffer
+	if err := New(cfg, createTestSchema(), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContent := []string{
+		"# GraphQL Documentation\n",
+		"## Query\n",
+		"### hello\n",
+		"<a id=\"type_user\"></a>",
+		"**Return:** [`User`](#type_user)",
+		"| Type | Field | Description |\n| --- | --- | --- |",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Markdown output should contain %q, but doesn't. Output:\n%s", expected, output)
+		}
+	}
+	for _, notExpected := range []string{"|===", "// tag::", "[[type_user]]", "----"} {
+		if strings.Contains(output, notExpected) {
+			t.Errorf("Markdown output should NOT contain AsciiDoc syntax %q", notExpected)
+		}
+	}
+
+	cfg.Format = "docx"
+	if err := New(cfg, createTestSchema(), &buf).Generate(); err == nil {
+		t.Error("Expected error for unsupported format")
+	}
+}
+
+func TestCheckFormats(t *testing.T) {
+	testCases := []struct {
+		name    string
+		modify  func(*config.Config)
+		wantErr bool
+	}{
+		{name: "defaults", modify: func(c *config.Config) {}},
+		{name: "markdown", modify: func(c *config.Config) { c.Format = "markdown" }},
+		{name: "unsupported format", modify: func(c *config.Config) { c.Format = "docx" }, wantErr: true},
+		{name: "split html", modify: func(c *config.Config) { c.SplitDir, c.Format = "site", "HTML" }},
+		{name: "split markdown", modify: func(c *config.Config) { c.SplitDir, c.Format = "site", "markdown" }, wantErr: true},
+		{name: "mermaid diagram", modify: func(c *config.Config) { c.Diagram = "Mermaid" }},
+		{name: "unsupported diagram", modify: func(c *config.Config) { c.Diagram = "graphviz" }, wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.NewConfig()
+			tc.modify(cfg)
+
+			err := CheckFormats(cfg)
+			if tc.wantErr && err == nil {
+				t.Error("Expected an error")
+			}
+			if !tc.wantErr && err != nil {
+				t.Errorf("Expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateSplitDir(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.SplitDir = t.TempDir()
+
+	var buf bytes.Buffer
+	if err := New(cfg, createTestSchema(), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Split output should not write to the output stream, got:\n%s", buf.String())
+	}
+
+	nav, err := os.ReadFile(filepath.Join(cfg.SplitDir, "nav.adoc"))
+	if err != nil {
+		t.Fatalf("Expected nav.adoc to be written: %v", err)
+	}
+	for _, expected := range []string{"** Queries\n*** xref:queries/hello.adoc[hello]", "*** xref:types/User.adoc[User]"} {
+		if !strings.Contains(string(nav), expected) {
+			t.Errorf("nav.adoc should contain %q, got:\n%s", expected, nav)
+		}
+	}
+
+	page, err := os.ReadFile(filepath.Join(cfg.SplitDir, "pages", "queries", "hello.adoc"))
+	if err != nil {
+		t.Fatalf("Expected query page to be written: %v", err)
+	}
+	if !strings.HasPrefix(string(page), "= hello\n") {
+		t.Errorf("Query page should start with its title, got:\n%s", page)
+	}
+}
+
+func TestGenerateDiagram(t *testing.T) {
+	schema := buildTestSchema(t, `
+type Query { me: User version: String }
+type User { id: ID! role: Role posts: [Post!]! }
+type Post { title: String }
+type Audit { at: String }
+enum Role { ADMIN USER }
+`)
+
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.Diagram = "mermaid"
+	cfg.DiagramRoot = "me"
+
+	var buf bytes.Buffer
+	if err := New(cfg, schema, &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+	for _, expected := range []string{
+		"// tag::diagram[]\n[[type_diagram]]\n== Type Diagram\n\nThe types reachable from `me`.\n\n[mermaid, type-diagram, svg]\n----\nclassDiagram\n",
+		"  User --> \"*\" Post : posts\n",
+		"----\n// end::diagram[]",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+	if strings.Contains(output, "class Audit") {
+		t.Error("Types not reachable from the root field should not be drawn")
+	}
+
+	// A diagram file replaces the embedded diagram
+	cfg.Diagram = "plantuml"
+	cfg.DiagramRoot = ""
+	cfg.DiagramFile = filepath.Join(t.TempDir(), "types.puml")
+	buf.Reset()
+	if err := New(cfg, schema, &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "type_diagram") {
+		t.Error("Diagram should not be embedded when written to a file")
+	}
+	source, err := os.ReadFile(cfg.DiagramFile)
+	if err != nil {
+		t.Fatalf("Expected diagram file to be written: %v", err)
+	}
+	if !strings.HasPrefix(string(source), "@startuml\n") || !strings.Contains(string(source), "class Audit") {
+		t.Errorf("Unexpected diagram file content:\n%s", source)
+	}
+
+	// An unknown root field fails the generation
+	cfg.DiagramFile = ""
+	cfg.DiagramRoot = "missing"
+	if err := New(cfg, schema, &buf).Generate(); err == nil {
+		t.Error("Expected error for an unknown diagram root")
+	}
+}
+
 // Helper function to create a test schema with various types
 func createTestSchema() *ast.Schema {
 	queryDef := &ast.Definition{
@@ -719,6 +1363,26 @@ func TestIsBuiltInScalar(t *testing.T) {
 	}
 }
 
+func TestOperationAnchor(t *testing.T) {
+	testCases := []struct {
+		kind     ast.Operation
+		name     string
+		expected string
+	}{
+		{ast.Query, "getUserById", "query_getuserbyid"},
+		{ast.Mutation, "createUser", "mutation_create_user"},
+		{ast.Subscription, "onUserCreated", "subscription_onusercreated"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(string(tc.kind), func(t *testing.T) {
+			if result := operationAnchor(tc.kind, tc.name); result != tc.expected {
+				t.Errorf("operationAnchor(%q, %q) = %q, expected %q", tc.kind, tc.name, result, tc.expected)
+			}
+		})
+	}
+}
+
 func TestGenerateSubscriptions(t *testing.T) {
 	cfg := config.NewConfig()
 	cfg.SchemaFile = testSchemaFile
@@ -1271,7 +1935,7 @@ func TestGetEnumValuesTableString(t *testing.T) {
 		},
 	}
 
-	result := gen.getEnumValuesTableString(enumDef)
+	result := gen.getEnumValuesTableString(enumDef, map[string]*ast.Definition{})
 
 	// Debug: print actual result
 	fmt.Printf("ACTUAL ENUM VALUES TABLE:\n%s\n", result)
@@ -1323,7 +1987,7 @@ func TestGenerateEnums(t *testing.T) {
 		sortedDefs = append(sortedDefs, def)
 	}
 
-	count := gen.generateEnums(sortedDefs)
+	count := gen.generateEnums(sortedDefs, gen.schema.Types)
 	if count != 1 {
 		t.Errorf("Expected 1 enum, got %d", count)
 	}
@@ -1549,7 +2213,7 @@ func TestGenerateEnumsWithNoValues(t *testing.T) {
 	for _, def := range gen.schema.Types {
 		sortedDefs = append(sortedDefs, def)
 	}
-	count := gen.generateEnums(sortedDefs)
+	count := gen.generateEnums(sortedDefs, gen.schema.Types)
 	if count != 1 {
 		t.Errorf("Expected 1 enum, got %d", count)
 	}
@@ -1868,3 +2532,434 @@ func TestInputFieldsTableWithDefaultValues(t *testing.T) {
 		t.Errorf("Table should show _none_ for name without default. Output:\n%s", table)
 	}
 }
+
+const deprecationSchema = `
+type Query {
+  users: [User!]!
+  allUsers: [User!]! @deprecated(reason: "Use users instead.")
+  search(term: String, q: String @deprecated(reason: "Use term")): [User!]!
+}
+
+type User {
+  id: ID!
+  name: String @deprecated(reason: "Use ` + "`User.fullName`" + ` instead.")
+  fullName: String
+  legacyId: Int @deprecated
+}
+
+enum Role {
+  ADMIN
+  ROOT @deprecated(reason: "Use ADMIN instead.")
+}
+
+input UserFilter {
+  role: Role
+  admin: Boolean @deprecated(reason: "Use Role instead.")
+}
+`
+
+func buildTestSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "test", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+func TestDeprecationReason(t *testing.T) {
+	testCases := []struct {
+		name       string
+		directives ast.DirectiveList
+		reason     string
+		deprecated bool
+	}{
+		{name: "not deprecated", directives: nil},
+		{
+			name:       "without reason",
+			directives: ast.DirectiveList{{Name: "deprecated"}},
+			reason:     defaultDeprecationReason,
+			deprecated: true,
+		},
+		{
+			name: "with reason",
+			directives: ast.DirectiveList{{Name: "deprecated", Arguments: ast.ArgumentList{
+				{Name: "reason", Value: &ast.Value{Kind: ast.StringValue, Raw: "Use users"}},
+			}}},
+			reason:     "Use users",
+			deprecated: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, deprecated := deprecationReason(tc.directives)
+			if reason != tc.reason || deprecated != tc.deprecated {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tc.reason, tc.deprecated, reason, deprecated)
+			}
+		})
+	}
+}
+
+func TestLinkReplacement(t *testing.T) {
+	schema := buildTestSchema(t, deprecationSchema)
+	gen := New(config.NewConfig(), schema, &bytes.Buffer{})
+
+	testCases := []struct {
+		reason   string
+		expected string
+	}{
+		{"Use users instead.", "Use <<query_users,`users`>> instead."},
+		{"Use `User.fullName` instead.", "Use <<User,`User.fullName`>> instead."},
+		{"Use Role instead.", "Use <<Role,`Role`>> instead."},
+		{"use Query.users", "use <<query_users,`Query.users`>>"},
+		{"Use the new API instead.", "Use the new API instead."},
+		{"Use User.missing instead.", "Use User.missing instead."},
+		{"Removed in 2.0.", "Removed in 2.0."},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.reason, func(t *testing.T) {
+			if got := gen.linkReplacement(tc.reason, schema.Types); got != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLinkReplacementSkipsExcludedOperations(t *testing.T) {
+	schema := buildTestSchema(t, deprecationSchema)
+	cfg := config.NewConfig()
+	cfg.IncludeQueries = false
+	gen := New(cfg, schema, &bytes.Buffer{})
+
+	// A link to a query that is not documented would have no target
+	if got := gen.linkReplacement("Use users instead.", schema.Types); got != "Use users instead." {
+		t.Errorf("Expected the reason to be unchanged, got %q", got)
+	}
+}
+
+func TestGenerateDeprecationWarnings(t *testing.T) {
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.IncludeDeprecated = true
+
+	var buf bytes.Buffer
+	if err := New(cfg, buildTestSchema(t, deprecationSchema), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContent := []string{
+		// Operation
+		"// tag::query-deprecation-allUsers[]\n[WARNING]\n====\n" +
+			"*Deprecated:* Use <<query_users,`users`>> instead.\n====\n// end::query-deprecation-allUsers[]",
+		// Argument, as a continuation of its list item
+		"* `q : String @deprecated(reason: \"Use term\")`\n+\n[WARNING]\n====\n*Deprecated:* Use term\n====\n",
+		// Type fields
+		"| name | \n\n[WARNING]\n====\n*Deprecated:* Use <<User,`User.fullName`>> instead.\n====",
+		"*Deprecated:* No longer supported",
+		// Enum value
+		"| `ROOT` | [WARNING]\n====\n*Deprecated:* Use ADMIN instead.\n====",
+		// Input field
+		"*Deprecated:* Use <<Role,`Role`>> instead.",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	if strings.Contains(output, "tag::query-deprecation-users[]") {
+		t.Error("Queries that are not deprecated should have no deprecation warning")
+	}
+}
+
+func TestGetTypeFieldsTableStringWithArguments(t *testing.T) {
+	schema := buildTestSchema(t, `
+input PageInput { size: Int }
+interface Node { id: ID! }
+type User implements Node {
+  id: ID!
+  "The user's posts"
+  posts(
+    "Number of posts to return"
+    first: Int = 10 @length(max: 100)
+    page: PageInput!
+  ): [String!]!
+}
+`)
+	gen := New(config.NewConfig(), schema, &bytes.Buffer{})
+
+	table, err := gen.getTypeFieldsTableString(schema.Types["User"], schema.Types)
+	if err != nil {
+		t.Fatalf("getTypeFieldsTableString() returned error: %v", err)
+	}
+
+	expected := "| posts | The user's posts\n\n.Arguments\n" +
+		"* `first` : `Int` = `10` `@length(max: 100)`\n+\n--\nNumber of posts to return\n--\n" +
+		"* `page` : <<PageInput,`PageInput`>>!\n"
+	if !strings.Contains(table, expected) {
+		t.Errorf("Fields table should contain %q, got:\n%s", expected, table)
+	}
+
+	// Fields without arguments have no arguments list
+	if strings.Count(table, ".Arguments") != 1 {
+		t.Errorf("Only the posts field should have an arguments list, got:\n%s", table)
+	}
+}
+
+func TestGenerateExamples(t *testing.T) {
+	schema := buildTestSchema(t, `
+type Query { user(id: ID!): User }
+type Mutation { renameUser(id: ID!, name: String!): User }
+type Subscription { userChanged: User }
+type User { id: ID! name: String }
+`)
+	cfg := config.NewConfig()
+	cfg.SchemaFile = testSchemaFile
+	cfg.IncludeSubscriptions = true
+	cfg.Examples = true
+
+	var buf bytes.Buffer
+	if err := New(cfg, schema, &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	expectedContent := []string{
+		"// tag::query-example-user[]\n.Example query\n[source, graphql]\n----\n" +
+			"query User($id: ID!) {\n  user(id: $id) {\n    id\n    name\n  }\n}\n----\n\n" +
+			".Variables\n[source, json]\n----\n{\n  \"id\": \"1\"\n}\n----\n\n.Response\n",
+		"// tag::mutation-example-renameUser[]\n.Example mutation\n",
+		"mutation RenameUser($id: ID!, $name: String!) {\n  renameUser(id: $id, name: $name) {\n",
+		"// tag::subscription-example-userChanged[]\n.Example subscription\n[source, graphql]\n----\n" +
+			"subscription UserChanged {\n  userChanged {\n    id\n    name\n  }\n}\n----\n\n" +
+			".Response\n[source, json]\n----\n" +
+			"{\n  \"data\": {\n    \"userChanged\": {\n      \"id\": \"1\",\n      \"name\": \"example\"\n    }\n  }\n}\n" +
+			"----\n// end::subscription-example-userChanged[]",
+	}
+	for _, expected := range expectedContent {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output should contain %q, got:\n%s", expected, output)
+		}
+	}
+
+	// Examples are off by default
+	cfg.Examples = false
+	buf.Reset()
+	if err := New(cfg, schema, &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "[source, graphql]") {
+		t.Errorf("Examples should only be generated with --examples, got:\n%s", buf.String())
+	}
+}
+
+func TestGetSignatureBlockStyles(t *testing.T) {
+	schema := buildTestSchema(t, `
+type Query {
+  users(
+    "Maximum number of users"
+    limit: Int = 10
+    filter: UserFilter! @deprecated(reason: "Use search")
+    ids: [ID!]
+  ): [User!]! @cached
+  me: User
+}
+type User { id: ID! }
+input UserFilter { name: String }
+`)
+
+	testCases := []struct {
+		style    string
+		field    string
+		expected string
+	}{
+		{
+			style: "kotlin",
+			field: "users",
+			expected: ".query: users\n[source, kotlin]\n----\nusers(\n" +
+				"  limit: Int = 10 , <1> \n  filter: UserFilter! , <2> \n  ids: [ID!] <3> \n" +
+				"): [User!]! <4>\n----",
+		},
+		{
+			style: "graphql",
+			field: "users",
+			expected: ".query: users\n[source, graphql]\n----\nusers(\n" +
+				"  limit: Int = 10 <1>\n" +
+				"  filter: UserFilter! @deprecated(reason: \"Use search\") <2>\n" +
+				"  ids: [ID!] <3>\n" +
+				"): [User!]! @cached <4>\n----",
+		},
+		{
+			style:    "graphql",
+			field:    "me",
+			expected: ".query: me\n[source, graphql]\n----\nme: User <1>\n----",
+		},
+		{
+			style: "typescript",
+			field: "users",
+			expected: ".query: users\n[source, typescript]\n----\nusers: (\n" +
+				"  limit?: number | null /* = 10 */, <1>\n" +
+				"  filter: UserFilter, <2>\n" +
+				"  ids?: Array<string> | null <3>\n" +
+				") => Array<User> <4>\n----",
+		},
+		{
+			style:    "typescript",
+			field:    "me",
+			expected: ".query: me\n[source, typescript]\n----\nme: () => User | null <1>\n----",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.style+" "+tc.field, func(t *testing.T) {
+			cfg := config.NewConfig()
+			cfg.SignatureStyle = tc.style
+			gen := New(cfg, schema, &bytes.Buffer{})
+
+			got := gen.getSignatureBlock("query", schema.Query.Fields.ForName(tc.field), schema.Types)
+			if got != tc.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestGenerateDefinedIn(t *testing.T) {
+	doc, err := parser.ParseSources([]*ast.Source{
+		{Name: "/srv/schemas/users/users.graphqls", Input: "type Query { user: User }\ntype User { id: ID! }"},
+		{Name: "/srv/schemas/orders/orders.graphqls", Input: "type Order { id: ID! }\nextend type User { orders: [Order] }"},
+	})
+	if err != nil {
+		t.Fatalf("ParseSources() returned error: %v", err)
+	}
+
+	cfg := config.NewConfig()
+	cfg.SchemaPattern = "/srv/schemas/**/*.graphqls"
+	var buf bytes.Buffer
+	if err := New(cfg, parser.BuildSchema(doc), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	output := buf.String()
+
+	for _, expected := range []string{
+		"// tag::query-source-user[]\n*Defined in:* `users/users.graphqls`\n",
+		"// tag::type-source-User[]\n*Defined in:* `users/users.graphqls`, `orders/orders.graphqls`\n",
+		"// tag::type-source-Order[]\n*Defined in:* `orders/orders.graphqls`\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output to contain %q. Output:\n%s", expected, output)
+		}
+	}
+
+	// A schema read from one file needs no note
+	buf.Reset()
+	if err := New(cfg, buildTestSchema(t, "type Query { user: User }\ntype User { id: ID! }"), &buf).Generate(); err != nil {
+		t.Fatalf("Generate() returned error: %v", err)
+	}
+	if strings.Contains(buf.String(), "Defined in") {
+		t.Error("Expected no Defined in note for a single-file schema")
+	}
+}
+
+func TestGenerateGroupBy(t *testing.T) {
+	doc, err := parser.ParseSources([]*ast.Source{
+		{Name: "/srv/schemas/users/users.graphqls", Input: `
+directive @tag(name: String!) on FIELD_DEFINITION | OBJECT
+type Query { user: User @tag(name: "users") }
+type Mutation { addUser: User @tag(name: "users") }
+type User @tag(name: "users") { id: ID! }`},
+		{Name: "/srv/schemas/orders/orders.graphqls", Input: `
+extend type Query { orders: [Order] @tag(name: "orders") }
+extend type Mutation { saveOrder: Order @tag(name: "orders") }
+"""
+An order
+@module: orders
+"""
+type Order { id: ID! }
+scalar DateTime`},
+	})
+	if err != nil {
+		t.Fatalf("ParseSources() returned error: %v", err)
+	}
+	schema := parser.BuildSchema(doc)
+
+	generate := func(mode string) string {
+		cfg := config.NewConfig()
+		cfg.SchemaPattern = "/srv/schemas/**/*.graphqls"
+		cfg.GroupBy = mode
+		var buf bytes.Buffer
+		if err := New(cfg, schema, &buf).Generate(); err != nil {
+			t.Fatalf("Generate() returned error: %v", err)
+		}
+		return buf.String()
+	}
+
+	output := generate(config.GroupByFile)
+	for _, expected := range []string{
+		"| File | Operations | Types\n",
+		"| <<group_orders_orders_graphqls,orders/orders.graphqls>>\n" +
+			"| <<query_orders,orders>>, <<mutation_save_order,saveOrder>>\n" +
+			"| <<type_order,Order>>, <<scalar-DateTime,DateTime>>\n",
+		"[[group_users_users_graphqls]]\n== users/users.graphqls\n=== Query\n",
+		"[[group_users_users_graphqls_mutations]]\n=== Mutations\n",
+		"[[query_user]]\n==== user\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected file groups to contain %q. Output:\n%s", expected, output)
+		}
+	}
+	if strings.Index(output, "== orders/orders.graphqls") > strings.Index(output, "== users/users.graphqls") {
+		t.Error("Expected groups sorted by name")
+	}
+
+	output = generate(config.GroupByTag)
+	for _, expected := range []string{
+		"| Module | Operations | Types\n",
+		"| <<group_orders,orders>>\n| <<query_orders,orders>>, <<mutation_save_order,saveOrder>>\n| <<type_order,Order>>\n",
+		"| <<group_other,Other>>\n| \n| <<scalar-DateTime,DateTime>>, <<directive_tag,@tag>>\n",
+		"[[group_other_scalars]]\n=== Scalars\n",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected module groups to contain %q. Output:\n%s", expected, output)
+		}
+	}
+	if strings.Index(output, "== Other") < strings.Index(output, "== users") {
+		t.Error("Expected the Other group last")
+	}
+	if strings.Contains(output, "@module") {
+		t.Error("Expected module lines to be left out of descriptions")
+	}
+}
+
+func TestNestSections(t *testing.T) {
+	doc := `[[mutations]]
+== Mutations
+
+// tag::mutation-addUser[]
+[[mutation_add_user]]
+=== addUser
+
+[source,graphql]
+----
+== not a title
+----
+`
+	expected := `[[group_users_mutations]]
+=== Mutations
+
+// tag::mutation-addUser[]
+[[mutation_add_user]]
+==== addUser
+
+[source,graphql]
+----
+== not a title
+----
+`
+	if got := nestSections(doc, "group_users_"); got != expected {
+		t.Errorf("nestSections() =\n%s\nwant\n%s", got, expected)
+	}
+}
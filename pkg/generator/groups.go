@@ -0,0 +1,334 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/asciidoc"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+const (
+	// otherGroup holds the elements that declare no module, listed last
+	otherGroup = "Other"
+	// topLevelGroup names the directory of schema files given without one
+	topLevelGroup = "(top level)"
+)
+
+var reNonAnchor = regexp.MustCompile(`[^a-z0-9]+`)
+
+// group is one top-level section of the document with --group-by: the
+// definitions, root operation fields and directives of a source file,
+// directory or module that are documented. A nil group stands for the whole
+// schema, so its methods report every element as a member.
+type group struct {
+	name        string
+	definitions map[*ast.Definition]bool
+	fields      map[*ast.FieldDefinition]bool
+	directives  map[*ast.DirectiveDefinition]bool
+}
+
+func (gr *group) hasDefinition(def *ast.Definition) bool {
+	return gr == nil || gr.definitions[def]
+}
+
+func (gr *group) hasField(field *ast.FieldDefinition) bool {
+	return gr == nil || gr.fields[field]
+}
+
+func (gr *group) hasDirective(directive *ast.DirectiveDefinition) bool {
+	return gr == nil || gr.directives[directive]
+}
+
+// hasFieldsOf reports whether the group has operations of a root type
+func (gr *group) hasFieldsOf(root *ast.Definition) bool {
+	if gr == nil {
+		return true
+	}
+	for _, field := range root.Fields {
+		if gr.fields[field] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasKind reports whether the group has definitions of a kind
+func (gr *group) hasKind(kind ast.DefinitionKind) bool {
+	if gr == nil {
+		return true
+	}
+	for def := range gr.definitions {
+		if def.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func (gr *group) hasDirectives() bool {
+	return gr == nil || len(gr.directives) > 0
+}
+
+// anchorName returns the id of the group's section
+func (gr *group) anchorName() string {
+	return "group_" + strings.Trim(reNonAnchor.ReplaceAllString(strings.ToLower(gr.name), "_"), "_")
+}
+
+// generateGroups writes the index of the groups and then a section per group
+// holding its operation and type sections, each one level further down
+func (g *Generator) generateGroups(sortedDefs []*ast.Definition, definitionsMap map[string]*ast.Definition) error {
+	var groups []*group
+	var infos []GroupInfo
+	for _, gr := range g.groups() {
+		info := g.groupInfo(gr, sortedDefs)
+		if info.Operations == "" && info.Types == "" {
+			continue
+		}
+		groups = append(groups, gr)
+		infos = append(infos, info)
+	}
+
+	heading := map[string]string{
+		config.GroupByFile:      "File",
+		config.GroupByDirectory: "Directory",
+		config.GroupByTag:       "Module",
+	}[g.config.GroupBy]
+	if err := g.executeTemplate("group-index", GroupIndexData{Heading: heading, Groups: infos}); err != nil {
+		return err
+	}
+
+	for i, gr := range groups {
+		var buf bytes.Buffer
+		writer := g.writer
+		g.writer, g.group = &buf, gr
+		g.generateSections(sortedDefs, definitionsMap)
+		g.writer, g.group = writer, nil
+
+		infos[i].Content = nestSections(buf.String(), infos[i].AnchorName+"_")
+		if err := g.executeTemplate("group", infos[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// groups assigns the documented definitions, root operation fields and
+// directives to groups by --group-by. Groups are sorted by name, with the
+// elements that belong to none in an Other group at the end.
+func (g *Generator) groups() []*group {
+	byName := make(map[string]*group)
+	add := func(name string) *group {
+		if name == "" {
+			name = otherGroup
+		}
+		if gr, ok := byName[name]; ok {
+			return gr
+		}
+		gr := &group{
+			name:        name,
+			definitions: make(map[*ast.Definition]bool),
+			fields:      make(map[*ast.FieldDefinition]bool),
+			directives:  make(map[*ast.DirectiveDefinition]bool),
+		}
+		byName[name] = gr
+		return gr
+	}
+
+	roots := map[*ast.Definition]bool{
+		g.schema.Query:        g.config.IncludeQueries,
+		g.schema.Mutation:     g.config.IncludeMutations,
+		g.schema.Subscription: g.config.IncludeSubscriptions,
+	}
+	kinds := map[ast.DefinitionKind]bool{
+		ast.Object:      g.config.IncludeTypes,
+		ast.Interface:   g.config.IncludeInterfaces,
+		ast.Union:       g.config.IncludeUnions,
+		ast.Enum:        g.config.IncludeEnums,
+		ast.InputObject: g.config.IncludeInputs,
+		ast.Scalar:      g.config.IncludeScalars,
+	}
+	for _, def := range g.schema.Types {
+		if include, ok := roots[def]; ok {
+			for _, field := range def.Fields {
+				if include && g.shouldIncludeField(field.Name, field.Description, field.Directives) {
+					add(g.groupName(field.Position, field.Directives, field.Description)).fields[field] = true
+				}
+			}
+			continue
+		}
+		if !kinds[def.Kind] || parser.IsBuiltInGraphQLType(def.Name) || isBuiltInScalar(def.Name) {
+			continue
+		}
+		add(g.groupName(def.Position, def.Directives, def.Description)).definitions[def] = true
+	}
+	if g.config.IncludeDirectives {
+		for _, directive := range g.schema.Directives {
+			add(g.groupName(directive.Position, nil, directive.Description)).directives[directive] = true
+		}
+	}
+
+	groups := make([]*group, 0, len(byName))
+	for _, gr := range byName {
+		groups = append(groups, gr)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].name == otherGroup) != (groups[j].name == otherGroup) {
+			return groups[j].name == otherGroup
+		}
+		return groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// groupName returns the group an element belongs to: its schema file or
+// that file's directory, or the module named by its @tag(name:) directive
+// or a "@module: name" line in its description. It returns "" when the
+// element declares no module.
+func (g *Generator) groupName(pos *ast.Position, directives ast.DirectiveList, description string) string {
+	if g.config.GroupBy == config.GroupByTag {
+		if tag := directives.ForName("tag"); tag != nil {
+			if arg := tag.Arguments.ForName("name"); arg != nil && arg.Value != nil {
+				return arg.Value.Raw
+			}
+		}
+		return parser.DescriptionModule(description)
+	}
+
+	if pos == nil || pos.Src == nil {
+		return ""
+	}
+	name, ok := g.displaySourceNames()[pos.Src.Name]
+	if !ok {
+		return ""
+	}
+	if g.config.GroupBy == config.GroupByFile {
+		return name
+	}
+
+	dir := path.Dir(name)
+	if dir == "." {
+		// All the files share the directory, which the display names omit
+		dir = filepath.Base(filepath.Dir(pos.Src.Name))
+	}
+	if dir == "." || dir == string(filepath.Separator) {
+		return topLevelGroup
+	}
+	return dir
+}
+
+// groupInfo builds the index entry of a group, with cross-references to its
+// operations and types in the order they are documented
+func (g *Generator) groupInfo(gr *group, sortedDefs []*ast.Definition) GroupInfo {
+	var operations []string
+	roots := []struct {
+		kind ast.Operation
+		def  *ast.Definition
+	}{
+		{ast.Query, g.schema.Query},
+		{ast.Mutation, g.schema.Mutation},
+		{ast.Subscription, g.schema.Subscription},
+	}
+	for _, root := range roots {
+		if root.def == nil {
+			continue
+		}
+		var names []string
+		for _, field := range root.def.Fields {
+			if gr.fields[field] {
+				names = append(names, field.Name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			operations = append(operations, fmt.Sprintf("<<%s,%s>>", operationAnchor(root.kind, name), name))
+		}
+	}
+
+	prefixes := map[ast.DefinitionKind]string{
+		ast.Object:      "type_",
+		ast.Interface:   "interface_",
+		ast.Union:       "union_",
+		ast.Enum:        "enum_",
+		ast.InputObject: "input_",
+	}
+	kinds := []ast.DefinitionKind{ast.Object, ast.Interface, ast.Union, ast.Enum, ast.InputObject, ast.Scalar}
+	var types []string
+	for _, kind := range kinds {
+		for _, def := range sortedDefs {
+			if def.Kind != kind || !gr.definitions[def] {
+				continue
+			}
+			anchor := prefixes[kind] + parser.CamelToSnake(def.Name)
+			if kind == ast.Scalar {
+				anchor = "scalar-" + def.Name
+			}
+			types = append(types, fmt.Sprintf("<<%s,%s>>", anchor, def.Name))
+		}
+	}
+
+	var directiveNames []string
+	for directive := range gr.directives {
+		directiveNames = append(directiveNames, directive.Name)
+	}
+	sort.Strings(directiveNames)
+	for _, name := range directiveNames {
+		types = append(types, fmt.Sprintf("<<directive_%s,@%s>>", strings.ToLower(name), name))
+	}
+
+	return GroupInfo{
+		Name:       gr.name,
+		AnchorName: gr.anchorName(),
+		Operations: strings.Join(operations, ", "),
+		Types:      strings.Join(types, ", "),
+	}
+}
+
+// nestSections moves every section title of a document one level down and
+// prefixes the anchors of its top-level sections, which would otherwise be
+// declared once per group. Listing and literal blocks are left untouched.
+func nestSections(doc, prefix string) string {
+	lines := strings.Split(doc, "\n")
+	fence := ""
+	anchorLine := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if trimmed == fence {
+				fence = ""
+			}
+			continue
+		}
+		if trimmed == "----" || trimmed == "...." {
+			fence = trimmed
+			anchorLine = -1
+			continue
+		}
+
+		if _, ok := asciidoc.AnchorID(trimmed); ok {
+			anchorLine = i
+			continue
+		}
+		if level, _, ok := asciidoc.SectionTitle(line); ok {
+			if level == 2 && anchorLine >= 0 { //nolint:mnd // the section templates start at level 2
+				id, _ := asciidoc.AnchorID(strings.TrimSpace(lines[anchorLine]))
+				lines[anchorLine] = "[[" + prefix + id + "]]"
+			}
+			lines[i] = "=" + line
+			anchorLine = -1
+			continue
+		}
+		if trimmed != "" && !asciidoc.IsComment(trimmed) {
+			anchorLine = -1
+		}
+	}
+	return strings.Join(lines, "\n")
+}
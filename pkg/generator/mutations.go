@@ -5,13 +5,11 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/changelog"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
-	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/templates"
 )
 
 // generateMutations generates the mutations section
@@ -20,29 +18,16 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 
 	if g.schema.Mutation == nil || len(g.schema.Mutation.Fields) == 0 {
 		// No mutations exist
-		tmpl, err := template.New("mutation").Parse(templates.MutationTemplate)
-		if err == nil {
-			if execErr := tmpl.Execute(g.writer, struct {
-				MutationTag               string
-				MutationObjectDescription string
-				FoundMutations            bool
-				Mutations                 []MutationInfo
-			}{
-				MutationTag:               "== Mutations",
-				MutationObjectDescription: "",
-				FoundMutations:            false,
-				Mutations:                 nil,
-			}); execErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: template execution error for empty mutations: %v\n", execErr)
-			}
-		} else {
-			fmt.Fprintln(g.writer, "== Mutations")
-			fmt.Fprintln(g.writer)
-			fmt.Fprintln(g.writer, "[NOTE]")
-			fmt.Fprintln(g.writer, "====")
-			fmt.Fprintln(g.writer, "No mutations exist in this schema.")
-			fmt.Fprintln(g.writer, "====")
-			fmt.Fprintln(g.writer)
+		data := struct {
+			MutationTag               string
+			MutationObjectDescription string
+			FoundMutations            bool
+			Mutations                 []MutationInfo
+		}{
+			MutationTag: "== Mutations",
+		}
+		if err := g.executeTemplate("mutation", data); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: template execution error for empty mutations: %v\n", err)
 		}
 		g.metrics.LogProgress("Mutations", "Generated 0 mutations")
 		return 0
@@ -50,7 +35,7 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 
 	var mutationInfos []MutationInfo
 	for _, f := range g.schema.Mutation.Fields {
-		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) {
+		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) || !g.group.hasField(f) {
 			continue
 		}
 
@@ -66,7 +51,7 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 		directivesBlock := g.getDirectivesBlock(f)
 		mutationInfo := MutationInfo{
 			Name:                 f.Name,
-			AnchorName:           "mutation_" + parser.CamelToSnake(f.Name),
+			AnchorName:           operationAnchor(ast.Mutation, f.Name),
 			Description:          f.Description,
 			CleanedDescription:   processedDesc,
 			TypeName:             parser.ProcessTypeName(f.Type.String(), definitionsMap),
@@ -78,6 +63,9 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 			IsInternal:           isInternal(f.Name, f.Description),
 			Changelog:            changelogText,
 			NumberedRefs:         parser.CrossReferenceTypeNames(numberedRefs, definitionsMap),
+			Deprecation:          g.getDeprecationWarning(f.Directives, definitionsMap),
+			Example:              g.getExampleBlock("mutation", f),
+			DefinedIn:            g.definedIn(f.Position),
 		}
 		mutationInfos = append(mutationInfos, mutationInfo)
 	}
@@ -104,7 +92,7 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 		Mutations:                 mutationInfos,
 	}
 
-	if err := g.executeTemplate("mutation", templates.MutationTemplate, data); err != nil {
+	if err := g.executeTemplate("mutation", data); err != nil {
 		g.metrics.LogProgress("Mutations", "Generated 0 mutations (template error)")
 		return 0
 	}
@@ -115,24 +103,7 @@ func (g *Generator) generateMutations(definitionsMap map[string]*ast.Definition)
 
 // getMethodSignatureBlock builds the method signature block for a mutation
 func (g *Generator) getMethodSignatureBlock(f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) string {
-	var b strings.Builder
-	fmt.Fprintf(&b, ".mutation: %s\n", f.Name)
-	fmt.Fprintln(&b, "[source, kotlin]")
-	fmt.Fprintln(&b, "----")
-	fmt.Fprintf(&b, "%s(\n", f.Name)
-	for i, arg := range f.Arguments {
-		typeName := parser.ProcessTypeNameForSignature(arg.Type.String(), definitionsMap)
-		fmt.Fprintf(&b, "  %s: %s%s", arg.Name, typeName, formatDefaultValue(arg.DefaultValue))
-		if i < len(f.Arguments)-1 {
-			fmt.Fprint(&b, " ,")
-		}
-		fmt.Fprintf(&b, " <%d> \n", i+1)
-	}
-	fmt.Fprintf(&b, "): %s <%d>\n",
-		parser.ProcessTypeNameForSignature(f.Type.String(), definitionsMap),
-		len(f.Arguments)+1)
-	fmt.Fprint(&b, "----")
-	return b.String()
+	return g.getSignatureBlock("mutation", f, definitionsMap)
 }
 
 // getArgumentsBlock builds the arguments list for a mutation
@@ -144,6 +115,7 @@ func (g *Generator) getArgumentsBlock(f *ast.FieldDefinition, definitionsMap map
 	for _, arg := range f.Arguments {
 		typeName := parser.ProcessTypeName(arg.Type.String(), definitionsMap)
 		fmt.Fprint(&b, formatArgumentListItem(arg.Name, typeName, arg.DefaultValue, arg.Directives))
+		fmt.Fprint(&b, g.getArgumentDeprecation(arg.Directives, definitionsMap))
 	}
 	return b.String()
 }
@@ -19,17 +19,10 @@ func (g *Generator) generateQueries(definitionsMap map[string]*ast.Definition) i
 
 	g.metrics.LogProgress("Queries", "Starting query generation")
 
-	fmt.Fprintln(g.writer, "== Query")
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer)
-	if g.schema.Query.Description != "" {
-		fmt.Fprintln(g.writer, parser.ProcessDescription(g.schema.Query.Description))
-	}
-
 	// Collect and filter queries
 	var queryFields []*ast.FieldDefinition
 	for _, f := range g.schema.Query.Fields {
-		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) {
+		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) || !g.group.hasField(f) {
 			continue
 		}
 		queryFields = append(queryFields, f)
@@ -40,101 +33,66 @@ func (g *Generator) generateQueries(definitionsMap map[string]*ast.Definition) i
 		return queryFields[i].Name < queryFields[j].Name
 	})
 
-	// Generate documentation for each query
+	queryInfos := make([]QueryInfo, 0, len(queryFields))
 	for _, f := range queryFields {
-		g.generateQueryField(f, definitionsMap)
+		queryInfos = append(queryInfos, g.getQueryInfo(f, definitionsMap))
+	}
+
+	queryObjectDescription := ""
+	if g.schema.Query.Description != "" {
+		queryObjectDescription = parser.ProcessDescription(g.schema.Query.Description)
+	}
+
+	data := struct {
+		QueryTag               string
+		QueryObjectDescription string
+		Queries                []QueryInfo
+	}{
+		QueryTag:               "== Query",
+		QueryObjectDescription: queryObjectDescription,
+		Queries:                queryInfos,
+	}
+
+	if err := g.executeTemplate("query", data); err != nil {
+		g.metrics.LogProgress("Queries", "Generated 0 queries (template error)")
+		return 0
 	}
 
-	count := len(queryFields)
+	count := len(queryInfos)
 	g.metrics.LogProgress("Queries", fmt.Sprintf("Generated %d queries", count))
 	return count
 }
 
-// generateQueryField generates documentation for a single query field
-func (g *Generator) generateQueryField(field *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) {
-	fmt.Fprintf(g.writer, "// tag::query-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-	fmt.Fprintf(g.writer, "[[query_%s]]\n", strings.ToLower(field.Name))
-	fmt.Fprintf(g.writer, "=== %s\n", field.Name)
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer)
-
+// getQueryInfo builds the template data for a single query field
+func (g *Generator) getQueryInfo(field *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) QueryInfo {
 	// Process description and extract changelog
 	processedDesc, changelogText := changelog.ProcessWithChangelog(field.Description, parser.ProcessDescription)
 
 	mainDesc, numberedRefs := splitOnArgumentsMarker(processedDesc)
 
-	fmt.Fprintf(g.writer, "// tag::method-description-%s[]\n", field.Name)
-	if strings.TrimSpace(mainDesc) != "" {
-		fmt.Fprint(g.writer, strings.TrimSpace(mainDesc))
-		fmt.Fprintln(g.writer)
-	}
-	fmt.Fprintf(g.writer, "// end::method-description-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-
-	// Generate method signature
-	fmt.Fprintf(g.writer, "// tag::method-signature-%s[]\n", field.Name)
-	fmt.Fprintf(g.writer, ".query: %s\n", field.Name)
-	fmt.Fprintln(g.writer, "[source, kotlin]")
-	fmt.Fprintln(g.writer, "----")
-	fmt.Fprintf(g.writer, "%s(\n", field.Name)
-
-	// Generate arguments
-	for i, arg := range field.Arguments {
-		argType := parser.ProcessTypeNameForSignature(arg.Type.String(), definitionsMap)
-		fmt.Fprintf(g.writer, "  %s: %s%s", arg.Name, argType, formatDefaultValue(arg.DefaultValue))
-		if i < len(field.Arguments)-1 {
-			fmt.Fprint(g.writer, " ,")
-		}
-		fmt.Fprintf(g.writer, " <%d> \n", i+1)
-	}
-
-	fmt.Fprintf(g.writer, "): %s <%d>\n",
-		parser.ProcessTypeNameForSignature(field.Type.String(), definitionsMap),
-		len(field.Arguments)+1)
-	fmt.Fprintln(g.writer, "----")
-	fmt.Fprintf(g.writer, "// end::method-signature-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-
 	// Add numbered references from description with cross-referenced type names
-	fmt.Fprintf(g.writer, "// tag::method-args-%s[]\n", field.Name)
 	if strings.TrimSpace(numberedRefs) != "" {
 		numberedRefs = parser.CrossReferenceTypeNames(numberedRefs, definitionsMap)
-		fmt.Fprint(g.writer, strings.TrimSpace(numberedRefs))
-		fmt.Fprintln(g.writer)
-	}
-	fmt.Fprintf(g.writer, "// end::method-args-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-
-	fmt.Fprintf(g.writer, "// tag::query-name-%s[]\n", field.Name)
-	fmt.Fprintf(g.writer, "*Query Name:* _%s_\n", field.Name)
-	fmt.Fprintf(g.writer, "// end::query-name-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-
-	fmt.Fprintf(g.writer, "// tag::query-return-%s[]\n", field.Name)
-	fmt.Fprintf(g.writer, "*Return:* %s\n", parser.ProcessTypeName(field.Type.String(), definitionsMap))
-	fmt.Fprintf(g.writer, "// end::query-return-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
-
-	// Add changelog section right after return
-	if changelogText != "" {
-		fmt.Fprintf(g.writer, "// tag::query-changelog-%s[]\n", field.Name)
-		fmt.Fprint(g.writer, changelogText)
-		fmt.Fprintln(g.writer)
-		fmt.Fprintf(g.writer, "// end::query-changelog-%s[]\n", field.Name)
-		fmt.Fprintln(g.writer)
 	}
 
-	if len(field.Arguments) > 0 {
-		fmt.Fprintf(g.writer, "// tag::arguments-%s[]\n", field.Name)
-		fmt.Fprintln(g.writer, ".Arguments")
-		for _, arg := range field.Arguments {
-			fmt.Fprint(g.writer, formatArgumentListItem(arg.Name, arg.Type.String(), arg.DefaultValue, arg.Directives))
-		}
-		fmt.Fprintf(g.writer, "// end::arguments-%s[]\n", field.Name)
-		fmt.Fprintln(g.writer)
+	var args strings.Builder
+	for _, arg := range field.Arguments {
+		args.WriteString(formatArgumentListItem(arg.Name, arg.Type.String(), arg.DefaultValue, arg.Directives))
+		args.WriteString(g.getArgumentDeprecation(arg.Directives, definitionsMap))
 	}
 
-	fmt.Fprintf(g.writer, "// end::query-%s[]\n", field.Name)
-	fmt.Fprintln(g.writer)
+	return QueryInfo{
+		Name:                 field.Name,
+		AnchorName:           operationAnchor(ast.Query, field.Name),
+		Description:          strings.TrimSpace(mainDesc),
+		TypeName:             parser.ProcessTypeName(field.Type.String(), definitionsMap),
+		MethodSignatureBlock: g.getSignatureBlock("query", field, definitionsMap),
+		Arguments:            args.String(),
+		HasArguments:         len(field.Arguments) > 0,
+		Changelog:            changelogText,
+		NumberedRefs:         strings.TrimSpace(numberedRefs),
+		Deprecation:          g.getDeprecationWarning(field.Directives, definitionsMap),
+		Example:              g.getExampleBlock("query", field),
+		DefinedIn:            g.definedIn(field.Position),
+	}
 }
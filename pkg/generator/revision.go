@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// revisionDate returns the :revdate: of the document, see
+// config.RevisionDate
+func (g *Generator) revisionDate() string {
+	return config.RevisionDate(g.config, g.newestSchemaTime)
+}
+
+// newestSchemaTime returns the latest modification time of the schema files
+func (g *Generator) newestSchemaTime() time.Time {
+	var files []string
+	switch {
+	case g.config.IntrospectionFile != "":
+		files = []string{g.config.IntrospectionFile}
+	case g.config.SchemaFile != "":
+		// An archive is dated as a whole; stdin has no time and falls back
+		// to the epoch
+		files = []string{g.config.SchemaFile}
+	case g.config.SchemaPattern != "":
+		files, _ = parser.FindSchemaFiles(g.config.SchemaPattern)
+	}
+	return config.NewestModTime(files)
+}
+
+// commandLine returns the :commandline: of the document. In reproducible
+// mode the program is named without its path, which differs between
+// machines and for `go run`, and absolute paths in the arguments, also in
+// the -flag=value form, are made relative to the working directory.
+func (g *Generator) commandLine() string {
+	if !g.config.IsReproducible() || len(os.Args) == 0 {
+		return strings.Join(os.Args, " ")
+	}
+
+	args := []string{filepath.Base(os.Args[0])}
+	for _, arg := range os.Args[1:] {
+		if name, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(name, "-") {
+			arg = name + "=" + config.RelativePath(value)
+		} else {
+			arg = config.RelativePath(arg)
+		}
+		args = append(args, arg)
+	}
+	return strings.Join(args, " ")
+}
+
+// schemaSource returns the :sourceFile: of the document, also named in the
+// generated-file notice. In reproducible mode absolute paths are made
+// relative to the working directory.
+func (g *Generator) schemaSource() string {
+	if !g.config.IsReproducible() {
+		return g.config.SchemaSource()
+	}
+	cfg := *g.config
+	cfg.SchemaFile = config.RelativePath(cfg.SchemaFile)
+	cfg.SchemaPattern = config.RelativePath(cfg.SchemaPattern)
+	cfg.IntrospectionFile = config.RelativePath(cfg.IntrospectionFile)
+	return cfg.SchemaSource()
+}
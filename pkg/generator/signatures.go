@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// getSignatureBlock builds the titled source block showing an operation's
+// signature in the configured style, with one callout per argument and a
+// final callout for the return type. The callouts are numbered the same way
+// in every style. kind ("query", "mutation" or "subscription") is used as the
+// block title.
+func (g *Generator) getSignatureBlock(
+	kind string,
+	f *ast.FieldDefinition,
+	definitionsMap map[string]*ast.Definition,
+) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, ".%s: %s\n", kind, f.Name)
+	switch strings.ToLower(g.config.SignatureStyle) {
+	case config.SignatureGraphQL:
+		writeGraphQLSignature(&b, f)
+	case config.SignatureTypeScript:
+		writeTypeScriptSignature(&b, f)
+	default:
+		writeKotlinSignature(&b, f, definitionsMap)
+	}
+	fmt.Fprint(&b, "----")
+	return b.String()
+}
+
+// writeKotlinSignature writes the signature as Kotlin-like pseudo-code
+func writeKotlinSignature(b *strings.Builder, f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) {
+	fmt.Fprintln(b, "[source, kotlin]")
+	fmt.Fprintln(b, "----")
+	fmt.Fprintf(b, "%s(\n", f.Name)
+	for i, arg := range f.Arguments {
+		typeName := parser.ProcessTypeNameForSignature(arg.Type.String(), definitionsMap)
+		fmt.Fprintf(b, "  %s: %s%s", arg.Name, typeName, formatDefaultValue(arg.DefaultValue))
+		if i < len(f.Arguments)-1 {
+			fmt.Fprint(b, " ,")
+		}
+		fmt.Fprintf(b, " <%d> \n", i+1)
+	}
+	fmt.Fprintf(b, "): %s <%d>\n",
+		parser.ProcessTypeNameForSignature(f.Type.String(), definitionsMap),
+		len(f.Arguments)+1)
+}
+
+// writeGraphQLSignature writes the signature as an SDL field definition, as
+// it would appear in the root type but without descriptions
+func writeGraphQLSignature(b *strings.Builder, f *ast.FieldDefinition) {
+	fmt.Fprintln(b, "[source, graphql]")
+	fmt.Fprintln(b, "----")
+	returnType := f.Type.String()
+	if len(f.Directives) > 0 {
+		returnType += " " + formatDirectiveList(f.Directives)
+	}
+	if len(f.Arguments) == 0 {
+		fmt.Fprintf(b, "%s: %s <1>\n", f.Name, returnType)
+		return
+	}
+
+	fmt.Fprintf(b, "%s(\n", f.Name)
+	for i, arg := range f.Arguments {
+		fmt.Fprintf(b, "  %s: %s%s", arg.Name, arg.Type.String(), formatDefaultValue(arg.DefaultValue))
+		if len(arg.Directives) > 0 {
+			fmt.Fprintf(b, " %s", formatDirectiveList(arg.Directives))
+		}
+		fmt.Fprintf(b, " <%d>\n", i+1)
+	}
+	fmt.Fprintf(b, "): %s <%d>\n", returnType, len(f.Arguments)+1)
+}
+
+// writeTypeScriptSignature writes the signature as a TypeScript function
+// type. Nullable and defaulted arguments are optional; defaults are shown in
+// a comment since TypeScript types cannot carry them.
+func writeTypeScriptSignature(b *strings.Builder, f *ast.FieldDefinition) {
+	fmt.Fprintln(b, "[source, typescript]")
+	fmt.Fprintln(b, "----")
+	if len(f.Arguments) == 0 {
+		fmt.Fprintf(b, "%s: () => %s <1>\n", f.Name, typeScriptType(f.Type))
+		return
+	}
+
+	fmt.Fprintf(b, "%s: (\n", f.Name)
+	for i, arg := range f.Arguments {
+		optional := ""
+		if !arg.Type.NonNull || arg.DefaultValue != nil {
+			optional = "?"
+		}
+		fmt.Fprintf(b, "  %s%s: %s", arg.Name, optional, typeScriptType(arg.Type))
+		if arg.DefaultValue != nil {
+			fmt.Fprintf(b, " /* = %s */", arg.DefaultValue.String())
+		}
+		if i < len(f.Arguments)-1 {
+			fmt.Fprint(b, ",")
+		}
+		fmt.Fprintf(b, " <%d>\n", i+1)
+	}
+	fmt.Fprintf(b, ") => %s <%d>\n", typeScriptType(f.Type), len(f.Arguments)+1)
+}
+
+// typeScriptType converts a GraphQL type to TypeScript: built-in scalars map
+// to their primitive types, nullable types gain "| null" and lists become
+// Array<T>. Other named types keep their GraphQL name.
+func typeScriptType(t *ast.Type) string {
+	var name string
+	if t.Elem != nil {
+		name = "Array<" + typeScriptType(t.Elem) + ">"
+	} else {
+		switch t.NamedType {
+		case "Int", "Float":
+			name = "number"
+		case "String", "ID":
+			name = "string"
+		case "Boolean":
+			name = "boolean"
+		default:
+			name = t.NamedType
+		}
+	}
+	if !t.NonNull {
+		name += " | null"
+	}
+	return name
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// definedIn returns the "Defined in" note naming the schema files the
+// positions come from, in order of first appearance, or "" when the schema
+// was read from a single file
+func (g *Generator) definedIn(positions ...*ast.Position) string {
+	if len(g.displaySourceNames()) < 2 { //nolint:mnd // a single file needs no note
+		return ""
+	}
+
+	var names []string
+	seen := make(map[string]bool)
+	for _, pos := range positions {
+		if pos == nil || pos.Src == nil {
+			continue
+		}
+		name, ok := g.displaySourceNames()[pos.Src.Name]
+		if !ok || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, "`"+name+"`")
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return "*Defined in:* " + strings.Join(names, ", ")
+}
+
+// definitionPositions returns the positions of a definition and of its
+// fields and enum values, which differ when it is extended in other files
+func definitionPositions(def *ast.Definition) []*ast.Position {
+	positions := []*ast.Position{def.Position}
+	for _, field := range def.Fields {
+		positions = append(positions, field.Position)
+	}
+	for _, value := range def.EnumValues {
+		positions = append(positions, value.Position)
+	}
+	return positions
+}
+
+// displaySourceNames returns the names of the schema files as shown in the
+// documentation, keyed by file name; it is computed on first use
+func (g *Generator) displaySourceNames() map[string]string {
+	if g.sourceNames == nil {
+		g.sourceNames = displaySourceNames(g.schema)
+	}
+	return g.sourceNames
+}
+
+// displaySourceNames maps the names of the files a schema was read from to
+// the names shown in the documentation: their paths without the directory
+// all of them share, so the output does not depend on where the schema was
+// checked out
+func displaySourceNames(schema *ast.Schema) map[string]string {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(pos *ast.Position) {
+		if pos == nil || pos.Src == nil || pos.Src.Name == "" || seen[pos.Src.Name] {
+			return
+		}
+		seen[pos.Src.Name] = true
+		files = append(files, pos.Src.Name)
+	}
+	if schema != nil {
+		for _, def := range schema.Types {
+			for _, pos := range definitionPositions(def) {
+				add(pos)
+			}
+		}
+		for _, def := range schema.Directives {
+			add(def.Position)
+		}
+	}
+
+	names := make(map[string]string)
+	if len(files) == 0 {
+		return names
+	}
+
+	common := strings.Split(filepath.ToSlash(filepath.Dir(files[0])), "/")
+	for _, file := range files[1:] {
+		dir := strings.Split(filepath.ToSlash(filepath.Dir(file)), "/")
+		n := 0
+		for n < len(common) && n < len(dir) && common[n] == dir[n] {
+			n++
+		}
+		common = common[:n]
+	}
+	prefix := strings.Join(common, "/")
+
+	for _, file := range files {
+		name := filepath.ToSlash(file)
+		if prefix != "" && prefix != "." {
+			name = strings.TrimPrefix(name, prefix+"/")
+		}
+		names[file] = name
+	}
+	return names
+}
@@ -5,13 +5,11 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/changelog"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
-	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/templates"
 )
 
 // generateSubscriptions generates the subscriptions section
@@ -20,25 +18,12 @@ func (g *Generator) generateSubscriptions(definitionsMap map[string]*ast.Definit
 
 	if g.schema.Subscription == nil || len(g.schema.Subscription.Fields) == 0 {
 		// No subscriptions exist
-		tmpl, err := template.New("subscription").Parse(templates.SubscriptionTemplate)
-		if err == nil {
-			if execErr := tmpl.Execute(g.writer, struct {
-				FoundSubscriptions bool
-				Subscriptions      []SubscriptionInfo
-			}{
-				FoundSubscriptions: false,
-				Subscriptions:      nil,
-			}); execErr != nil {
-				fmt.Fprintf(os.Stderr, "Warning: template execution error for empty subscriptions: %v\n", execErr)
-			}
-		} else {
-			fmt.Fprintln(g.writer, "== Subscription")
-			fmt.Fprintln(g.writer)
-			fmt.Fprintln(g.writer, "[NOTE]")
-			fmt.Fprintln(g.writer, "====")
-			fmt.Fprintln(g.writer, "No subscriptions exist in this schema.")
-			fmt.Fprintln(g.writer, "====")
-			fmt.Fprintln(g.writer)
+		data := struct {
+			FoundSubscriptions bool
+			Subscriptions      []SubscriptionInfo
+		}{}
+		if err := g.executeTemplate("subscription", data); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: template execution error for empty subscriptions: %v\n", err)
 		}
 		g.metrics.LogProgress("Subscriptions", "Generated 0 subscriptions")
 		return 0
@@ -47,7 +32,7 @@ func (g *Generator) generateSubscriptions(definitionsMap map[string]*ast.Definit
 	// Collect and filter subscriptions
 	var subscriptionFields []*ast.FieldDefinition
 	for _, f := range g.schema.Subscription.Fields {
-		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) {
+		if !g.shouldIncludeField(f.Name, f.Description, f.Directives) || !g.group.hasField(f) {
 			continue
 		}
 		subscriptionFields = append(subscriptionFields, f)
@@ -61,13 +46,14 @@ func (g *Generator) generateSubscriptions(definitionsMap map[string]*ast.Definit
 	// Generate subscription info for each subscription
 	var subscriptionInfos []SubscriptionInfo
 	for _, f := range subscriptionFields {
-		processedDesc, _ := changelog.ProcessWithChangelog(f.Description, parser.ProcessDescription)
-		details := g.getSubscriptionDetails(f, definitionsMap)
+		subscriptionInfo := g.getSubscriptionInfo(f, definitionsMap)
 
-		subscriptionInfo := SubscriptionInfo{
-			Description: processedDesc,
-			Details:     details,
+		details, err := g.renderTemplate("subscription-details", subscriptionInfo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering subscription %s: %v\n", f.Name, err)
 		}
+		subscriptionInfo.Details = details
+
 		subscriptionInfos = append(subscriptionInfos, subscriptionInfo)
 	}
 
@@ -79,7 +65,7 @@ func (g *Generator) generateSubscriptions(definitionsMap map[string]*ast.Definit
 		Subscriptions:      subscriptionInfos,
 	}
 
-	if err := g.executeTemplate("subscription", templates.SubscriptionTemplate, data); err != nil {
+	if err := g.executeTemplate("subscription", data); err != nil {
 		g.metrics.LogProgress("Subscriptions", "Generated 0 subscriptions (template error)")
 		return 0
 	}
@@ -88,78 +74,37 @@ func (g *Generator) generateSubscriptions(definitionsMap map[string]*ast.Definit
 	return len(subscriptionInfos)
 }
 
-// getSubscriptionDetails builds detailed documentation for a subscription field
-func (g *Generator) getSubscriptionDetails(f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) string {
-	var b strings.Builder
-
-	// Generate subscription signature
-	fmt.Fprintf(&b, "// tag::subscription-%s[]\n", f.Name)
-	fmt.Fprintln(&b)
-	fmt.Fprintf(&b, "[[subscription_%s]]\n", strings.ToLower(f.Name))
-	fmt.Fprintf(&b, "=== %s\n", f.Name)
-	fmt.Fprintln(&b)
-	fmt.Fprintln(&b)
-
-	// Generate method signature
-	fmt.Fprintf(&b, "// tag::subscription-signature-%s[]\n", f.Name)
-	fmt.Fprintf(&b, ".subscription: %s\n", f.Name)
-	fmt.Fprintln(&b, "[source, kotlin]")
-	fmt.Fprintln(&b, "----")
-	fmt.Fprintf(&b, "%s(\n", f.Name)
-
-	// Generate arguments
-	for i, arg := range f.Arguments {
-		argType := parser.ProcessTypeNameForSignature(arg.Type.String(), definitionsMap)
-		fmt.Fprintf(&b, "  %s: %s%s", arg.Name, argType, formatDefaultValue(arg.DefaultValue))
-		if i < len(f.Arguments)-1 {
-			fmt.Fprint(&b, " ,")
-		}
-		fmt.Fprintf(&b, " <%d> \n", i+1)
-	}
+// getSubscriptionInfo builds the template data for a single subscription field
+func (g *Generator) getSubscriptionInfo(f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) SubscriptionInfo {
+	processedDesc, _ := changelog.ProcessWithChangelog(f.Description, parser.ProcessDescription)
 
-	fmt.Fprintf(&b, "): %s <%d>\n",
-		parser.ProcessTypeNameForSignature(f.Type.String(), definitionsMap),
-		len(f.Arguments)+1)
-	fmt.Fprintln(&b, "----")
-	fmt.Fprintf(&b, "// end::subscription-signature-%s[]\n", f.Name)
-	fmt.Fprintln(&b)
-
-	// Add subscription name
-	fmt.Fprintf(&b, "// tag::subscription-name-%s[]\n", f.Name)
-	fmt.Fprintf(&b, "*Subscription Name:* _%s_\n", f.Name)
-	fmt.Fprintf(&b, "// end::subscription-name-%s[]\n", f.Name)
-	fmt.Fprintln(&b)
-
-	// Add return type
-	fmt.Fprintf(&b, "// tag::subscription-return-%s[]\n", f.Name)
-	fmt.Fprintf(&b, "*Return:* %s\n", parser.ProcessTypeName(f.Type.String(), definitionsMap))
-	fmt.Fprintf(&b, "// end::subscription-return-%s[]\n", f.Name)
-	fmt.Fprintln(&b)
-
-	// Add arguments if any
-	if len(f.Arguments) > 0 {
-		fmt.Fprintf(&b, "// tag::subscription-arguments-%s[]\n", f.Name)
-		fmt.Fprintln(&b, ".Arguments")
-		for _, arg := range f.Arguments {
-			fmt.Fprint(&b, formatArgumentListItem(arg.Name, arg.Type.String(), arg.DefaultValue, arg.Directives))
-		}
-		fmt.Fprintf(&b, "// end::subscription-arguments-%s[]\n", f.Name)
-		fmt.Fprintln(&b)
+	var args strings.Builder
+	for _, arg := range f.Arguments {
+		args.WriteString(formatArgumentListItem(arg.Name, arg.Type.String(), arg.DefaultValue, arg.Directives))
+		args.WriteString(g.getArgumentDeprecation(arg.Directives, definitionsMap))
 	}
 
-	// Add directives if any
-	if len(f.Directives) > 0 {
-		fmt.Fprintf(&b, "// tag::subscription-directives-%s[]\n", f.Name)
-		fmt.Fprintln(&b, ".Directives")
-		for _, d := range f.Directives {
-			fmt.Fprintf(&b, "* @%s\n", d.Name)
-		}
-		fmt.Fprintf(&b, "// end::subscription-directives-%s[]\n", f.Name)
-		fmt.Fprintln(&b)
+	return SubscriptionInfo{
+		Name:                 f.Name,
+		AnchorName:           operationAnchor(ast.Subscription, f.Name),
+		Description:          processedDesc,
+		TypeName:             parser.ProcessTypeName(f.Type.String(), definitionsMap),
+		MethodSignatureBlock: g.getSignatureBlock("subscription", f, definitionsMap),
+		Arguments:            args.String(),
+		Directives:           g.getDirectivesBlock(f),
+		HasArguments:         len(f.Arguments) > 0,
+		HasDirectives:        len(f.Directives) > 0,
+		Deprecation:          g.getDeprecationWarning(f.Directives, definitionsMap),
+		Example:              g.getExampleBlock("subscription", f),
+		DefinedIn:            g.definedIn(f.Position),
 	}
+}
 
-	fmt.Fprintf(&b, "// end::subscription-%s[]\n", f.Name)
-	fmt.Fprintln(&b)
-
-	return b.String()
+// getSubscriptionDetails builds detailed documentation for a subscription field
+func (g *Generator) getSubscriptionDetails(f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) string {
+	details, err := g.renderTemplate("subscription-details", g.getSubscriptionInfo(f, definitionsMap))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error rendering subscription %s: %v\n", f.Name, err)
+	}
+	return details
 }
@@ -1,6 +1,25 @@
 package generator
 
-// Data structures for template rendering
+import "github.com/bovinemagnet/graphqls-to-asciidoc/pkg/config"
+
+// Data structures for template rendering. Each template in pkg/templates is
+// executed with one of these (or an anonymous wrapper holding a slice of
+// them); see docs/TEMPLATES.md for the per-template data model.
+
+// HeaderData represents the document header for template rendering
+type HeaderData struct {
+	Title       string
+	Author      string
+	Version     string
+	SchemaFile  string
+	RevDate     string
+	CommandLine string
+	// Attributes are the built-in attributes merged with the configured ones
+	Attributes     []config.Attribute
+	AttributesFile string // Included below the attributes, if set
+	Banner         string // Pre-rendered generated-file notice, empty when disabled
+	Preamble       string // Content of the preamble file
+}
 
 // FieldData represents field information for template rendering
 type FieldData struct {
@@ -12,6 +31,8 @@ type FieldData struct {
 	IsArray         bool
 	Directives      string
 	Changelog       string
+	Deprecation     string // Pre-rendered WARNING admonition for deprecated fields
+	Arguments       string // Pre-rendered bullet list of the field's arguments
 }
 
 // TypeInfo represents type information for template rendering
@@ -22,7 +43,32 @@ type TypeInfo struct {
 	Description string
 	FieldsTable string // Pre-rendered AsciiDoc table for fields
 	IsInterface bool
+	Implements  string // Pre-rendered cross-references to implemented interfaces
+	MemberOf    string // Pre-rendered cross-references to unions containing this type
+	Changelog   string
+	DefinedIn   string // Pre-rendered "Defined in" note, empty for a single-file schema
+}
+
+// InterfaceInfo represents interface type information for template rendering
+type InterfaceInfo struct {
+	Name         string
+	AnchorName   string
+	Description  string
+	FieldsTable  string   // Pre-rendered AsciiDoc table for fields
+	Implements   string   // Pre-rendered cross-references to interfaces this interface extends
+	Implementors []string // Pre-rendered cross-references to implementing types
+	Changelog    string
+	DefinedIn    string // Pre-rendered "Defined in" note, empty for a single-file schema
+}
+
+// UnionInfo represents union type information for template rendering
+type UnionInfo struct {
+	Name        string
+	AnchorName  string
+	Description string
+	Members     []string // Pre-rendered cross-references to member types
 	Changelog   string
+	DefinedIn   string // Pre-rendered "Defined in" note, empty for a single-file schema
 }
 
 // EnumInfo represents enum information for template rendering
@@ -31,6 +77,7 @@ type EnumInfo struct {
 	AnchorName  string
 	Description string
 	ValuesTable string
+	DefinedIn   string // Pre-rendered "Defined in" note, empty for a single-file schema
 }
 
 // InputInfo represents input type information for template rendering
@@ -40,6 +87,23 @@ type InputInfo struct {
 	Description string
 	FieldsTable string
 	Changelog   string
+	DefinedIn   string // Pre-rendered "Defined in" note, empty for a single-file schema
+}
+
+// QueryInfo represents query information for template rendering
+type QueryInfo struct {
+	Name                 string
+	AnchorName           string
+	Description          string
+	TypeName             string
+	MethodSignatureBlock string
+	Arguments            string
+	HasArguments         bool
+	Changelog            string
+	NumberedRefs         string
+	Deprecation          string // Pre-rendered WARNING admonition for deprecated queries
+	Example              string // Pre-rendered example operation, empty unless enabled
+	DefinedIn            string // Pre-rendered "Defined in" note, empty for a single-file schema
 }
 
 // MutationInfo represents mutation information for template rendering
@@ -57,6 +121,16 @@ type MutationInfo struct {
 	IsInternal           bool
 	Changelog            string
 	NumberedRefs         string
+	Deprecation          string // Pre-rendered WARNING admonition for deprecated mutations
+	Example              string // Pre-rendered example operation, empty unless enabled
+	DefinedIn            string // Pre-rendered "Defined in" note, empty for a single-file schema
+}
+
+// DiagramData represents the type diagram for template rendering
+type DiagramData struct {
+	Style  string // asciidoctor-diagram block style: plantuml or mermaid
+	Root   string // root field the diagram is scoped to, empty for all types
+	Source string // diagram source, empty when there are no types to draw
 }
 
 // ScalarData represents scalar information for template rendering
@@ -70,6 +144,7 @@ type ScalarData struct {
 type ScalarInfo struct {
 	Name        string
 	Description string
+	DefinedIn   string // Pre-rendered "Defined in" note, empty for a single-file schema
 }
 
 // SubscriptionData represents subscription information for template rendering
@@ -78,10 +153,22 @@ type SubscriptionData struct {
 	Subscriptions      []SubscriptionInfo
 }
 
-// SubscriptionInfo represents individual subscription information
+// SubscriptionInfo represents individual subscription information. Details
+// holds the output of the subscription-details template for this entry.
 type SubscriptionInfo struct {
-	Description string
-	Details     string
+	Name                 string
+	AnchorName           string
+	Description          string
+	TypeName             string
+	MethodSignatureBlock string
+	Arguments            string
+	Directives           string
+	HasArguments         bool
+	HasDirectives        bool
+	Deprecation          string // Pre-rendered WARNING admonition for deprecated subscriptions
+	Example              string // Pre-rendered example operation, empty unless enabled
+	DefinedIn            string // Pre-rendered "Defined in" note, empty for a single-file schema
+	Details              string
 }
 
 // DirectiveData represents directive information for template rendering
@@ -99,6 +186,27 @@ type DirectiveInfo struct {
 	Description string
 }
 
+// DirectiveDefinitionInfo represents a directive definition for the
+// directives template
+type DirectiveDefinitionInfo struct {
+	Name         string
+	AnchorName   string
+	Description  string
+	Signature    string
+	Arguments    []ArgumentInfo
+	Locations    []string
+	IsRepeatable bool
+	DefinedIn    string // Pre-rendered "Defined in" note, empty for a single-file schema
+}
+
+// ArgumentInfo represents a single argument definition for template rendering
+type ArgumentInfo struct {
+	Name         string
+	Type         string
+	DefaultValue string
+	Description  string
+}
+
 // CatalogueEntry represents a single entry in the catalogue table
 type CatalogueEntry struct {
 	Name        string
@@ -114,6 +222,7 @@ type MutationGroup struct {
 
 // CatalogueData represents the data for catalogue template rendering
 type CatalogueData struct {
+	Header         string // Pre-rendered document header, shared with the full documentation
 	SubTitle       string
 	RevDate        string
 	CommandLine    string
@@ -122,3 +231,18 @@ type CatalogueData struct {
 	MutationGroups []MutationGroup  // Grouped mutations
 	Subscriptions  []CatalogueEntry
 }
+
+// GroupInfo represents one --group-by group for template rendering
+type GroupInfo struct {
+	Name       string
+	AnchorName string
+	Operations string // Pre-rendered cross-references to the group's queries, mutations and subscriptions
+	Types      string // Pre-rendered cross-references to the group's types, scalars and directives
+	Content    string // The group's sections, one level down; empty in the index
+}
+
+// GroupIndexData represents the index of the --group-by groups
+type GroupIndexData struct {
+	Heading string // What each group is: File, Directory or Module
+	Groups  []GroupInfo
+}
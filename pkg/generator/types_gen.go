@@ -5,13 +5,11 @@ import (
 	"os"
 	"sort"
 	"strings"
-	"text/template"
 
 	"github.com/vektah/gqlparser/v2/ast"
 
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/changelog"
 	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
-	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/templates"
 )
 
 const errFieldsTable = "[ERROR generating fields table]"
@@ -23,7 +21,7 @@ func (g *Generator) generateTypes(sortedDefs []*ast.Definition, definitionsMap m
 	count := 0
 
 	for _, t := range sortedDefs {
-		if t.Kind != ast.Object || parser.IsBuiltInGraphQLType(t.Name) {
+		if t.Kind != ast.Object || parser.IsBuiltInGraphQLType(t.Name) || !g.group.hasDefinition(t) {
 			continue
 		}
 
@@ -44,7 +42,10 @@ func (g *Generator) generateTypes(sortedDefs []*ast.Definition, definitionsMap m
 			Description: processedDesc,
 			FieldsTable: fieldsTableString,
 			IsInterface: t.Kind == ast.Interface,
+			Implements:  formatTypeReferenceList(t.Interfaces, definitionsMap),
+			MemberOf:    formatTypeReferenceList(findContainingUnions(t.Name, sortedDefs), definitionsMap),
 			Changelog:   changelogText,
+			DefinedIn:   g.definedIn(definitionPositions(t)...),
 		}
 		typeInfos = append(typeInfos, typeInfo)
 		count++
@@ -59,7 +60,7 @@ func (g *Generator) generateTypes(sortedDefs []*ast.Definition, definitionsMap m
 			Types:    typeInfos,
 		}
 
-		if err := g.executeTemplate("types", templates.TypeSectionTemplate, data); err != nil {
+		if err := g.executeTemplate("type-section", data); err != nil {
 			g.metrics.LogProgress("Types", fmt.Sprintf("Generated %d types", count))
 			return count
 		}
@@ -69,7 +70,162 @@ func (g *Generator) generateTypes(sortedDefs []*ast.Definition, definitionsMap m
 	return count
 }
 
-func (g *Generator) generateEnums(sortedDefs []*ast.Definition) int {
+func (g *Generator) generateInterfaces(sortedDefs []*ast.Definition, definitionsMap map[string]*ast.Definition) int {
+	g.metrics.LogProgress("Interfaces", "Starting interfaces generation")
+
+	var interfaceInfos []InterfaceInfo
+	count := 0
+
+	for _, def := range sortedDefs {
+		if def.Kind != ast.Interface || !g.group.hasDefinition(def) {
+			continue
+		}
+
+		fieldsTableString, err := g.getTypeFieldsTableString(def, definitionsMap)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating fields table for interface %s: %v\n", def.Name, err)
+			fieldsTableString = errFieldsTable
+		}
+
+		processedDesc, changelogText := changelog.ProcessWithChangelog(def.Description, parser.ProcessDescription)
+
+		var implementors []string
+		for _, name := range findImplementors(def.Name, sortedDefs) {
+			implementors = append(implementors, parser.ProcessTypeName(name, definitionsMap))
+		}
+
+		interfaceInfo := InterfaceInfo{
+			Name:         def.Name,
+			AnchorName:   "interface_" + parser.CamelToSnake(def.Name),
+			Description:  processedDesc,
+			FieldsTable:  fieldsTableString,
+			Implements:   formatTypeReferenceList(def.Interfaces, definitionsMap),
+			Implementors: implementors,
+			Changelog:    changelogText,
+			DefinedIn:    g.definedIn(definitionPositions(def)...),
+		}
+		interfaceInfos = append(interfaceInfos, interfaceInfo)
+		count++
+	}
+
+	// Interfaces are optional in most schemas, so the section is omitted
+	// entirely when there is nothing to document (as for directives).
+	if len(interfaceInfos) > 0 {
+		data := struct {
+			InterfacesTag string
+			Interfaces    []InterfaceInfo
+		}{
+			InterfacesTag: "== Interfaces",
+			Interfaces:    interfaceInfos,
+		}
+
+		if err := g.executeTemplate("interface-section", data); err != nil {
+			g.metrics.LogProgress("Interfaces", fmt.Sprintf("Generated %d interfaces", count))
+			return count
+		}
+	}
+
+	g.metrics.LogProgress("Interfaces", fmt.Sprintf("Generated %d interfaces", count))
+	return count
+}
+
+func (g *Generator) generateUnions(sortedDefs []*ast.Definition, definitionsMap map[string]*ast.Definition) int {
+	g.metrics.LogProgress("Unions", "Starting unions generation")
+
+	var unionInfos []UnionInfo
+	count := 0
+
+	for _, def := range sortedDefs {
+		if def.Kind != ast.Union || !g.group.hasDefinition(def) {
+			continue
+		}
+
+		processedDesc, changelogText := changelog.ProcessWithChangelog(def.Description, parser.ProcessDescription)
+
+		var members []string
+		for _, name := range def.Types {
+			members = append(members, parser.ProcessTypeName(name, definitionsMap))
+		}
+
+		unionInfo := UnionInfo{
+			Name:        def.Name,
+			AnchorName:  "union_" + parser.CamelToSnake(def.Name),
+			Description: processedDesc,
+			Members:     members,
+			Changelog:   changelogText,
+			DefinedIn:   g.definedIn(def.Position),
+		}
+		unionInfos = append(unionInfos, unionInfo)
+		count++
+	}
+
+	// As with interfaces, the section is omitted when the schema has no unions.
+	if len(unionInfos) > 0 {
+		data := struct {
+			UnionsTag string
+			Unions    []UnionInfo
+		}{
+			UnionsTag: "== Unions",
+			Unions:    unionInfos,
+		}
+
+		if err := g.executeTemplate("union-section", data); err != nil {
+			g.metrics.LogProgress("Unions", fmt.Sprintf("Generated %d unions", count))
+			return count
+		}
+	}
+
+	g.metrics.LogProgress("Unions", fmt.Sprintf("Generated %d unions", count))
+	return count
+}
+
+// findContainingUnions returns the names of all unions that list the named
+// type as a member, in sortedDefs order.
+func findContainingUnions(typeName string, sortedDefs []*ast.Definition) []string {
+	var names []string
+	for _, def := range sortedDefs {
+		if def.Kind != ast.Union {
+			continue
+		}
+		for _, member := range def.Types {
+			if member == typeName {
+				names = append(names, def.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// findImplementors returns the names of all object and interface types that
+// declare the named interface in their implements clause, in sortedDefs order.
+func findImplementors(interfaceName string, sortedDefs []*ast.Definition) []string {
+	var names []string
+	for _, def := range sortedDefs {
+		if def.Kind != ast.Object && def.Kind != ast.Interface {
+			continue
+		}
+		for _, name := range def.Interfaces {
+			if name == interfaceName {
+				names = append(names, def.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// formatTypeReferenceList renders type names as a comma-separated list of
+// cross-references, e.g. "<<Node,`Node`>>, <<Auditable,`Auditable`>>".
+func formatTypeReferenceList(names []string, definitionsMap map[string]*ast.Definition) string {
+	refs := make([]string, 0, len(names))
+	for _, name := range names {
+		refs = append(refs, parser.ProcessTypeName(name, definitionsMap))
+	}
+	return strings.Join(refs, ", ")
+}
+
+func (g *Generator) generateEnums(sortedDefs []*ast.Definition, definitionsMap map[string]*ast.Definition) int {
 	g.metrics.LogProgress("Enums", "Starting enums generation")
 
 	var enumInfos []EnumInfo
@@ -77,12 +233,12 @@ func (g *Generator) generateEnums(sortedDefs []*ast.Definition) int {
 
 	// Filter for enum definitions
 	for _, def := range sortedDefs {
-		if def.Kind != ast.Enum {
+		if def.Kind != ast.Enum || !g.group.hasDefinition(def) {
 			continue
 		}
 
 		// Generate values table
-		valuesTableString := g.getEnumValuesTableString(def)
+		valuesTableString := g.getEnumValuesTableString(def, definitionsMap)
 
 		// Process enum description and extract changelog
 		processedDesc, _ := changelog.ProcessWithChangelog(def.Description, parser.ProcessDescription)
@@ -92,33 +248,24 @@ func (g *Generator) generateEnums(sortedDefs []*ast.Definition) int {
 			AnchorName:  "enum_" + parser.CamelToSnake(def.Name),
 			Description: processedDesc,
 			ValuesTable: valuesTableString,
+			DefinedIn:   g.definedIn(definitionPositions(def)...),
 		}
 		enumInfos = append(enumInfos, enumInfo)
 		count++
 	}
 
-	if len(enumInfos) > 0 {
-		data := struct {
-			EnumsTag string
-			Enums    []EnumInfo
-		}{
-			EnumsTag: "== Enums",
-			Enums:    enumInfos,
-		}
+	// The template renders a NOTE in place of the section when there are no enums
+	data := struct {
+		EnumsTag string
+		Enums    []EnumInfo
+	}{
+		EnumsTag: "== Enums",
+		Enums:    enumInfos,
+	}
 
-		if err := g.executeTemplate("enums", templates.EnumSectionTemplate, data); err != nil {
-			g.metrics.LogProgress("Enums", fmt.Sprintf("Generated %d enums", count))
-			return count
-		}
-	} else {
-		// No enums found, write a note
-		fmt.Fprintln(g.writer, "== Enums")
-		fmt.Fprintln(g.writer)
-		fmt.Fprintln(g.writer, "[NOTE]")
-		fmt.Fprintln(g.writer, "====")
-		fmt.Fprintln(g.writer, "No enums exist in this schema.")
-		fmt.Fprintln(g.writer, "====")
-		fmt.Fprintln(g.writer)
+	if err := g.executeTemplate("enum-section", data); err != nil {
+		g.metrics.LogProgress("Enums", fmt.Sprintf("Generated %d enums", count))
+		return count
 	}
 
 	g.metrics.LogProgress("Enums", fmt.Sprintf("Generated %d enums", count))
@@ -133,7 +280,7 @@ func (g *Generator) generateInputs(sortedDefs []*ast.Definition, definitionsMap
 
 	// Filter for input object definitions
 	for _, def := range sortedDefs {
-		if def.Kind != ast.InputObject {
+		if def.Kind != ast.InputObject || !g.group.hasDefinition(def) {
 			continue
 		}
 
@@ -147,32 +294,24 @@ func (g *Generator) generateInputs(sortedDefs []*ast.Definition, definitionsMap
 			Description: processedDesc,
 			FieldsTable: fieldsTableString,
 			Changelog:   changelogText,
+			DefinedIn:   g.definedIn(definitionPositions(def)...),
 		}
 		inputInfos = append(inputInfos, inputInfo)
 		count++
 	}
 
-	if len(inputInfos) > 0 {
-		data := struct {
-			InputsTag string
-			Inputs    []InputInfo
-		}{
-			InputsTag: "== Inputs",
-			Inputs:    inputInfos,
-		}
+	// The template renders a NOTE in place of the section when there are no inputs
+	data := struct {
+		InputsTag string
+		Inputs    []InputInfo
+	}{
+		InputsTag: "== Inputs",
+		Inputs:    inputInfos,
+	}
 
-		if err := g.executeTemplate("inputs", templates.InputSectionTemplate, data); err != nil {
-			g.metrics.LogProgress("Inputs", fmt.Sprintf("Generated %d inputs", count))
-			return count
-		}
-	} else {
-		fmt.Fprintln(g.writer, "== Inputs")
-		fmt.Fprintln(g.writer)
-		fmt.Fprintln(g.writer, "[NOTE]")
-		fmt.Fprintln(g.writer, "====")
-		fmt.Fprintln(g.writer, "No input types exist in this schema.")
-		fmt.Fprintln(g.writer, "====")
-		fmt.Fprintln(g.writer)
+	if err := g.executeTemplate("input-section", data); err != nil {
+		g.metrics.LogProgress("Inputs", fmt.Sprintf("Generated %d inputs", count))
+		return count
 	}
 
 	g.metrics.LogProgress("Inputs", fmt.Sprintf("Generated %d inputs", count))
@@ -193,7 +332,7 @@ func (g *Generator) getInputFieldsTableString(
 	for _, field := range def.Fields {
 		typeName := parser.ProcessTypeName(field.Type.String(), definitionsMap)
 		processedDesc, changelogText := changelog.ProcessWithChangelog(field.Description, parser.ProcessDescription)
-		desc := processedDesc
+		desc := g.appendDeprecationWarning(processedDesc, field.Directives, definitionsMap)
 		if changelogText != "" {
 			desc += "\n" + changelogText
 		}
@@ -216,138 +355,104 @@ func (g *Generator) generateDirectives() int {
 		return 0
 	}
 
-	fmt.Fprintln(g.writer, "== Directives")
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer, "// tag::DIRECTIVES[]")
-	fmt.Fprintln(g.writer)
-
 	// Sort directives by name for consistent output
 	var directiveNames []string
-	for name := range g.schema.Directives {
-		directiveNames = append(directiveNames, name)
+	for name, directive := range g.schema.Directives {
+		if g.group.hasDirective(directive) {
+			directiveNames = append(directiveNames, name)
+		}
 	}
 	sort.Strings(directiveNames)
 
-	count := 0
+	directiveInfos := make([]DirectiveDefinitionInfo, 0, len(directiveNames))
 	for _, name := range directiveNames {
-		directive := g.schema.Directives[name]
-		g.generateDirective(directive)
-		count++
+		directiveInfos = append(directiveInfos, g.getDirectiveDefinitionInfo(g.schema.Directives[name]))
 	}
 
-	fmt.Fprintln(g.writer, "// end::DIRECTIVES[]")
+	data := struct {
+		DirectivesTag string
+		Directives    []DirectiveDefinitionInfo
+	}{
+		DirectivesTag: "== Directives",
+		Directives:    directiveInfos,
+	}
+
+	if err := g.executeTemplate("directives", data); err != nil {
+		g.metrics.LogProgress("Directives", "Generated 0 directives (template error)")
+		return 0
+	}
 
+	count := len(directiveInfos)
 	g.metrics.LogProgress("Directives", fmt.Sprintf("Generated %d directives", count))
 	return count
 }
 
-// generateDirective generates documentation for a single directive
-func (g *Generator) generateDirective(directive *ast.DirectiveDefinition) {
-	fmt.Fprintf(g.writer, "// tag::directive-%s[]\n", directive.Name)
-	fmt.Fprintln(g.writer)
-	fmt.Fprintf(g.writer, "[[directive_%s]]\n", strings.ToLower(directive.Name))
-	fmt.Fprintf(g.writer, "=== @%s\n", directive.Name)
-	fmt.Fprintln(g.writer)
+// getDirectiveDefinitionInfo builds the template data for a single directive definition
+func (g *Generator) getDirectiveDefinitionInfo(directive *ast.DirectiveDefinition) DirectiveDefinitionInfo {
+	info := DirectiveDefinitionInfo{
+		Name:         directive.Name,
+		AnchorName:   "directive_" + strings.ToLower(directive.Name),
+		Signature:    getDirectiveSignature(directive),
+		IsRepeatable: directive.IsRepeatable,
+		DefinedIn:    g.definedIn(directive.Position),
+	}
 
-	// Process description
 	if directive.Description != "" {
-		processedDesc := parser.ProcessDescription(directive.Description)
-		fmt.Fprintf(g.writer, "// tag::directive-description-%s[]\n", directive.Name)
-		fmt.Fprint(g.writer, processedDesc)
-		fmt.Fprintln(g.writer)
-		fmt.Fprintf(g.writer, "// end::directive-description-%s[]\n", directive.Name)
-		fmt.Fprintln(g.writer)
+		info.Description = parser.ProcessDescription(directive.Description)
 	}
 
-	// Generate directive signature
-	fmt.Fprintf(g.writer, "// tag::directive-signature-%s[]\n", directive.Name)
-	fmt.Fprintln(g.writer, ".Directive Signature")
-	fmt.Fprintln(g.writer, "[source, graphql]")
-	fmt.Fprintln(g.writer, "----")
-	fmt.Fprintf(g.writer, "directive @%s", directive.Name)
+	for _, arg := range directive.Arguments {
+		argInfo := ArgumentInfo{
+			Name: arg.Name,
+			Type: arg.Type.String(),
+		}
+		if arg.DefaultValue != nil {
+			argInfo.DefaultValue = arg.DefaultValue.String()
+		}
+		if arg.Description != "" {
+			argInfo.Description = parser.ProcessDescription(arg.Description)
+		}
+		info.Arguments = append(info.Arguments, argInfo)
+	}
+
+	for _, location := range directive.Locations {
+		info.Locations = append(info.Locations, string(location))
+	}
+
+	return info
+}
+
+// getDirectiveSignature renders a directive definition as SDL, e.g.
+// "directive @length(max: Int = 100) on FIELD_DEFINITION | ARGUMENT_DEFINITION".
+func getDirectiveSignature(directive *ast.DirectiveDefinition) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "directive @%s", directive.Name)
 
 	if len(directive.Arguments) > 0 {
-		fmt.Fprint(g.writer, "(")
+		b.WriteString("(")
 		for i, arg := range directive.Arguments {
 			if i > 0 {
-				fmt.Fprint(g.writer, ", ")
+				b.WriteString(", ")
 			}
-			fmt.Fprintf(g.writer, "%s: %s", arg.Name, arg.Type.String())
+			fmt.Fprintf(&b, "%s: %s", arg.Name, arg.Type.String())
 			if arg.DefaultValue != nil {
-				fmt.Fprintf(g.writer, " = %s", arg.DefaultValue.String())
+				fmt.Fprintf(&b, " = %s", arg.DefaultValue.String())
 			}
 		}
-		fmt.Fprint(g.writer, ")")
+		b.WriteString(")")
 	}
 
 	if len(directive.Locations) > 0 {
-		fmt.Fprint(g.writer, " on ")
+		b.WriteString(" on ")
 		for i, location := range directive.Locations {
 			if i > 0 {
-				fmt.Fprint(g.writer, " | ")
-			}
-			fmt.Fprint(g.writer, string(location))
-		}
-	}
-
-	fmt.Fprintln(g.writer)
-	fmt.Fprintln(g.writer, "----")
-	fmt.Fprintf(g.writer, "// end::directive-signature-%s[]\n", directive.Name)
-	fmt.Fprintln(g.writer)
-
-	// Generate arguments table if there are arguments
-	if len(directive.Arguments) > 0 {
-		fmt.Fprintf(g.writer, "// tag::directive-arguments-%s[]\n", directive.Name)
-		fmt.Fprintf(g.writer, ".@%s Arguments\n", directive.Name)
-		fmt.Fprintln(g.writer, "[options=\"header\",stripes=\"even\"]")
-		fmt.Fprintln(g.writer, "|===")
-		fmt.Fprintln(g.writer, "| Argument | Type | Default | Description")
-
-		for _, arg := range directive.Arguments {
-			fmt.Fprintf(g.writer, "| `%s`", arg.Name)
-			fmt.Fprintf(g.writer, " | `%s`", arg.Type.String())
-
-			if arg.DefaultValue != nil {
-				fmt.Fprintf(g.writer, " | `%s`", arg.DefaultValue.String())
-			} else {
-				fmt.Fprint(g.writer, " | _none_")
+				b.WriteString(" | ")
 			}
-
-			if arg.Description != "" {
-				processedDesc := parser.ProcessDescription(arg.Description)
-				fmt.Fprintf(g.writer, " | %s", processedDesc)
-			} else {
-				fmt.Fprint(g.writer, " | _No description_")
-			}
-			fmt.Fprintln(g.writer)
-		}
-
-		fmt.Fprintln(g.writer, "|===")
-		fmt.Fprintf(g.writer, "// end::directive-arguments-%s[]\n", directive.Name)
-		fmt.Fprintln(g.writer)
-	}
-
-	// Generate locations information
-	if len(directive.Locations) > 0 {
-		fmt.Fprintf(g.writer, "// tag::directive-locations-%s[]\n", directive.Name)
-		fmt.Fprintf(g.writer, ".@%s Usage Locations\n", directive.Name)
-		for _, location := range directive.Locations {
-			fmt.Fprintf(g.writer, "* `%s`\n", string(location))
+			b.WriteString(string(location))
 		}
-		fmt.Fprintf(g.writer, "// end::directive-locations-%s[]\n", directive.Name)
-		fmt.Fprintln(g.writer)
 	}
 
-	// Repeatable information
-	if directive.IsRepeatable {
-		fmt.Fprintf(g.writer, "// tag::directive-repeatable-%s[]\n", directive.Name)
-		fmt.Fprintln(g.writer, "NOTE: This directive is repeatable and can be used multiple times on the same element.")
-		fmt.Fprintf(g.writer, "// end::directive-repeatable-%s[]\n", directive.Name)
-		fmt.Fprintln(g.writer)
-	}
-
-	fmt.Fprintf(g.writer, "// end::directive-%s[]\n", directive.Name)
-	fmt.Fprintln(g.writer)
+	return b.String()
 }
 
 func (g *Generator) generateScalars(sortedDefs []*ast.Definition) int {
@@ -358,13 +463,14 @@ func (g *Generator) generateScalars(sortedDefs []*ast.Definition) int {
 
 	// Filter for scalar definitions and exclude built-in scalars
 	for _, def := range sortedDefs {
-		if def.Kind == ast.Scalar && !isBuiltInScalar(def.Name) {
+		if def.Kind == ast.Scalar && !isBuiltInScalar(def.Name) && g.group.hasDefinition(def) {
 			// Process description and extract changelog
 			processedDesc, _ := changelog.ProcessWithChangelog(def.Description, parser.ProcessDescription)
 
 			scalarInfo := ScalarInfo{
 				Name:        def.Name,
 				Description: processedDesc,
+				DefinedIn:   g.definedIn(def.Position),
 			}
 			scalarInfos = append(scalarInfos, scalarInfo)
 			count++
@@ -378,7 +484,7 @@ func (g *Generator) generateScalars(sortedDefs []*ast.Definition) int {
 		Scalars:      scalarInfos,
 	}
 
-	if err := g.executeTemplate("scalars", templates.ScalarTemplate, data); err != nil {
+	if err := g.executeTemplate("scalar", data); err != nil {
 		g.metrics.LogProgress("Scalars", fmt.Sprintf("Generated %d scalars", count))
 		return count
 	}
@@ -394,7 +500,11 @@ func (g *Generator) getTypeFieldsTableString(
 ) (string, error) {
 	var builder strings.Builder
 
-	builder.WriteString(".type: " + t.Name + "\n")
+	if t.Kind == ast.Interface {
+		builder.WriteString(".interface: " + t.Name + "\n")
+	} else {
+		builder.WriteString(".type: " + t.Name + "\n")
+	}
 	builder.WriteString("[options=\"header\",cols=\"2a,2m,5a\"]\n")
 	builder.WriteString("|===\n")
 	builder.WriteString("| Type | Field | Description \n")
@@ -409,11 +519,11 @@ func (g *Generator) getTypeFieldsTableString(
 			Description:     processedDesc,
 			RequiredOrArray: strings.Contains(typeName, "!") || strings.Contains(typeName, "["),
 			Changelog:       changelogText,
+			Deprecation:     g.getDeprecationWarning(f.Directives, definitionsMap),
+			Arguments:       g.getFieldArgumentsList(f, definitionsMap),
 		}
 
-		tmpl, err := template.New("field").Funcs(template.FuncMap{
-			"processDescription": parser.ProcessDescription,
-		}).Parse(templates.FieldTemplate)
+		tmpl, err := g.parseTemplate("field")
 		if err != nil {
 			return "", err
 		}
@@ -428,7 +538,23 @@ func (g *Generator) getTypeFieldsTableString(
 	return builder.String(), nil
 }
 
-func (g *Generator) getEnumValuesTableString(e *ast.Definition) string {
+// getFieldArgumentsList renders the arguments of a type or interface field as
+// a bullet list for its fields table cell, with each argument's description
+// attached to its list item. Argument types cross-reference their definitions.
+func (g *Generator) getFieldArgumentsList(f *ast.FieldDefinition, definitionsMap map[string]*ast.Definition) string {
+	var b strings.Builder
+	for _, arg := range f.Arguments {
+		typeName := parser.ProcessTypeName(arg.Type.String(), definitionsMap)
+		b.WriteString(formatFieldArgumentListItem(arg.Name, typeName, arg.DefaultValue, arg.Directives))
+		if arg.Description != "" {
+			fmt.Fprintf(&b, "+\n--\n%s\n--\n", strings.TrimSpace(parser.ProcessDescription(arg.Description)))
+		}
+		b.WriteString(g.getArgumentDeprecation(arg.Directives, definitionsMap))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (g *Generator) getEnumValuesTableString(e *ast.Definition, definitionsMap map[string]*ast.Definition) string {
 	var builder strings.Builder
 
 	builder.WriteString(".enum: " + e.Name + "\n")
@@ -438,6 +564,7 @@ func (g *Generator) getEnumValuesTableString(e *ast.Definition) string {
 
 	for _, value := range e.EnumValues {
 		processedDesc := parser.ProcessDescription(value.Description)
+		processedDesc = g.appendDeprecationWarning(processedDesc, value.Directives, definitionsMap)
 		fmt.Fprintf(&builder, "| `%s` | %s\n", value.Name, processedDesc)
 	}
 
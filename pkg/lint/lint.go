@@ -0,0 +1,232 @@
+// Package lint checks a schema against documentation and naming
+// conventions. Each check is a Rule with a name and a default severity;
+// rules are registered in a package-level registry, so new rules can be
+// plugged in with Register, and every rule's severity can be changed to
+// off, warn or error when a Linter is created.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// Severity is how a rule's findings are treated
+type Severity int
+
+const (
+	// Off disables a rule
+	Off Severity = iota
+	// Warn reports findings without failing
+	Warn
+	// Error reports findings and fails the lint run
+	Error
+)
+
+func (s Severity) String() string {
+	switch s {
+	case Warn:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return "off"
+}
+
+// ParseSeverity parses a severity as written in flags and configuration
+// files: off, warn (or warning) and error, in any case
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "off":
+		return Off, nil
+	case "warn", "warning":
+		return Warn, nil
+	case "error":
+		return Error, nil
+	}
+	return Off, fmt.Errorf("invalid lint severity '%s' (supported: off, warn, error)", s)
+}
+
+// Reporter records a finding of a rule at a schema element. path names the
+// element, e.g. "User.email" or "Query.users(limit)"; pos may be nil.
+type Reporter func(pos *ast.Position, path, message string)
+
+// Rule is a single lint check
+type Rule interface {
+	// Name identifies the rule in configuration and output, e.g.
+	// "enum-value-case"
+	Name() string
+	// Description says what the rule checks, in one sentence
+	Description() string
+	// DefaultSeverity applies when the rule is not configured
+	DefaultSeverity() Severity
+	// Check reports every finding in the schema
+	Check(schema *ast.Schema, report Reporter)
+}
+
+// NewRule returns a rule that runs check
+func NewRule(name, description string, severity Severity, check func(*ast.Schema, Reporter)) Rule {
+	return &funcRule{name: name, description: description, severity: severity, check: check}
+}
+
+type funcRule struct {
+	name, description string
+	severity          Severity
+	check             func(*ast.Schema, Reporter)
+}
+
+func (r *funcRule) Name() string              { return r.name }
+func (r *funcRule) Description() string       { return r.description }
+func (r *funcRule) DefaultSeverity() Severity { return r.severity }
+func (r *funcRule) Check(schema *ast.Schema, report Reporter) {
+	r.check(schema, report)
+}
+
+// registry holds the available rules in registration order
+var registry []Rule
+
+// Register adds a rule to the registry. It panics if a rule with the same
+// name is already registered.
+func Register(rule Rule) {
+	if Lookup(rule.Name()) != nil {
+		panic(fmt.Sprintf("lint rule '%s' is already registered", rule.Name()))
+	}
+	registry = append(registry, rule)
+}
+
+// Rules returns the registered rules in registration order
+func Rules() []Rule {
+	return append([]Rule(nil), registry...)
+}
+
+// Lookup returns the registered rule with the given name, or nil
+func Lookup(name string) Rule {
+	for _, r := range registry {
+		if r.Name() == name {
+			return r
+		}
+	}
+	return nil
+}
+
+// Issue is a single finding
+type Issue struct {
+	Rule     string
+	Severity Severity
+	// Path names the schema element, e.g. "User.email"
+	Path    string
+	Message string
+	// Position is where the element is defined; it may be nil
+	Position *ast.Position
+}
+
+// String formats the issue as "file:line: severity: message [rule]", or
+// with the element path in place of the position when it is unknown
+func (i Issue) String() string {
+	location := i.Path
+	if i.Position != nil && i.Position.Src != nil {
+		location = fmt.Sprintf("%s:%d", i.Position.Src.Name, i.Position.Line)
+	}
+	return fmt.Sprintf("%s: %s: %s [%s]", location, i.Severity, i.Message, i.Rule)
+}
+
+// Linter runs the registered rules with configured severities
+type Linter struct {
+	severities map[string]Severity
+}
+
+// New returns a linter. levels maps rule names to "off", "warn" or
+// "error"; rules not listed keep their default severity. Unknown rule
+// names and invalid severities are errors.
+func New(levels map[string]string) (*Linter, error) {
+	l := &Linter{severities: make(map[string]Severity)}
+	for _, r := range registry {
+		l.severities[r.Name()] = r.DefaultSeverity()
+	}
+
+	names := make([]string, 0, len(levels))
+	for name := range levels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if Lookup(name) == nil {
+			return nil, fmt.Errorf("unknown lint rule '%s' (available: %s)", name, strings.Join(ruleNames(), ", "))
+		}
+		severity, err := ParseSeverity(levels[name])
+		if err != nil {
+			return nil, fmt.Errorf("rule '%s': %v", name, err)
+		}
+		l.severities[name] = severity
+	}
+	return l, nil
+}
+
+// Severity returns the configured severity of a rule
+func (l *Linter) Severity(name string) Severity {
+	return l.severities[name]
+}
+
+// Run checks the schema with every rule that is not off and returns the
+// issues ordered by position, then by rule name
+func (l *Linter) Run(schema *ast.Schema) []Issue {
+	var issues []Issue
+	for _, r := range registry {
+		severity := l.severities[r.Name()]
+		if severity == Off {
+			continue
+		}
+		r.Check(schema, func(pos *ast.Position, path, message string) {
+			issues = append(issues, Issue{
+				Rule: r.Name(), Severity: severity, Path: path, Message: message, Position: pos,
+			})
+		})
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if fa, fb := sourceName(a.Position), sourceName(b.Position); fa != fb {
+			return fa < fb
+		}
+		if la, lb := line(a.Position), line(b.Position); la != lb {
+			return la < lb
+		}
+		return a.Rule < b.Rule
+	})
+	return issues
+}
+
+// Count returns the number of issues with the given severity
+func Count(issues []Issue, severity Severity) int {
+	n := 0
+	for _, i := range issues {
+		if i.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+func ruleNames() []string {
+	names := make([]string, len(registry))
+	for i, r := range registry {
+		names[i] = r.Name()
+	}
+	return names
+}
+
+func sourceName(pos *ast.Position) string {
+	if pos == nil || pos.Src == nil {
+		return ""
+	}
+	return pos.Src.Name
+}
+
+func line(pos *ast.Position) int {
+	if pos == nil {
+		return 0
+	}
+	return pos.Line
+}
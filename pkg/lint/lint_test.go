@@ -0,0 +1,155 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+const testSchema = `"Queries"
+type Query {
+  """
+  Find users.
+  @param limit page size
+  @param ofset items to skip
+  """
+  users(limit: Int, offset: Int, "Sort order" sort_order: String): [User!]!
+}
+type Mutation {
+  "Adds a user"
+  addUser(name: String): User
+  "Creates a user"
+  createUser(name: String): User
+}
+"A user"
+type User {
+  "Id"
+  id: ID!
+  "Name"
+  first_name: String
+  "Old name"
+  nick: String @deprecated
+  "Role"
+  role: Role @deprecated(reason: "Use roles")
+}
+"Roles"
+enum Role {
+  "Admin"
+  ADMIN
+  "Read only"
+  readOnly
+}
+`
+
+func buildSchema(t *testing.T, sdl string) *ast.Schema {
+	t.Helper()
+	doc, err := gqlparser.ParseSchema(&ast.Source{Name: "schema.graphqls", Input: sdl})
+	if err != nil {
+		t.Fatalf("failed to parse schema: %v", err)
+	}
+	return parser.BuildSchema(doc)
+}
+
+func TestRun(t *testing.T) {
+	l, err := New(nil)
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	issues := l.Run(buildSchema(t, testSchema))
+
+	var lines []string
+	for _, i := range issues {
+		lines = append(lines, i.String())
+	}
+	expected := []string{
+		"schema.graphqls:7: error: @param ofset of Query.users does not match an argument [param-names]",
+		"schema.graphqls:8: warning: argument Query.users(sort_order) is not camelCase [field-camel-case]",
+		"schema.graphqls:8: warning: argument Query.users(offset) has no description [missing-description]",
+		"schema.graphqls:12: warning: argument Mutation.addUser(name) has no description [missing-description]",
+		"schema.graphqls:13: warning: mutation createUser does not start with add, update, delete or save [mutation-prefix]",
+		"schema.graphqls:14: warning: argument Mutation.createUser(name) has no description [missing-description]",
+		"schema.graphqls:20: warning: field User.first_name is not camelCase [field-camel-case]",
+		"schema.graphqls:22: warning: field User.nick is deprecated without a reason [deprecation-reason]",
+		"schema.graphqls:31: warning: enum value Role.readOnly is not SCREAMING_CASE [enum-value-case]",
+	}
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected issues:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(lines, "\n"))
+	}
+	if Count(issues, Error) != 1 || Count(issues, Warn) != 8 {
+		t.Errorf("Expected 1 error and 8 warnings, got %d and %d", Count(issues, Error), Count(issues, Warn))
+	}
+}
+
+func TestConfiguredSeverities(t *testing.T) {
+	l, err := New(map[string]string{
+		MissingDescription: "off",
+		ParamNames:         "warn",
+		MutationPrefix:     "ERROR",
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	issues := l.Run(buildSchema(t, testSchema))
+
+	for _, i := range issues {
+		if i.Rule == MissingDescription {
+			t.Errorf("Disabled rule reported: %s", i)
+		}
+		if want := l.Severity(i.Rule); i.Severity != want {
+			t.Errorf("Expected severity %s for %s, got %s", want, i.Rule, i.Severity)
+		}
+	}
+	if Count(issues, Error) != 1 || issues[2].Rule != MutationPrefix {
+		t.Errorf("Expected the mutation prefix issue to be the only error, got: %v", issues)
+	}
+
+	for _, levels := range []map[string]string{
+		{"no-such-rule": "warn"},
+		{EnumValueCase: "fatal"},
+	} {
+		if _, err := New(levels); err == nil {
+			t.Errorf("Expected an error for %v", levels)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	rule := NewRule("no-foo-types", "Types are not called Foo", Error, func(schema *ast.Schema, report Reporter) {
+		if def := schema.Types["Foo"]; def != nil {
+			report(def.Position, def.Name, "type Foo is not allowed")
+		}
+	})
+	Register(rule)
+	defer func() { registry = registry[:len(registry)-1] }()
+
+	l, err := New(map[string]string{MissingDescription: "off"})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+	issues := l.Run(buildSchema(t, `"A foo" type Foo { "Id" id: ID }`))
+	if len(issues) != 1 || issues[0].String() != "schema.graphqls:1: error: type Foo is not allowed [no-foo-types]" {
+		t.Errorf("Expected the plugged-in rule to report, got: %v", issues)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Registering a duplicate rule name should panic")
+		}
+	}()
+	Register(rule)
+}
+
+func TestParseSeverity(t *testing.T) {
+	for input, expected := range map[string]Severity{"off": Off, "Warn": Warn, "warning": Warn, " error ": Error} {
+		if got, err := ParseSeverity(input); err != nil || got != expected {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", input, got, err, expected)
+		}
+	}
+	if _, err := ParseSeverity("info"); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
@@ -0,0 +1,188 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/coverage"
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// Names of the built-in rules
+const (
+	MissingDescription = "missing-description"
+	FieldCamelCase     = "field-camel-case"
+	EnumValueCase      = "enum-value-case"
+	MutationPrefix     = "mutation-prefix"
+	ParamNames         = "param-names"
+	DeprecationReason  = "deprecation-reason"
+)
+
+var (
+	camelCase     = regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`)
+	screamingCase = regexp.MustCompile(`^[A-Z][A-Z0-9]*(_[A-Z0-9]+)*$`)
+)
+
+func init() {
+	Register(NewRule(MissingDescription,
+		"Types, fields, arguments, input fields and enum values have a description",
+		Warn, checkMissingDescriptions))
+	Register(NewRule(FieldCamelCase,
+		"Field, input field and argument names are camelCase",
+		Warn, checkFieldCamelCase))
+	Register(NewRule(EnumValueCase,
+		"Enum values are SCREAMING_CASE",
+		Warn, checkEnumValueCase))
+	Register(NewRule(MutationPrefix,
+		"Mutation names start with "+prefixList()+", the verbs the catalogue groups them by",
+		Warn, checkMutationPrefix))
+	Register(NewRule(ParamNames,
+		"Every @param in a field description names an argument of the field",
+		Error, checkParamNames))
+	Register(NewRule(DeprecationReason,
+		"Every @deprecated directive gives a reason",
+		Warn, checkDeprecationReason))
+}
+
+// checkMissingDescriptions reports the elements the coverage report counts
+// as undocumented
+func checkMissingDescriptions(schema *ast.Schema, report Reporter) {
+	for _, e := range coverage.Analyse(schema).Undocumented() {
+		report(e.Position, e.Path, fmt.Sprintf("%s %s has no description", e.Kind, e.Path))
+	}
+}
+
+func checkFieldCamelCase(schema *ast.Schema, report Reporter) {
+	for _, def := range userTypes(schema) {
+		kind := "field"
+		if def.Kind == ast.InputObject {
+			kind = "input field"
+		}
+		for _, f := range def.Fields {
+			path := def.Name + "." + f.Name
+			if !strings.HasPrefix(f.Name, "__") && !camelCase.MatchString(f.Name) {
+				report(f.Position, path, fmt.Sprintf("%s %s is not camelCase", kind, path))
+			}
+			for _, arg := range f.Arguments {
+				if !camelCase.MatchString(arg.Name) {
+					argPath := path + "(" + arg.Name + ")"
+					report(arg.Position, argPath, fmt.Sprintf("argument %s is not camelCase", argPath))
+				}
+			}
+		}
+	}
+}
+
+func checkEnumValueCase(schema *ast.Schema, report Reporter) {
+	for _, def := range userTypes(schema) {
+		for _, v := range def.EnumValues {
+			if !screamingCase.MatchString(v.Name) {
+				path := def.Name + "." + v.Name
+				report(v.Position, path, fmt.Sprintf("enum value %s is not SCREAMING_CASE", path))
+			}
+		}
+	}
+}
+
+// checkMutationPrefix reports mutations that the catalogue would list under
+// "General" because they start with none of the grouping verbs
+func checkMutationPrefix(schema *ast.Schema, report Reporter) {
+	if schema.Mutation == nil {
+		return
+	}
+	for _, f := range schema.Mutation.Fields {
+		lower := strings.ToLower(f.Name)
+		matched := false
+		for _, prefix := range parser.MutationPrefixes {
+			if strings.HasPrefix(lower, prefix) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			path := schema.Mutation.Name + "." + f.Name
+			report(f.Position, path, fmt.Sprintf("mutation %s does not start with %s", f.Name, prefixList()))
+		}
+	}
+}
+
+// checkParamNames reports @param annotations naming no argument, usually a
+// typo or an argument that was renamed or removed
+func checkParamNames(schema *ast.Schema, report Reporter) {
+	descriptions := parser.NewDescriptionParser()
+	for _, def := range userTypes(schema) {
+		for _, f := range def.Fields {
+			parsed := descriptions.ParseDescription(f.Description)
+			if parsed.Structured == nil {
+				continue
+			}
+			for _, p := range parsed.Structured.Parameters {
+				name := strings.TrimPrefix(strings.TrimSuffix(p.Name, ":"), "$")
+				if strings.HasPrefix(name, "{") || f.Arguments.ForName(name) != nil {
+					continue // a JSDoc type such as {Int} hides the name
+				}
+				path := def.Name + "." + f.Name
+				report(f.Position, path, fmt.Sprintf("@param %s of %s does not match an argument", name, path))
+			}
+		}
+	}
+}
+
+func checkDeprecationReason(schema *ast.Schema, report Reporter) {
+	check := func(pos *ast.Position, kind, path string, directives ast.DirectiveList) {
+		d := directives.ForName("deprecated")
+		if d == nil {
+			return
+		}
+		if reason := d.Arguments.ForName("reason"); reason == nil || strings.TrimSpace(reason.Value.Raw) == "" {
+			report(pos, path, fmt.Sprintf("%s %s is deprecated without a reason", kind, path))
+		}
+	}
+
+	for _, def := range userTypes(schema) {
+		for _, f := range def.Fields {
+			path := def.Name + "." + f.Name
+			if def.Kind == ast.InputObject {
+				check(f.Position, "input field", path, f.Directives)
+			} else {
+				check(f.Position, "field", path, f.Directives)
+			}
+			for _, arg := range f.Arguments {
+				check(arg.Position, "argument", path+"("+arg.Name+")", arg.Directives)
+			}
+		}
+		for _, v := range def.EnumValues {
+			check(v.Position, "enum value", def.Name+"."+v.Name, v.Directives)
+		}
+	}
+}
+
+// builtinScalars are defined by the GraphQL specification, not the schema
+var builtinScalars = map[string]bool{
+	"String": true, "Int": true, "Float": true, "Boolean": true, "ID": true,
+}
+
+// userTypes returns the types defined by the schema, in name order
+func userTypes(schema *ast.Schema) []*ast.Definition {
+	var defs []*ast.Definition
+	for name, def := range schema.Types {
+		if !strings.HasPrefix(name, "__") && !builtinScalars[name] {
+			defs = append(defs, def)
+		}
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+// prefixList returns the mutation prefixes as "add, update, delete or save"
+func prefixList() string {
+	prefixes := parser.MutationPrefixes
+	if len(prefixes) == 1 {
+		return prefixes[0]
+	}
+	return strings.Join(prefixes[:len(prefixes)-1], ", ") + " or " + prefixes[len(prefixes)-1]
+}
@@ -36,18 +36,22 @@ func New(cfg *config.Config) *Metrics {
 	}
 }
 
-// StartSection begins timing for a processing section
+// StartSection begins timing for a processing section. A section started
+// again, as for each --group-by group, adds to its earlier count and time.
 func (m *Metrics) StartSection(name string) *SectionTimer {
 	if !m.enabled {
 		return &SectionTimer{enabled: false}
 	}
 
-	section := &SectionMetrics{
-		Name:      name,
-		Count:     0,
-		Processed: false,
+	section, ok := m.sections[name]
+	if !ok {
+		section = &SectionMetrics{
+			Name:      name,
+			Count:     0,
+			Processed: false,
+		}
+		m.sections[name] = section
 	}
-	m.sections[name] = section
 
 	return &SectionTimer{
 		metrics:   m,
@@ -78,7 +82,7 @@ func (st *SectionTimer) Finish() {
 	if !st.enabled {
 		return
 	}
-	st.section.Duration = time.Since(st.startTime)
+	st.section.Duration += time.Since(st.startTime)
 	st.section.Processed = true
 }
 
@@ -96,12 +100,39 @@ func (m *Metrics) LogInputParameters() {
 	t.AppendHeader(table.Row{"Parameter", "Value"})
 
 	// Input parameters
-	t.AppendRow(table.Row{"Schema File", m.config.SchemaFile})
+	t.AppendRow(table.Row{"Schema File", m.config.SchemaSource()})
 	if m.config.OutputFile != "" {
 		t.AppendRow(table.Row{"Output File", m.config.OutputFile})
+	} else if m.config.SplitDir != "" {
+		t.AppendRow(table.Row{"Split Dir", m.config.SplitDir})
 	} else {
 		t.AppendRow(table.Row{"Output", "stdout"})
 	}
+	if m.config.Format != "" {
+		t.AppendRow(table.Row{"Format", m.config.Format})
+	}
+	if m.config.SignatureStyle != "" {
+		t.AppendRow(table.Row{"Signature Style", m.config.SignatureStyle})
+	}
+	if m.config.GroupBy != "" {
+		t.AppendRow(table.Row{"Group By", m.config.GroupBy})
+	}
+	if m.config.Diagram != "" {
+		diagram := m.config.Diagram
+		if m.config.DiagramRoot != "" {
+			diagram += " from " + m.config.DiagramRoot
+		}
+		t.AppendRow(table.Row{"Diagram", diagram})
+	}
+	if m.config.Strict {
+		t.AppendRow(table.Row{"Strict Validation", "enabled"})
+	}
+	if m.config.Examples {
+		t.AppendRow(table.Row{"Example Depth", m.config.ExampleDepth})
+	}
+	if m.config.ConfigFile != "" {
+		t.AppendRow(table.Row{"Config File", m.config.ConfigFile})
+	}
 	t.AppendRow(table.Row{"Exclude Internal", m.config.ExcludeInternal})
 
 	// Add separator before sections
@@ -112,6 +143,8 @@ func (m *Metrics) LogInputParameters() {
 	t.AppendRow(table.Row{"Mutations", formatEnabled(m.config.IncludeMutations)})
 	t.AppendRow(table.Row{"Subscriptions", formatEnabled(m.config.IncludeSubscriptions)})
 	t.AppendRow(table.Row{"Types", formatEnabled(m.config.IncludeTypes)})
+	t.AppendRow(table.Row{"Interfaces", formatEnabled(m.config.IncludeInterfaces)})
+	t.AppendRow(table.Row{"Unions", formatEnabled(m.config.IncludeUnions)})
 	t.AppendRow(table.Row{"Enums", formatEnabled(m.config.IncludeEnums)})
 	t.AppendRow(table.Row{"Inputs", formatEnabled(m.config.IncludeInputs)})
 	t.AppendRow(table.Row{"Directives", formatEnabled(m.config.IncludeDirectives)})
@@ -138,7 +171,7 @@ func (m *Metrics) LogMetricsTable() {
 	// Define the order of sections for consistent display
 	sectionOrder := []string{
 		"Queries", "Mutations", "Subscriptions",
-		"Types", "Enums", "Inputs", "Directives", "Scalars",
+		"Types", "Interfaces", "Unions", "Enums", "Inputs", "Directives", "Scalars",
 	}
 
 	var totalProcessed int
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// defaultArchivePattern selects the schema files of an archive when no
+// pattern is given
+const defaultArchivePattern = "**/*.{graphql,graphqls,gql}"
+
+// stdin is read for the standard input argument; tests replace it
+var stdin io.Reader = os.Stdin
+
+var (
+	zipMagic  = []byte("PK\x03\x04")
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// isArchiveContent reports whether data starts like a zip or gzip file. An
+// SDL schema never does, so content read from stdin can be told apart.
+func isArchiveContent(data []byte) bool {
+	return bytes.HasPrefix(data, zipMagic) || bytes.HasPrefix(data, gzipMagic)
+}
+
+// readArchive returns the files of a zip or gzip-compressed tar archive whose
+// paths match pattern, sorted by path. Each source is named after the
+// archive and the path inside it, e.g. "export.zip/users/user.graphqls".
+// An empty pattern selects every .graphql, .graphqls and .gql file.
+func readArchive(name string, data []byte, pattern string) ([]*ast.Source, error) {
+	if pattern == "" {
+		pattern = defaultArchivePattern
+	}
+	if _, err := path.Match(strings.ReplaceAll(pattern, "**", "*"), ""); err != nil {
+		return nil, fmt.Errorf("invalid pattern '%s': %v", pattern, err)
+	}
+
+	var sources []*ast.Source
+	add := func(member string, r io.Reader) error {
+		member = strings.TrimPrefix(path.Clean("/"+member), "/")
+		if !matchArchivePath(member, pattern) {
+			return nil
+		}
+		content, err := io.ReadAll(r)
+		if err != nil {
+			return fmt.Errorf("failed to read '%s' from archive '%s': %v", member, name, err)
+		}
+		sources = append(sources, &ast.Source{Name: name + "/" + member, Input: string(content)})
+		return nil
+	}
+
+	var err error
+	if bytes.HasPrefix(data, zipMagic) {
+		err = readZip(data, add)
+	} else {
+		err = readTarGz(data, add)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive '%s': %v", name, err)
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no GraphQL schema files found in archive '%s' matching pattern '%s'", name, pattern)
+	}
+
+	sort.Slice(sources, func(i, j int) bool { return sources[i].Name < sources[j].Name })
+	return sources, nil
+}
+
+func readZip(data []byte, add func(string, io.Reader) error) error {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return err
+		}
+		err = add(f.Name, rc)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readTarGz(data []byte, add func(string, io.Reader) error) error {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := add(header.Name, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// matchArchivePath matches a slash-separated path inside an archive against
+// a pattern in the syntax of FindSchemaFiles: path.Match wildcards in each
+// segment, "**" for any number of directories and {a,b} alternatives.
+func matchArchivePath(name, pattern string) bool {
+	for _, expanded := range expandBraces(pattern) {
+		if matchSegments(strings.Split(name, "/"), strings.Split(strings.TrimPrefix(expanded, "./"), "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(name, pattern []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(name[i:], pattern[1:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	matched, err := path.Match(pattern[0], name[0])
+	return err == nil && matched && matchSegments(name[1:], pattern[1:])
+}
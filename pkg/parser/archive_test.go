@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+var archiveFiles = map[string]string{
+	"export/users/user.graphqls":   "type Query { user: User }\ntype User { id: ID! }",
+	"export/users/extra.graphqls":  "extend type User { name: String }",
+	"export/orders/order.graphqls": "type Order { id: ID! }",
+	"export/README.md":             "# Registry export",
+}
+
+func zipArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range archiveFiles {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func tarGzArchive(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "export/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range archiveFiles {
+		header := &tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}
+		if err := tw.WriteHeader(header); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestLoadSchemaArchive(t *testing.T) {
+	dir := t.TempDir()
+	archives := map[string][]byte{"export.zip": zipArchive(t), "export.tar.gz": tarGzArchive(t)}
+
+	for name, data := range archives {
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(dir, name)
+			if err := os.WriteFile(file, data, 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			loaded, err := LoadSchema(file, "")
+			if err != nil {
+				t.Fatalf("LoadSchema() returned error: %v", err)
+			}
+			expected := []string{
+				file + "/export/orders/order.graphqls",
+				file + "/export/users/extra.graphqls",
+				file + "/export/users/user.graphqls",
+			}
+			if strings.Join(loaded.Files, ",") != strings.Join(expected, ",") {
+				t.Errorf("Expected files %v, got %v", expected, loaded.Files)
+			}
+			if loaded.Schema.Types["Order"] == nil || loaded.Schema.Types["User"].Fields.ForName("name") == nil {
+				t.Error("Expected every schema file in the archive to be combined")
+			}
+
+			loaded, err = LoadSchema(file, "export/users/*.graphqls")
+			if err != nil {
+				t.Fatalf("LoadSchema() with pattern returned error: %v", err)
+			}
+			if len(loaded.Files) != 2 || loaded.Schema.Types["Order"] != nil {
+				t.Errorf("Expected the pattern to select the users files, got %v", loaded.Files)
+			}
+			if len(loaded.Sources) != 2 || loaded.Sources[1].Name != file+"/export/users/user.graphqls" {
+				t.Errorf("Expected sources named after the archive members, got %v", loaded.Sources)
+			}
+
+			if _, err := LoadSchema(file, "**/*.gql"); err == nil {
+				t.Error("Expected an error when no file in the archive matches")
+			}
+		})
+	}
+}
+
+func TestLoadSchemaStdin(t *testing.T) {
+	defer func(r io.Reader) { stdin = r }(stdin)
+
+	stdin = strings.NewReader("type Query { ping: String }")
+	loaded, err := LoadSchema(schemafile.Stdin, "")
+	if err != nil {
+		t.Fatalf("LoadSchema() returned error: %v", err)
+	}
+	if loaded.Schema.Query == nil || len(loaded.Files) != 1 || loaded.Files[0] != schemafile.StdinName {
+		t.Errorf("Unexpected stdin result: %+v", loaded)
+	}
+	if loaded.Sources[0].Name != schemafile.StdinName {
+		t.Errorf("Expected the source to be named %s, got %s", schemafile.StdinName, loaded.Sources[0].Name)
+	}
+
+	stdin = bytes.NewReader(tarGzArchive(t))
+	loaded, err = LoadSchema(schemafile.Stdin, "**/orders/*.graphqls")
+	if err != nil {
+		t.Fatalf("LoadSchema() for a piped archive returned error: %v", err)
+	}
+	if len(loaded.Files) != 1 || loaded.Files[0] != schemafile.StdinName+"/export/orders/order.graphqls" {
+		t.Errorf("Unexpected piped archive files: %v", loaded.Files)
+	}
+
+	stdin = strings.NewReader("type Query { ping: String }")
+	if _, err := LoadSchema(schemafile.Stdin, "*.graphqls"); err == nil {
+		t.Error("Expected an error for a pattern with plain SDL on stdin")
+	}
+}
+
+func TestMatchArchivePath(t *testing.T) {
+	testCases := []struct {
+		name, pattern string
+		expected      bool
+	}{
+		{"schema.graphqls", "*.graphqls", true},
+		{"users/schema.graphqls", "*.graphqls", false},
+		{"users/schema.graphqls", "**/*.graphqls", true},
+		{"schema.graphqls", "**/*.graphqls", true},
+		{"a/b/c/schema.gql", "a/**/*.{graphql,gql}", true},
+		{"a/b/c/schema.gql", "./a/**/c/*.gql", true},
+		{"a/b/schema.graphqls", "b/**/*.graphqls", false},
+		{"README.md", defaultArchivePattern, false},
+	}
+	for _, tc := range testCases {
+		if got := matchArchivePath(tc.name, tc.pattern); got != tc.expected {
+			t.Errorf("matchArchivePath(%q, %q) = %v, want %v", tc.name, tc.pattern, got, tc.expected)
+		}
+	}
+}
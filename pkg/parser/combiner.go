@@ -3,93 +3,111 @@ package parser
 import (
 	"fmt"
 	"os"
-	"regexp"
 	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
 )
 
-// CombineSchemaFiles reads and combines multiple GraphQL schema files into a single schema string
+// CombineSchemaFiles reads and combines multiple GraphQL schema files into a
+// single schema string, after checking that they parse and define no type or
+// directive twice
 func CombineSchemaFiles(files []string) (string, error) {
 	if len(files) == 0 {
 		return "", fmt.Errorf("no files provided to combine")
 	}
 
-	var combined strings.Builder
-	var allContent []string
-
-	// Track definitions to detect conflicts
-	definedTypes := make(map[string]string) // type name -> source file
-
-	// Read all files
+	sources := make([]*ast.Source, 0, len(files))
 	for _, file := range files {
 		content, err := os.ReadFile(file)
 		if err != nil {
 			return "", fmt.Errorf("failed to read schema file '%s': %v", file, err)
 		}
-
-		contentStr := string(content)
-
-		// Check for duplicate type definitions
-		if err := checkForConflicts(contentStr, file, definedTypes); err != nil {
-			return "", err
-		}
-
-		allContent = append(allContent, contentStr)
+		sources = append(sources, &ast.Source{Name: file, Input: RemoveFragments(string(content))})
+	}
+	if _, err := ParseSources(sources); err != nil {
+		return "", err
 	}
 
-	// Combine all content with appropriate separators
-	for i, content := range allContent {
+	var combined strings.Builder
+	for i, source := range sources {
 		if i > 0 {
 			combined.WriteString("\n\n") // Add separator between files
 		}
 
 		// Add a comment to indicate source file for debugging
-		if len(files) > 1 {
-			fmt.Fprintf(&combined, "# Source: %s\n", files[i])
+		if len(sources) > 1 {
+			fmt.Fprintf(&combined, "# Source: %s\n", source.Name)
 		}
 
-		combined.WriteString(strings.TrimSpace(content))
+		combined.WriteString(strings.TrimSpace(source.Input))
 	}
 
 	return combined.String(), nil
 }
 
-// checkForConflicts detects duplicate type definitions across files
-func checkForConflicts(content, filename string, definedTypes map[string]string) error {
-	// Regular expressions to match GraphQL type definitions
-	typePatterns := []*regexp.Regexp{
-		regexp.MustCompile(`(?m)^\s*type\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*input\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*enum\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*scalar\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*interface\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*union\s+(\w+)`),
-		regexp.MustCompile(`(?m)^\s*directive\s+@(\w+)`),
+// ParseSources parses each schema source on its own and merges the
+// documents, so every definition keeps the position in the file it came
+// from. Types and directives defined more than once are reported with the
+// file and line of every definition.
+func ParseSources(sources []*ast.Source) (*ast.SchemaDocument, error) {
+	merged := &ast.SchemaDocument{}
+	for _, source := range sources {
+		doc, err := gqlparser.ParseSchema(source)
+		if err != nil {
+			return nil, err
+		}
+		merged.Merge(doc)
 	}
 
-	for _, pattern := range typePatterns {
-		matches := pattern.FindAllStringSubmatch(content, -1)
-		for _, match := range matches {
-			if len(match) < 2 { //nolint:mnd // regex group count
-				continue
-			}
-
-			typeName := match[1]
+	if conflicts := findConflicts(merged); len(conflicts) > 0 {
+		return nil, fmt.Errorf("%s", strings.Join(conflicts, "\n"))
+	}
+	return merged, nil
+}
 
-			// Skip built-in GraphQL types
-			if isBuiltInType(typeName) {
-				continue
-			}
+// findConflicts describes every type and directive of doc that is defined
+// more than once. Redefined built-in scalars are allowed; type extensions
+// are merged by BuildSchema and never conflict.
+func findConflicts(doc *ast.SchemaDocument) []string {
+	var conflicts []string
 
-			if existingFile, exists := definedTypes[typeName]; exists {
-				return fmt.Errorf("duplicate definition of '%s' found in '%s' (previously defined in '%s')",
-					typeName, filename, existingFile)
-			}
+	definedTypes := make(map[string]*ast.Position)
+	for _, def := range doc.Definitions {
+		if isBuiltInType(def.Name) {
+			continue
+		}
+		if previous, exists := definedTypes[def.Name]; exists {
+			conflicts = append(conflicts, fmt.Sprintf("duplicate definition of '%s' at %s (previously defined at %s)",
+				def.Name, FormatPosition(def.Position), FormatPosition(previous)))
+			continue
+		}
+		definedTypes[def.Name] = def.Position
+	}
 
-			definedTypes[typeName] = filename
+	definedDirectives := make(map[string]*ast.Position)
+	for _, def := range doc.Directives {
+		if previous, exists := definedDirectives[def.Name]; exists {
+			conflicts = append(conflicts, fmt.Sprintf("duplicate definition of '@%s' at %s (previously defined at %s)",
+				def.Name, FormatPosition(def.Position), FormatPosition(previous)))
+			continue
 		}
+		definedDirectives[def.Name] = def.Position
 	}
 
-	return nil
+	return conflicts
+}
+
+// FormatPosition formats a position as file:line, or just the line when the
+// source has no name
+func FormatPosition(pos *ast.Position) string {
+	if pos == nil {
+		return "unknown position"
+	}
+	if pos.Src == nil || pos.Src.Name == "" {
+		return fmt.Sprintf("line %d", pos.Line)
+	}
+	return fmt.Sprintf("%s:%d", pos.Src.Name, pos.Line)
 }
 
 // isBuiltInType checks if a type name is a built-in GraphQL type
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
 )
 
 const userTypeSDL = `type User {
@@ -113,35 +115,76 @@ func TestCombineSchemaFilesConflicts(t *testing.T) {
 	}
 }
 
-func TestCheckForConflicts(t *testing.T) {
+func TestParseSources(t *testing.T) {
+	sources := []*ast.Source{
+		{Name: "users.graphqls", Input: `"""
+A user.
+type Account is described here, not defined
+"""
+type User { id: ID! }
+scalar String`},
+		{Name: "orders.graphqls", Input: "type Order { id: ID! }\nextend type User { orders: [Order] }\nscalar String"},
+	}
+
+	doc, err := ParseSources(sources)
+	if err != nil {
+		t.Fatalf("ParseSources() returned error: %v", err)
+	}
+	for _, def := range doc.Definitions {
+		if def.Name == "Account" {
+			t.Error("A type named in a description should not be defined")
+		}
+	}
+	if len(doc.Definitions) != 4 || len(doc.Extensions) != 1 {
+		t.Fatalf("Expected 4 definitions and 1 extension, got %d and %d", len(doc.Definitions), len(doc.Extensions))
+	}
+	if got := FormatPosition(doc.Definitions[0].Position); got != "users.graphqls:5" {
+		t.Errorf("Expected User at users.graphqls:5, got %s", got)
+	}
+	if got := FormatPosition(doc.Extensions[0].Fields[0].Position); got != "orders.graphqls:2" {
+		t.Errorf("Expected the extension field at orders.graphqls:2, got %s", got)
+	}
+}
+
+func TestParseSourcesConflicts(t *testing.T) {
 	testCases := []struct {
 		name     string
-		content  string
-		filename string
-		setup    func(map[string]string)
-		wantErr  bool
+		sources  []*ast.Source
+		expected string
 	}{
-		{"new type definition", "type User { id: ID! }", "user.graphql", nil, false},
-		{"duplicate type definition", "type User { email: String }", "user2.graphql",
-			func(dt map[string]string) { dt["User"] = "user.graphql" }, true},
-		{"input type", "input UserInput { name: String }", "input.graphql", nil, false},
-		{"enum type", "enum Status { ACTIVE INACTIVE }", "enum.graphql", nil, false},
-		{"scalar type", "scalar DateTime", "scalar.graphql", nil, false},
-		{"directive definition", "directive @auth on FIELD_DEFINITION", "directive.graphql", nil, false},
-		{"built-in type (allowed)", "scalar String", "builtin.graphql", nil, false},
+		{
+			name: "type in two files",
+			sources: []*ast.Source{
+				{Name: "user1.graphqls", Input: userTypeSDL},
+				{Name: "user2.graphqls", Input: "\n\n" + userTypeSDL},
+			},
+			expected: "duplicate definition of 'User' at user2.graphqls:3 (previously defined at user1.graphqls:1)",
+		},
+		{
+			name:     "type in one file",
+			sources:  []*ast.Source{{Name: "schema.graphqls", Input: "enum Status { ON }\ninput Status { on: Boolean }"}},
+			expected: "duplicate definition of 'Status' at schema.graphqls:2 (previously defined at schema.graphqls:1)",
+		},
+		{
+			name: "directive",
+			sources: []*ast.Source{
+				{Name: "a.graphqls", Input: "directive @auth on FIELD_DEFINITION"},
+				{Name: "b.graphqls", Input: "directive @auth on OBJECT"},
+			},
+			expected: "duplicate definition of '@auth' at b.graphqls:1 (previously defined at a.graphqls:1)",
+		},
+		{
+			name:     "syntax error",
+			sources:  []*ast.Source{{Name: "a.graphqls", Input: "type Query {"}, {Name: "b.graphqls", Input: "type {"}},
+			expected: "a.graphqls:1:13: Expected Name, found <EOF>",
+		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			definedTypes := make(map[string]string)
-			if tc.setup != nil {
-				tc.setup(definedTypes)
-			}
-
-			err := checkForConflicts(tc.content, tc.filename, definedTypes)
-
-			if (err != nil) != tc.wantErr {
-				t.Errorf("checkForConflicts() error = %v, wantErr %v", err, tc.wantErr)
+			_, err := ParseSources(tc.sources)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("Expected error %q, got: %v", tc.expected, err)
 			}
 		})
 	}
@@ -458,7 +458,8 @@ func (dp *DescriptionParser) calculateMetrics(structure *DescriptionStructure, r
 		}
 		metrics.WordCount = len(strings.Fields(allText))
 	} else if rawDescription != "" {
-		// Calculate from raw description
+		// Calculate from raw description, which is all overview
+		metrics.HasOverview = true
 		metrics.WordCount = len(strings.Fields(rawDescription))
 	}
 
@@ -382,6 +382,12 @@ func TestCalculateMetrics(t *testing.T) {
 			}
 		})
 	}
+
+	// An unstructured description counts as an overview
+	metrics := parser.calculateMetrics(nil, "The user's display name")
+	if !metrics.HasOverview || metrics.WordCount != 4 || metrics.Completeness != 0.3 {
+		t.Errorf("Unexpected metrics for an unstructured description: %+v", metrics)
+	}
 }
 
 func TestExtractParameterType(t *testing.T) {
@@ -0,0 +1,364 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	gqlparser "github.com/vektah/gqlparser/v2/parser"
+)
+
+// introspectionResult accepts both a bare {"__schema": ...} object and a
+// full response wrapped in {"data": ...}
+type introspectionResult struct {
+	Data *struct {
+		Schema *introspectionSchema `json:"__schema"`
+	} `json:"data"`
+	Schema *introspectionSchema `json:"__schema"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+type introspectionSchema struct {
+	QueryType        *introspectionName       `json:"queryType"`
+	MutationType     *introspectionName       `json:"mutationType"`
+	SubscriptionType *introspectionName       `json:"subscriptionType"`
+	Types            []introspectionType      `json:"types"`
+	Directives       []introspectionDirective `json:"directives"`
+}
+
+type introspectionName struct {
+	Name string `json:"name"`
+}
+
+type introspectionType struct {
+	Kind          string                    `json:"kind"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description"`
+	Fields        []introspectionField      `json:"fields"`
+	InputFields   []introspectionInputValue `json:"inputFields"`
+	Interfaces    []introspectionTypeRef    `json:"interfaces"`
+	EnumValues    []introspectionEnumValue  `json:"enumValues"`
+	PossibleTypes []introspectionTypeRef    `json:"possibleTypes"`
+}
+
+type introspectionField struct {
+	Name              string                    `json:"name"`
+	Description       string                    `json:"description"`
+	Args              []introspectionInputValue `json:"args"`
+	Type              introspectionTypeRef      `json:"type"`
+	IsDeprecated      bool                      `json:"isDeprecated"`
+	DeprecationReason *string                   `json:"deprecationReason"`
+}
+
+type introspectionInputValue struct {
+	Name              string               `json:"name"`
+	Description       string               `json:"description"`
+	Type              introspectionTypeRef `json:"type"`
+	DefaultValue      *string              `json:"defaultValue"`
+	IsDeprecated      bool                 `json:"isDeprecated"`
+	DeprecationReason *string              `json:"deprecationReason"`
+}
+
+type introspectionEnumValue struct {
+	Name              string  `json:"name"`
+	Description       string  `json:"description"`
+	IsDeprecated      bool    `json:"isDeprecated"`
+	DeprecationReason *string `json:"deprecationReason"`
+}
+
+type introspectionTypeRef struct {
+	Kind   string                `json:"kind"`
+	Name   string                `json:"name"`
+	OfType *introspectionTypeRef `json:"ofType"`
+}
+
+type introspectionDirective struct {
+	Name         string                    `json:"name"`
+	Description  string                    `json:"description"`
+	Locations    []string                  `json:"locations"`
+	Args         []introspectionInputValue `json:"args"`
+	IsRepeatable bool                      `json:"isRepeatable"`
+}
+
+// builtInDirectives are defined by the GraphQL specification and appear in
+// every introspection result, but are never part of an SDL schema file
+var builtInDirectives = map[string]bool{
+	"skip": true, "include": true, "deprecated": true, "specifiedBy": true, "oneOf": true,
+}
+
+// LoadIntrospection reads an introspection result saved as JSON and converts
+// it to a schema, see ParseIntrospection
+func LoadIntrospection(file string) (*LoadedSchema, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read introspection file %s: %v", file, err)
+	}
+	schema, err := ParseIntrospection(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", file, err)
+	}
+	return &LoadedSchema{Schema: schema, Files: []string{file}}, nil
+}
+
+// ParseIntrospection converts the JSON result of an introspection query into
+// the same *ast.Schema shape that BuildSchema produces for SDL: only the
+// schema's own types and directives are included (no introspection types,
+// built-in scalars or built-in directives), deprecations become @deprecated
+// directives and default values are parsed into ast.Value literals.
+func ParseIntrospection(data []byte) (*ast.Schema, error) {
+	var result introspectionResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("invalid introspection JSON: %v", err)
+	}
+
+	raw := result.Schema
+	if raw == nil && result.Data != nil {
+		raw = result.Data.Schema
+	}
+	if raw == nil {
+		if len(result.Errors) > 0 {
+			return nil, fmt.Errorf("introspection result contains errors: %s", result.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("no __schema object found in introspection JSON")
+	}
+
+	schema := &ast.Schema{
+		Types:      make(map[string]*ast.Definition),
+		Directives: make(map[string]*ast.DirectiveDefinition),
+	}
+
+	for _, t := range raw.Types {
+		if strings.HasPrefix(t.Name, "__") || (t.Kind == "SCALAR" && IsBuiltInGraphQLType(t.Name)) {
+			continue
+		}
+		def, err := convertIntrospectionType(t)
+		if err != nil {
+			return nil, fmt.Errorf("type %s: %v", t.Name, err)
+		}
+		schema.Types[def.Name] = def
+	}
+
+	for _, d := range raw.Directives {
+		if builtInDirectives[d.Name] {
+			continue
+		}
+		def, err := convertIntrospectionDirective(d)
+		if err != nil {
+			return nil, fmt.Errorf("directive @%s: %v", d.Name, err)
+		}
+		schema.Directives[def.Name] = def
+	}
+
+	schema.Query = rootType(schema, raw.QueryType)
+	schema.Mutation = rootType(schema, raw.MutationType)
+	schema.Subscription = rootType(schema, raw.SubscriptionType)
+
+	return schema, nil
+}
+
+// rootType returns the definition of a root operation type, or nil if the
+// schema has none
+func rootType(schema *ast.Schema, name *introspectionName) *ast.Definition {
+	if name == nil {
+		return nil
+	}
+	return schema.Types[name.Name]
+}
+
+func convertIntrospectionType(t introspectionType) (*ast.Definition, error) {
+	def := &ast.Definition{
+		Name:        t.Name,
+		Description: t.Description,
+	}
+
+	switch t.Kind {
+	case "OBJECT":
+		def.Kind = ast.Object
+	case "INTERFACE":
+		def.Kind = ast.Interface
+	case "UNION":
+		def.Kind = ast.Union
+	case "ENUM":
+		def.Kind = ast.Enum
+	case "INPUT_OBJECT":
+		def.Kind = ast.InputObject
+	case "SCALAR":
+		def.Kind = ast.Scalar
+	default:
+		return nil, fmt.Errorf("unknown kind %q", t.Kind)
+	}
+
+	for _, f := range t.Fields {
+		field, err := convertIntrospectionField(f)
+		if err != nil {
+			return nil, err
+		}
+		def.Fields = append(def.Fields, field)
+	}
+	for _, f := range t.InputFields {
+		field, err := convertIntrospectionInputField(f)
+		if err != nil {
+			return nil, err
+		}
+		def.Fields = append(def.Fields, field)
+	}
+	for _, i := range t.Interfaces {
+		def.Interfaces = append(def.Interfaces, i.Name)
+	}
+	for _, p := range t.PossibleTypes {
+		if def.Kind == ast.Union {
+			def.Types = append(def.Types, p.Name)
+		}
+	}
+	for _, v := range t.EnumValues {
+		def.EnumValues = append(def.EnumValues, &ast.EnumValueDefinition{
+			Name:        v.Name,
+			Description: v.Description,
+			Directives:  deprecation(v.IsDeprecated, v.DeprecationReason),
+		})
+	}
+
+	return def, nil
+}
+
+func convertIntrospectionField(f introspectionField) (*ast.FieldDefinition, error) {
+	fieldType, err := convertTypeRef(f.Type)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %v", f.Name, err)
+	}
+	args, err := convertArguments(f.Args)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %v", f.Name, err)
+	}
+	return &ast.FieldDefinition{
+		Name:        f.Name,
+		Description: f.Description,
+		Arguments:   args,
+		Type:        fieldType,
+		Directives:  deprecation(f.IsDeprecated, f.DeprecationReason),
+	}, nil
+}
+
+func convertIntrospectionInputField(f introspectionInputValue) (*ast.FieldDefinition, error) {
+	arg, err := convertInputValue(f)
+	if err != nil {
+		return nil, fmt.Errorf("input field %s: %v", f.Name, err)
+	}
+	return &ast.FieldDefinition{
+		Name:         arg.Name,
+		Description:  arg.Description,
+		Type:         arg.Type,
+		DefaultValue: arg.DefaultValue,
+		Directives:   arg.Directives,
+	}, nil
+}
+
+func convertArguments(values []introspectionInputValue) (ast.ArgumentDefinitionList, error) {
+	var args ast.ArgumentDefinitionList
+	for _, v := range values {
+		arg, err := convertInputValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("argument %s: %v", v.Name, err)
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+func convertInputValue(v introspectionInputValue) (*ast.ArgumentDefinition, error) {
+	valueType, err := convertTypeRef(v.Type)
+	if err != nil {
+		return nil, err
+	}
+	arg := &ast.ArgumentDefinition{
+		Name:        v.Name,
+		Description: v.Description,
+		Type:        valueType,
+		Directives:  deprecation(v.IsDeprecated, v.DeprecationReason),
+	}
+	if v.DefaultValue != nil {
+		if arg.DefaultValue, err = parseValueLiteral(*v.DefaultValue); err != nil {
+			return nil, err
+		}
+	}
+	return arg, nil
+}
+
+func convertIntrospectionDirective(d introspectionDirective) (*ast.DirectiveDefinition, error) {
+	args, err := convertArguments(d.Args)
+	if err != nil {
+		return nil, err
+	}
+	def := &ast.DirectiveDefinition{
+		Name:         d.Name,
+		Description:  d.Description,
+		Arguments:    args,
+		IsRepeatable: d.IsRepeatable,
+	}
+	for _, location := range d.Locations {
+		def.Locations = append(def.Locations, ast.DirectiveLocation(location))
+	}
+	return def, nil
+}
+
+// convertTypeRef converts a nested NON_NULL / LIST / named type reference
+func convertTypeRef(ref introspectionTypeRef) (*ast.Type, error) {
+	switch ref.Kind {
+	case "NON_NULL":
+		if ref.OfType == nil {
+			return nil, fmt.Errorf("NON_NULL type without ofType")
+		}
+		inner, err := convertTypeRef(*ref.OfType)
+		if err != nil {
+			return nil, err
+		}
+		inner.NonNull = true
+		return inner, nil
+	case "LIST":
+		if ref.OfType == nil {
+			return nil, fmt.Errorf("LIST type without ofType")
+		}
+		elem, err := convertTypeRef(*ref.OfType)
+		if err != nil {
+			return nil, err
+		}
+		return ast.ListType(elem, nil), nil
+	}
+	if ref.Name == "" {
+		return nil, fmt.Errorf("type reference of kind %q without a name", ref.Kind)
+	}
+	return ast.NamedType(ref.Name, nil), nil
+}
+
+// deprecation returns a @deprecated directive list for deprecated elements
+func deprecation(isDeprecated bool, reason *string) ast.DirectiveList {
+	if !isDeprecated {
+		return nil
+	}
+	directive := &ast.Directive{Name: "deprecated"}
+	if reason != nil {
+		directive.Arguments = ast.ArgumentList{{
+			Name:  "reason",
+			Value: &ast.Value{Kind: ast.StringValue, Raw: *reason},
+		}}
+	}
+	return ast.DirectiveList{directive}
+}
+
+// parseValueLiteral parses a default value, which introspection returns as
+// GraphQL literal source such as `10`, `"text"` or `{first: 5}`, by parsing
+// it as the default of a throwaway input field
+func parseValueLiteral(literal string) (*ast.Value, error) {
+	doc, err := gqlparser.ParseSchema(&ast.Source{
+		Name:  "default value",
+		Input: "input Default { value: Default = " + literal + " }",
+	})
+	if err != nil || len(doc.Definitions) != 1 || len(doc.Definitions[0].Fields) != 1 {
+		return nil, fmt.Errorf("invalid default value %s", literal)
+	}
+	return doc.Definitions[0].Fields[0].DefaultValue, nil
+}
@@ -0,0 +1,152 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testIntrospection = `{
+  "__schema": {
+    "queryType": {"name": "RootQuery"},
+    "mutationType": null,
+    "subscriptionType": null,
+    "types": [
+      {"kind": "OBJECT", "name": "RootQuery", "fields": [
+        {"name": "users", "description": "List users",
+         "args": [{"name": "first", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "10"},
+                  {"name": "filter", "type": {"kind": "INPUT_OBJECT", "name": "Filter"}, "defaultValue": "{role: ADMIN}"}],
+         "type": {"kind": "NON_NULL", "ofType": {"kind": "LIST", "ofType":
+                  {"kind": "NON_NULL", "ofType": {"kind": "OBJECT", "name": "User"}}}},
+         "isDeprecated": false}
+      ], "interfaces": []},
+      {"kind": "OBJECT", "name": "User", "fields": [
+        {"name": "login", "args": [], "type": {"kind": "SCALAR", "name": "String"},
+         "isDeprecated": true, "deprecationReason": "Use name"}
+      ], "interfaces": [{"kind": "INTERFACE", "name": "Node"}]},
+      {"kind": "INTERFACE", "name": "Node", "fields": [], "possibleTypes": [{"kind": "OBJECT", "name": "User"}]},
+      {"kind": "INPUT_OBJECT", "name": "Filter", "inputFields": [
+        {"name": "role", "type": {"kind": "ENUM", "name": "Role"}, "defaultValue": "USER"}
+      ]},
+      {"kind": "ENUM", "name": "Role", "enumValues": [
+        {"name": "ADMIN", "isDeprecated": false}, {"name": "USER", "isDeprecated": false}
+      ]},
+      {"kind": "UNION", "name": "Result", "possibleTypes": [{"kind": "OBJECT", "name": "User"}]},
+      {"kind": "SCALAR", "name": "String"},
+      {"kind": "OBJECT", "name": "__Type", "fields": [], "interfaces": []}
+    ],
+    "directives": [
+      {"name": "skip", "locations": ["FIELD"], "args": []},
+      {"name": "auth", "description": "Requires a role", "locations": ["FIELD_DEFINITION", "OBJECT"],
+       "args": [{"name": "role", "type": {"kind": "NON_NULL", "ofType": {"kind": "ENUM", "name": "Role"}}}],
+       "isRepeatable": true}
+    ]
+  }
+}`
+
+func TestParseIntrospection(t *testing.T) {
+	schema, err := ParseIntrospection([]byte(testIntrospection))
+	if err != nil {
+		t.Fatalf("ParseIntrospection() returned error: %v", err)
+	}
+
+	if _, ok := schema.Types["__Type"]; ok {
+		t.Error("Introspection types should be skipped")
+	}
+	if _, ok := schema.Types["String"]; ok {
+		t.Error("Built-in scalars should be skipped")
+	}
+	if schema.Query == nil || schema.Query.Name != "RootQuery" {
+		t.Fatalf("Expected the query root to be RootQuery, got %v", schema.Query)
+	}
+	if schema.Mutation != nil || schema.Subscription != nil {
+		t.Error("Missing root types should be nil")
+	}
+
+	users := schema.Query.Fields.ForName("users")
+	if users == nil {
+		t.Fatal("Expected field users")
+	}
+	if got := users.Type.String(); got != "[User!]!" {
+		t.Errorf("Expected type [User!]!, got %s", got)
+	}
+	if got := users.Arguments.ForName("first").DefaultValue.String(); got != "10" {
+		t.Errorf("Expected default 10, got %s", got)
+	}
+	if got := users.Arguments.ForName("filter").DefaultValue.String(); got != "{role:ADMIN}" {
+		t.Errorf("Expected object default, got %s", got)
+	}
+
+	login := schema.Types["User"].Fields.ForName("login")
+	deprecated := login.Directives.ForName("deprecated")
+	if deprecated == nil || deprecated.Arguments.ForName("reason").Value.Raw != "Use name" {
+		t.Errorf("Expected @deprecated(reason: \"Use name\"), got %v", login.Directives)
+	}
+
+	if got := strings.Join(schema.Types["User"].Interfaces, ","); got != "Node" {
+		t.Errorf("Expected interfaces Node, got %s", got)
+	}
+	if len(schema.Types["Node"].Types) != 0 {
+		t.Error("Possible types of an interface are not union members")
+	}
+	if got := strings.Join(schema.Types["Result"].Types, ","); got != "User" {
+		t.Errorf("Expected union members User, got %s", got)
+	}
+	if schema.Types["Filter"].Kind != ast.InputObject || schema.Types["Filter"].Fields.ForName("role") == nil {
+		t.Error("Expected input Filter with field role")
+	}
+	if len(schema.Types["Role"].EnumValues) != 2 {
+		t.Error("Expected two enum values")
+	}
+
+	if _, ok := schema.Directives["skip"]; ok {
+		t.Error("Built-in directives should be skipped")
+	}
+	auth := schema.Directives["auth"]
+	if auth == nil || !auth.IsRepeatable || len(auth.Locations) != 2 || auth.Arguments.ForName("role").Type.String() != "Role!" {
+		t.Errorf("Unexpected directive @auth: %+v", auth)
+	}
+}
+
+func TestParseIntrospectionDataWrapper(t *testing.T) {
+	schema, err := ParseIntrospection([]byte(`{"data": ` + testIntrospection + `}`))
+	if err != nil {
+		t.Fatalf("ParseIntrospection() returned error: %v", err)
+	}
+	if schema.Query == nil {
+		t.Error("Expected the query root from a wrapped result")
+	}
+}
+
+func TestParseIntrospectionErrors(t *testing.T) {
+	testCases := []struct {
+		name  string
+		input string
+		error string
+	}{
+		{name: "invalid JSON", input: `{`, error: "invalid introspection JSON"},
+		{name: "no schema", input: `{"data": {}}`, error: "no __schema object"},
+		{name: "error response", input: `{"errors": [{"message": "introspection disabled"}]}`, error: "introspection disabled"},
+		{
+			name:  "unknown kind",
+			input: `{"__schema": {"types": [{"kind": "WIDGET", "name": "A"}]}}`,
+			error: `type A: unknown kind "WIDGET"`,
+		},
+		{
+			name: "bad default value",
+			input: `{"__schema": {"types": [{"kind": "INPUT_OBJECT", "name": "A", "inputFields": ` +
+				`[{"name": "a", "type": {"kind": "SCALAR", "name": "Int"}, "defaultValue": "{"}]}]}}`,
+			error: "invalid default value",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseIntrospection([]byte(tc.input))
+			if err == nil || !strings.Contains(err.Error(), tc.error) {
+				t.Errorf("Expected error containing %q, got %v", tc.error, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package parser
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/schemafile"
+)
+
+// LoadedSchema is the result of reading and parsing a schema from disk
+type LoadedSchema struct {
+	Schema *ast.Schema
+	// Files lists the schema files read, in the order they were combined
+	Files []string
+	// Sources holds each file's content with fragments removed, named by
+	// file path, for error messages that point into the original files
+	Sources []*ast.Source
+	// RemovedFragments reports whether fragment definitions were stripped
+	RemovedFragments bool
+}
+
+// LoadSchema reads a schema from a single file or from all files matching a
+// pattern, removes fragment definitions, parses it and merges type
+// extensions with BuildSchema. The file may be schemafile.Stdin, and it may
+// be a .zip or .tar.gz archive, whether named or piped to stdin, in which
+// case the pattern selects the schema files inside it. Otherwise exactly one
+// of file and pattern is used; the pattern wins if both are set.
+func LoadSchema(file, pattern string) (*LoadedSchema, error) {
+	sources, err := readSchemaSources(file, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fragments are client-side constructs and don't belong in schema files
+	loaded := &LoadedSchema{}
+	for _, source := range sources {
+		cleaned := RemoveFragments(source.Input)
+		loaded.RemovedFragments = loaded.RemovedFragments || cleaned != source.Input
+		loaded.Files = append(loaded.Files, source.Name)
+		loaded.Sources = append(loaded.Sources, &ast.Source{Name: source.Name, Input: cleaned})
+	}
+
+	// Each file is parsed on its own so that definitions keep their file
+	// and line. Code blocks in descriptions are safe to parse because
+	// they're inside triple-quoted strings.
+	doc, err := ParseSources(loaded.Sources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %v", err)
+	}
+
+	loaded.Schema = BuildSchema(doc)
+	return loaded, nil
+}
+
+// readSchemaSources reads the schema files named by the file and pattern
+// arguments of LoadSchema, each as a source named by its path
+func readSchemaSources(file, pattern string) ([]*ast.Source, error) {
+	if file == "" || (pattern != "" && file != schemafile.Stdin && !schemafile.IsArchive(file)) {
+		files, err := FindSchemaFiles(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find schema files with pattern '%s': %v", pattern, err)
+		}
+		if err := ValidateSchemaFiles(files); err != nil {
+			return nil, fmt.Errorf("schema file validation failed: %v", err)
+		}
+		sources := make([]*ast.Source, 0, len(files))
+		for _, f := range files {
+			content, err := os.ReadFile(f)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read schema file '%s': %v", f, err)
+			}
+			sources = append(sources, &ast.Source{Name: f, Input: string(content)})
+		}
+		return sources, nil
+	}
+
+	name := file
+	var data []byte
+	var err error
+	if file == schemafile.Stdin {
+		name = schemafile.StdinName
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema file %s: %v", name, err)
+	}
+
+	if isArchiveContent(data) {
+		return readArchive(name, data, pattern)
+	}
+	if pattern != "" {
+		return nil, fmt.Errorf("a pattern can only be combined with a .zip or .tar.gz archive, not %s", name)
+	}
+	return []*ast.Source{{Name: name, Input: string(data)}}, nil
+}
@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSchema(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"user.graphqls":  "type Query { user: User }\ntype User { id: ID! }\nfragment F on User { id }",
+		"extra.graphqls": "extend type User { name: String }",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	loaded, err := LoadSchema(filepath.Join(dir, "user.graphqls"), "")
+	if err != nil {
+		t.Fatalf("LoadSchema() returned error: %v", err)
+	}
+	if !loaded.RemovedFragments {
+		t.Error("Expected fragments to be removed")
+	}
+	if loaded.Schema.Query == nil || len(loaded.Files) != 1 {
+		t.Errorf("Unexpected single file result: %+v", loaded)
+	}
+
+	loaded, err = LoadSchema("", filepath.Join(dir, "*.graphqls"))
+	if err != nil {
+		t.Fatalf("LoadSchema() returned error: %v", err)
+	}
+	if len(loaded.Files) != 2 {
+		t.Errorf("Expected 2 files, got %v", loaded.Files)
+	}
+	if user := loaded.Schema.Types["User"]; user == nil || user.Fields.ForName("name") == nil {
+		t.Error("Expected extensions from other files to be merged")
+	}
+
+	if _, err := LoadSchema(filepath.Join(dir, "missing.graphqls"), ""); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
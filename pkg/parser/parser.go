@@ -12,9 +12,25 @@ var (
 	reHyphenList   = regexp.MustCompile(`(^|\s)-\s`)
 )
 
+// reModuleLine matches a "@module: NAME" description line, which assigns an
+// element to a module for --group-by tag
+var reModuleLine = regexp.MustCompile(`(?m)^[ \t]*@module:[ \t]*(\S.*?)[ \t]*(?:\n|$)`)
+
+// DescriptionModule returns the module named by a "@module: NAME" line of a
+// description, or "" when it has none
+func DescriptionModule(description string) string {
+	if m := reModuleLine.FindStringSubmatch(description); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
 // ProcessDescription processes GraphQL description text for AsciiDoc output
 // This is the main entry point that supports both structured and unstructured descriptions
 func ProcessDescription(description string) string {
+	// Module annotations only say where the element is documented
+	description = reModuleLine.ReplaceAllString(description, "")
+
 	// First normalise indentation - GraphQL descriptions often have leading whitespace
 	description = NormalizeIndentation(description)
 
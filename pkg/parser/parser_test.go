@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/vektah/gqlparser/v2/ast"
@@ -1105,3 +1106,16 @@ func TestConvertArgumentsPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestDescriptionModule(t *testing.T) {
+	description := "Look up users.\n  @module: Accounts \nadd.version: 1.0"
+	if got := DescriptionModule(description); got != "Accounts" {
+		t.Errorf("DescriptionModule() = %q, want Accounts", got)
+	}
+	if got := DescriptionModule("Mentions @module: inline only"); got != "" {
+		t.Errorf("Expected no module for an inline mention, got %q", got)
+	}
+	if got := ProcessDescription(description); strings.Contains(got, "@module") {
+		t.Errorf("Expected the module line to be removed, got %q", got)
+	}
+}
@@ -92,6 +92,11 @@ func CamelToSnake(s string) string {
 	return string(result)
 }
 
+// MutationPrefixes are the verbs the catalogue groups mutations by, e.g.
+// addUser is listed under "Adds". The lint command expects every mutation
+// name to start with one of them.
+var MutationPrefixes = []string{"add", "update", "delete", "save"}
+
 // CleanDescription removes lines starting with skipCharacter, except for AsciiDoc code block delimiters
 func CleanDescription(text, skipCharacter string) string {
 	lines := strings.Split(text, "\n")
@@ -173,7 +178,9 @@ func ConvertDescriptionToRefNumbers(text string, skipNonDash bool) string {
 }
 
 // RemoveFragments removes fragment definitions from schema content
-// Fragments are client-side query constructs and don't belong in schema files
+// Fragments are client-side query constructs and don't belong in schema files.
+// Removed lines are left empty so that line numbers in parse and validation
+// errors still match the original file.
 func RemoveFragments(schemaContent string) string {
 	lines := strings.Split(schemaContent, "\n")
 	var cleanedLines []string
@@ -188,6 +195,7 @@ func RemoveFragments(schemaContent string) string {
 			inFragment = true
 			// Count opening braces on the same line
 			braceCount = strings.Count(line, "{") - strings.Count(line, "}")
+			cleanedLines = append(cleanedLines, "")
 			continue
 		}
 
@@ -199,6 +207,7 @@ func RemoveFragments(schemaContent string) string {
 			if braceCount == 0 {
 				inFragment = false
 			}
+			cleanedLines = append(cleanedLines, "")
 			continue
 		}
 
@@ -0,0 +1,31 @@
+// Package schemafile classifies schema arguments given on the command line
+// or in the configuration file: standard input, archives and file patterns.
+// It has no dependencies, so both the configuration and the loader can use it.
+package schemafile
+
+import "strings"
+
+// Stdin is the schema file argument that reads the schema from standard input
+const Stdin = "-"
+
+// StdinName names the schema read from standard input in messages and
+// source positions
+const StdinName = "<stdin>"
+
+// IsArchive reports whether a schema file argument names a .zip, .tar.gz or
+// .tgz archive
+func IsArchive(name string) bool {
+	lower := strings.ToLower(name)
+	for _, ext := range []string{".zip", ".tar.gz", ".tgz"} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsPattern reports whether a schema argument is a file pattern rather than
+// a plain path, i.e. whether it contains glob or brace characters
+func IsPattern(arg string) bool {
+	return strings.ContainsAny(arg, "*?[{")
+}
@@ -0,0 +1,31 @@
+package schemafile
+
+import "testing"
+
+func TestIsArchive(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"export.zip":      true,
+		"export.tar.gz":   true,
+		"EXPORT.TGZ":      true,
+		"schema.graphqls": false,
+		Stdin:             false,
+	} {
+		if got := IsArchive(name); got != expected {
+			t.Errorf("IsArchive(%q) = %v, want %v", name, got, expected)
+		}
+	}
+}
+
+func TestIsPattern(t *testing.T) {
+	for arg, expected := range map[string]bool{
+		"schema.graphqls":            false,
+		"schemas/v1/schema.graphqls": false,
+		"schemas/*.graphqls":         true,
+		"schemas/**/*.graphqls":      true,
+		"schema.{graphql,graphqls}":  true,
+	} {
+		if got := IsPattern(arg); got != expected {
+			t.Errorf("IsPattern(%q) = %v, want %v", arg, got, expected)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Package serve previews generated HTML documentation in a browser. The page
+// is held in memory and rebuilt on request; browsers showing it keep a
+// server-sent events connection open and reload when a rebuild finishes.
+package serve
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"net/http"
+	"sync"
+)
+
+// ReloadPath is the event stream browsers listen on for reloads
+const ReloadPath = "/_reload"
+
+// reloadScript is added to every page served; it reloads the page when the
+// server announces a rebuild
+const reloadScript = `<script>new EventSource("` + ReloadPath +
+	`").addEventListener("reload", function () { location.reload(); });</script>
+`
+
+// BuildFunc renders the documentation as a complete HTML page
+type BuildFunc func() ([]byte, error)
+
+// Server serves the most recent build of the documentation
+type Server struct {
+	build BuildFunc
+
+	mu      sync.RWMutex
+	page    []byte
+	clients map[chan struct{}]bool
+	done    chan struct{}
+	closed  bool
+}
+
+// New returns a server for the pages rendered by build. Nothing is built
+// until Rebuild is called.
+func New(build BuildFunc) *Server {
+	return &Server{
+		build:   build,
+		page:    withReload([]byte("<!DOCTYPE html>\n<html><body></body></html>\n")),
+		clients: make(map[chan struct{}]bool),
+		done:    make(chan struct{}),
+	}
+}
+
+// Rebuild renders the page again and tells open browsers to reload. When the
+// build fails, the error is shown in place of the page, so the browser
+// reflects the state of the schema either way, and it is returned.
+func (s *Server) Rebuild() error {
+	page, err := s.build()
+	if err != nil {
+		page = errorPage(err)
+	}
+
+	s.mu.Lock()
+	s.page = withReload(page)
+	for ch := range s.clients {
+		select {
+		case ch <- struct{}{}:
+		default: // a reload is already pending
+		}
+	}
+	s.mu.Unlock()
+	return err
+}
+
+// Close ends the open event streams, so that an http.Server using this
+// handler can shut down without waiting for browsers to disconnect
+func (s *Server) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.done)
+	}
+}
+
+// ServeHTTP serves the page at / and the reload events at ReloadPath
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/", "/index.html":
+		s.mu.RLock()
+		page := s.page
+		s.mu.RUnlock()
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = w.Write(page)
+	case ReloadPath:
+		s.serveEvents(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveEvents streams a reload event after every rebuild until the browser
+// disconnects or the server is closed
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	ch := make(chan struct{}, 1)
+	s.mu.Lock()
+	s.clients[ch] = true
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, ch)
+		s.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	_, _ = fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.done:
+			return
+		case <-ch:
+			_, _ = fmt.Fprint(w, "event: reload\ndata: \n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// withReload adds the reload script to the end of a page's body
+func withReload(page []byte) []byte {
+	i := bytes.LastIndex(page, []byte("</body>"))
+	if i < 0 {
+		return append(append([]byte{}, page...), reloadScript...)
+	}
+	out := make([]byte, 0, len(page)+len(reloadScript))
+	out = append(out, page[:i]...)
+	out = append(out, reloadScript...)
+	return append(out, page[i:]...)
+}
+
+// errorPage shows a failed build in the browser
+func errorPage(err error) []byte {
+	return []byte(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Documentation error</title>
+<style>
+body { font-family: sans-serif; margin: 2rem; }
+pre { background: #fdecea; padding: 1rem; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Documentation error</h1>
+<p>The documentation could not be generated. The page reloads when the schema changes.</p>
+<pre>` + html.EscapeString(err.Error()) + `</pre>
+</body>
+</html>
+`)
+}
@@ -0,0 +1,109 @@
+package serve
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, url string) (int, string) {
+	t.Helper()
+	resp, err := http.Get(url) //nolint:gosec,noctx // test server URL
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Reading %s failed: %v", url, err)
+	}
+	return resp.StatusCode, string(body)
+}
+
+func TestServePage(t *testing.T) {
+	content := "<html><body><h1>API</h1></body></html>"
+	s := New(func() ([]byte, error) { return []byte(content), nil })
+	if err := s.Rebuild(); err != nil {
+		t.Fatalf("Rebuild returned error: %v", err)
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	status, body := get(t, ts.URL+"/")
+	if status != http.StatusOK {
+		t.Errorf("Expected status 200, got %d", status)
+	}
+	expected := "<h1>API</h1><script>new EventSource(\"/_reload\")"
+	if !strings.Contains(body, expected) || !strings.HasSuffix(body, "</script>\n</body></html>") {
+		t.Errorf("Expected page with reload script before </body>, got:\n%s", body)
+	}
+
+	if status, _ := get(t, ts.URL+"/missing.html"); status != http.StatusNotFound {
+		t.Errorf("Expected status 404 for unknown path, got %d", status)
+	}
+}
+
+func TestServeBuildError(t *testing.T) {
+	s := New(func() ([]byte, error) { return nil, errors.New("schema.graphqls:3: Expected Name, found <EOF>") })
+	if err := s.Rebuild(); err == nil {
+		t.Error("Rebuild should return the build error")
+	}
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+
+	_, body := get(t, ts.URL+"/")
+	for _, expected := range []string{"Documentation error", "schema.graphqls:3: Expected Name, found &lt;EOF&gt;", ReloadPath} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Error page should contain %q, got:\n%s", expected, body)
+		}
+	}
+}
+
+func TestServeReloadEvents(t *testing.T) {
+	s := New(func() ([]byte, error) { return []byte("<html><body></body></html>"), nil })
+	ts := httptest.NewServer(s)
+	defer ts.Close()
+	defer s.Close()
+
+	resp, err := http.Get(ts.URL + ReloadPath) //nolint:noctx // test server URL
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", ReloadPath, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected an event stream, got %q", ct)
+	}
+
+	events := make(chan string, 10)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				events <- line
+			}
+		}
+		close(events)
+	}()
+
+	// Wait for the stream to open before rebuilding
+	if line := <-events; line != ": connected" {
+		t.Fatalf("Expected the connection comment, got %q", line)
+	}
+	if err := s.Rebuild(); err != nil {
+		t.Fatalf("Rebuild returned error: %v", err)
+	}
+
+	select {
+	case line := <-events:
+		if line != "event: reload" {
+			t.Errorf("Expected a reload event, got %q", line)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a reload event after Rebuild")
+	}
+}
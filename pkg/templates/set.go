@@ -0,0 +1,122 @@
+package templates
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TemplateExt is the file extension for user-supplied template overrides
+const TemplateExt = ".tmpl"
+
+// builtins maps each template name to its built-in source. The name doubles as
+// the override file name (without TemplateExt) inside a template directory.
+var builtins = map[string]string{
+	"header":                  HeaderTemplate,
+	"banner":                  BannerTemplate,
+	"catalogue":               CatalogueTemplate,
+	"catalogue-queries":       CatalogueQueriesTemplate,
+	"catalogue-mutations":     CatalogueMutationsTemplate,
+	"catalogue-subscriptions": CatalogueSubscriptionsTemplate,
+	"query":                   QueryTemplate,
+	"mutation":                MutationTemplate,
+	"subscription":            SubscriptionTemplate,
+	"subscription-details":    SubscriptionDetailsTemplate,
+	"type-section":            TypeSectionTemplate,
+	"field":                   FieldTemplate,
+	"interface-section":       InterfaceSectionTemplate,
+	"union-section":           UnionSectionTemplate,
+	"enum-section":            EnumSectionTemplate,
+	"input-section":           InputSectionTemplate,
+	"directives":              DirectivesTemplate,
+	"scalar":                  ScalarTemplate,
+	"diagram":                 DiagramTemplate,
+	"group-index":             GroupIndexTemplate,
+	"group":                   GroupTemplate,
+}
+
+// Set holds the template sources used for one generation run: the built-in
+// templates, optionally overlaid with files from a user template directory.
+type Set struct {
+	sources   map[string]string
+	overrides map[string]string // template name -> override file path
+}
+
+// Default returns a Set containing only the built-in templates
+func Default() *Set {
+	sources := make(map[string]string, len(builtins))
+	for name, src := range builtins {
+		sources[name] = src
+	}
+	return &Set{
+		sources:   sources,
+		overrides: make(map[string]string),
+	}
+}
+
+// Load returns the built-in templates overlaid with every <name>.tmpl file in
+// dir. An empty dir yields the defaults. Files whose name does not match a
+// known template are rejected so that typos do not silently fall back to the
+// built-in output.
+func Load(dir string) (*Set, error) {
+	set := Default()
+	if dir == "" {
+		return set, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template directory '%s': %v", dir, err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != TemplateExt {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), TemplateExt)
+		if _, known := builtins[name]; !known {
+			return nil, fmt.Errorf("unknown template '%s' in '%s' (known templates: %s)",
+				entry.Name(), dir, strings.Join(Names(), ", "))
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template '%s': %v", path, err)
+		}
+
+		set.sources[name] = string(content)
+		set.overrides[name] = path
+	}
+
+	return set, nil
+}
+
+// Source returns the template source for name. It panics on an unknown name,
+// since template names are fixed at compile time.
+func (s *Set) Source(name string) string {
+	src, ok := s.sources[name]
+	if !ok {
+		panic(fmt.Sprintf("templates: unknown template %q", name))
+	}
+	return src
+}
+
+// Overrides returns the names of templates replaced from a template directory,
+// mapped to the file each was read from.
+func (s *Set) Overrides() map[string]string {
+	return s.overrides
+}
+
+// Names returns the sorted names of all built-in templates
+func Names() []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
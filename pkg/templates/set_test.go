@@ -0,0 +1,91 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDefaultSetContainsBuiltins(t *testing.T) {
+	set := Default()
+
+	if set.Source("query") != QueryTemplate {
+		t.Error("Default set should return the built-in query template")
+	}
+	if set.Source("type-section") != TypeSectionTemplate {
+		t.Error("Default set should return the built-in type-section template")
+	}
+	if len(set.Overrides()) != 0 {
+		t.Errorf("Default set should have no overrides, got %v", set.Overrides())
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("empty dir name returns defaults", func(t *testing.T) {
+		set, err := Load("")
+		if err != nil {
+			t.Fatalf("Load(\"\") returned error: %v", err)
+		}
+		if set.Source("mutation") != MutationTemplate {
+			t.Error("Expected built-in mutation template")
+		}
+	})
+
+	t.Run("overrides matching files", func(t *testing.T) {
+		dir := t.TempDir()
+		custom := "== Custom Queries\n{{range .Queries}}* {{.Name}}\n{{end}}"
+		if err := os.WriteFile(filepath.Join(dir, "query.tmpl"), []byte(custom), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		// Non-template files are ignored
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("notes"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		set, err := Load(dir)
+		if err != nil {
+			t.Fatalf("Load returned error: %v", err)
+		}
+		if set.Source("query") != custom {
+			t.Errorf("Expected overridden query template, got %q", set.Source("query"))
+		}
+		if set.Source("mutation") != MutationTemplate {
+			t.Error("Templates without an override file should keep the built-in")
+		}
+		if got := set.Overrides()["query"]; got != filepath.Join(dir, "query.tmpl") {
+			t.Errorf("Expected override path for query, got %q", got)
+		}
+	})
+
+	t.Run("unknown template name", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "querys.tmpl"), []byte("x"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := Load(dir)
+		if err == nil || !strings.Contains(err.Error(), "unknown template 'querys.tmpl'") {
+			t.Errorf("Expected unknown template error, got %v", err)
+		}
+	})
+
+	t.Run("missing directory", func(t *testing.T) {
+		if _, err := Load(filepath.Join(t.TempDir(), "missing")); err == nil {
+			t.Error("Expected error for missing directory")
+		}
+	})
+}
+
+func TestNames(t *testing.T) {
+	names := Names()
+	if len(names) != len(builtins) {
+		t.Fatalf("Expected %d names, got %d", len(builtins), len(names))
+	}
+	for i := 1; i < len(names); i++ {
+		if names[i-1] > names[i] {
+			t.Errorf("Names should be sorted, got %v", names)
+			break
+		}
+	}
+}
@@ -1,7 +1,51 @@
 package templates
 
+// HeaderTemplate renders the document header shared by the full
+// documentation and the catalogue: the title, the attribute block, an
+// optional attributes include, the banner and the preamble.
+const HeaderTemplate = `= {{.Title}}
+{{- if .Author}}
+:author: {{.Author}}
+{{- end}}
+{{- if .Version}}
+:revnumber: {{.Version}}
+{{- end}}
+{{- range .Attributes}}
+:{{.Name}}:{{if .Value}} {{.Value}}{{end}}
+{{- end}}
+{{- if .AttributesFile}}
+
+include::{{.AttributesFile}}[]
+{{- end}}
+
+{{if .Banner}}
+{{.Banner}}
+
+{{end}}
+{{- if .Preamble}}{{.Preamble}}
+
+{{end}}`
+
+// BannerTemplate renders the notice below the document header that the
+// output is generated and must not be edited.
+const BannerTemplate = `[IMPORTANT]
+====
+This is automatically generated from the schema file ` + "`" + `{{.SchemaFile}}` + "`" + `. +
+Do not edit this file directly. +
+Last generated _{revdate}_
+====`
+
 const FieldTemplate = `
 | {{.Type}} | {{.Name}} | {{.Description}}
+{{- if .Deprecation}}
+
+{{.Deprecation}}
+{{- end}}
+{{- if .Arguments}}
+
+.Arguments
+{{.Arguments}}
+{{- end}}
 {{- if .RequiredOrArray}}
 
 .Notes:
@@ -46,6 +90,11 @@ The following custom scalar types are defined in this schema:
 {{ .Description | printAsciiDocTagsTmpl }}
 // end::scalar-description-{{.Name}}[]
 
+{{ end }}
+{{- if .DefinedIn }}
+// tag::scalar-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::scalar-source-{{.Name}}[]
 {{ end }}
 // end::scalar-{{.Name}}[]
 
@@ -59,6 +108,106 @@ No custom scalars exist in this schema.
 // end::scalar[]
 `
 
+// QueryTemplate renders the Query section, one entry per query field
+const QueryTemplate = `{{.QueryTag}}
+
+
+{{ if .QueryObjectDescription }}{{ .QueryObjectDescription }}
+{{ end }}
+{{- range .Queries }}// tag::query-{{.Name}}[]
+
+[[{{.AnchorName}}]]
+=== {{.Name}}
+
+
+{{ if .Deprecation }}// tag::query-deprecation-{{.Name}}[]
+{{ .Deprecation }}
+// end::query-deprecation-{{.Name}}[]
+
+{{ end }}// tag::method-description-{{.Name}}[]
+{{ if .Description }}{{ .Description }}
+{{ end }}// end::method-description-{{.Name}}[]
+
+// tag::method-signature-{{.Name}}[]
+{{ .MethodSignatureBlock }}
+// end::method-signature-{{.Name}}[]
+
+// tag::method-args-{{.Name}}[]
+{{ if .NumberedRefs }}{{ .NumberedRefs }}
+{{ end }}// end::method-args-{{.Name}}[]
+
+// tag::query-name-{{.Name}}[]
+*Query Name:* _{{.Name}}_
+// end::query-name-{{.Name}}[]
+
+// tag::query-return-{{.Name}}[]
+*Return:* {{ .TypeName }}
+// end::query-return-{{.Name}}[]
+
+{{ if .DefinedIn }}// tag::query-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::query-source-{{.Name}}[]
+
+{{ end }}{{ if .Changelog }}// tag::query-changelog-{{.Name}}[]
+{{ .Changelog }}
+// end::query-changelog-{{.Name}}[]
+
+{{ end }}{{ if .HasArguments }}// tag::arguments-{{.Name}}[]
+.Arguments
+{{ .Arguments }}// end::arguments-{{.Name}}[]
+
+{{ end }}{{ if .Example }}// tag::query-example-{{.Name}}[]
+{{ .Example }}
+// end::query-example-{{.Name}}[]
+
+{{ end }}// end::query-{{.Name}}[]
+
+{{ end }}`
+
+// SubscriptionDetailsTemplate renders the body of a single subscription; the
+// result is embedded in SubscriptionTemplate as .Details.
+const SubscriptionDetailsTemplate = `// tag::subscription-{{.Name}}[]
+
+[[{{.AnchorName}}]]
+=== {{.Name}}
+
+
+{{ if .Deprecation }}// tag::subscription-deprecation-{{.Name}}[]
+{{ .Deprecation }}
+// end::subscription-deprecation-{{.Name}}[]
+
+{{ end }}// tag::subscription-signature-{{.Name}}[]
+{{ .MethodSignatureBlock }}
+// end::subscription-signature-{{.Name}}[]
+
+// tag::subscription-name-{{.Name}}[]
+*Subscription Name:* _{{.Name}}_
+// end::subscription-name-{{.Name}}[]
+
+// tag::subscription-return-{{.Name}}[]
+*Return:* {{ .TypeName }}
+// end::subscription-return-{{.Name}}[]
+
+{{ if .DefinedIn }}// tag::subscription-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::subscription-source-{{.Name}}[]
+
+{{ end }}{{ if .HasArguments }}// tag::subscription-arguments-{{.Name}}[]
+.Arguments
+{{ .Arguments }}// end::subscription-arguments-{{.Name}}[]
+
+{{ end }}{{ if .HasDirectives }}// tag::subscription-directives-{{.Name}}[]
+.Directives
+{{ .Directives }}// end::subscription-directives-{{.Name}}[]
+
+{{ end }}{{ if .Example }}// tag::subscription-example-{{.Name}}[]
+{{ .Example }}
+// end::subscription-example-{{.Name}}[]
+
+{{ end }}// end::subscription-{{.Name}}[]
+
+`
+
 const SubscriptionTemplate = `
 // tag::subscription[]
 == Subscription
@@ -99,6 +248,13 @@ GraphQL Mutations are entry points on a GraphQL server that provides write acces
 [[{{.AnchorName}}]]
 === {{.Name}}{{ if .IsInternal }} [INTERNAL]{{ end }}
 
+{{- if .Deprecation }}
+
+// tag::mutation-deprecation-{{.Name}}[]
+{{ .Deprecation }}
+// end::mutation-deprecation-{{.Name}}[]
+{{- end }}
+
 // tag::method-description-{{.Name}}[]
 {{- if .CleanedDescription }}
 {{ .CleanedDescription | printAsciiDocTagsTmpl }}
@@ -122,6 +278,12 @@ GraphQL Mutations are entry points on a GraphQL server that provides write acces
 // tag::mutation-return-{{.Name}}[]
 *Return:* {{ .TypeName }}
 // end::mutation-return-{{.Name}}[]
+{{- if .DefinedIn }}
+
+// tag::mutation-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::mutation-source-{{.Name}}[]
+{{- end }}
 
 {{- if .Changelog }}
 // tag::mutation-changelog-{{.Name}}[]
@@ -143,6 +305,12 @@ GraphQL Mutations are entry points on a GraphQL server that provides write acces
 // end::mutation-directives-{{.Name}}[]
 {{- end }}
 
+{{- if .Example }}
+// tag::mutation-example-{{.Name}}[]
+{{ .Example }}
+// end::mutation-example-{{.Name}}[]
+{{- end }}
+
 // end::mutation-{{.Name}}[]
 {{ end }}
 {{- else }}
@@ -173,6 +341,25 @@ const TypeSectionTemplate = `
 // end::type-changelog-{{.Name}}[]
 {{- end }}
 
+{{- if .Implements }}
+// tag::type-implements-{{.Name}}[]
+*Implements:* {{ .Implements }}
+// end::type-implements-{{.Name}}[]
+{{- end }}
+
+{{- if .MemberOf }}
+// tag::type-unions-{{.Name}}[]
+*Member of:* {{ .MemberOf }}
+// end::type-unions-{{.Name}}[]
+{{- end }}
+
+{{- if .DefinedIn }}
+
+// tag::type-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::type-source-{{.Name}}[]
+{{- end }}
+
 // tag::type-def-{{.Name}}[]
 {{ .FieldsTable }}
 // end::type-def-{{.Name}}[]
@@ -182,7 +369,97 @@ const TypeSectionTemplate = `
 {{end}}
 `
 
-const EnumSectionTemplate = `
+const InterfaceSectionTemplate = `
+{{.InterfacesTag}}
+{{range .Interfaces}}
+// tag::interface-{{.Name}}[]
+[[{{.AnchorName}}]]
+=== {{.Name}}
+
+{{- if .Description }}
+// tag::interface-description-{{.Name}}[]
+{{ .Description | printAsciiDocTagsTmpl }}
+// end::interface-description-{{.Name}}[]
+{{- end }}
+
+{{- if .Changelog }}
+// tag::interface-changelog-{{.Name}}[]
+{{ .Changelog }}
+// end::interface-changelog-{{.Name}}[]
+{{- end }}
+
+{{- if .Implements }}
+// tag::interface-implements-{{.Name}}[]
+*Implements:* {{ .Implements }}
+// end::interface-implements-{{.Name}}[]
+{{- end }}
+
+{{- if .DefinedIn }}
+
+// tag::interface-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::interface-source-{{.Name}}[]
+{{- end }}
+
+// tag::interface-def-{{.Name}}[]
+{{ .FieldsTable }}
+// end::interface-def-{{.Name}}[]
+
+// tag::interface-implementors-{{.Name}}[]
+.Implemented by
+{{- if .Implementors }}
+{{- range .Implementors }}
+* {{ . }}
+{{- end }}
+{{- else }}
+_No types implement this interface._
+{{- end }}
+// end::interface-implementors-{{.Name}}[]
+
+// end::interface-{{.Name}}[]
+
+{{end}}
+`
+
+const UnionSectionTemplate = `
+{{.UnionsTag}}
+{{range .Unions}}
+// tag::union-{{.Name}}[]
+[[{{.AnchorName}}]]
+=== {{.Name}}
+
+{{- if .Description }}
+// tag::union-description-{{.Name}}[]
+{{ .Description | printAsciiDocTagsTmpl }}
+// end::union-description-{{.Name}}[]
+{{- end }}
+
+{{- if .Changelog }}
+// tag::union-changelog-{{.Name}}[]
+{{ .Changelog }}
+// end::union-changelog-{{.Name}}[]
+{{- end }}
+
+{{- if .DefinedIn }}
+
+// tag::union-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::union-source-{{.Name}}[]
+{{- end }}
+
+// tag::union-members-{{.Name}}[]
+.Possible types
+{{- range .Members }}
+* {{ . }}
+{{- end }}
+// end::union-members-{{.Name}}[]
+
+// end::union-{{.Name}}[]
+
+{{end}}
+`
+
+const EnumSectionTemplate = `{{- if .Enums }}
 {{.EnumsTag}}
 {{range .Enums}}
 // tag::enum-{{.Name}}[]
@@ -196,6 +473,13 @@ const EnumSectionTemplate = `
 // end::enum-description-{{.Name}}[]
 {{- end }}
 
+{{- if .DefinedIn }}
+
+// tag::enum-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::enum-source-{{.Name}}[]
+{{- end }}
+
 // tag::enum-def-{{.Name}}[]
 {{ .ValuesTable }}
 // end::enum-def-{{.Name}}[]
@@ -203,6 +487,65 @@ const EnumSectionTemplate = `
 // end::enum-{{.Name}}[]
 
 {{end}}
+{{ else -}}
+{{.EnumsTag}}
+
+[NOTE]
+====
+No enums exist in this schema.
+====
+
+{{ end -}}
+`
+
+// DirectivesTemplate renders the Directives section, one entry per directive
+// definition with its signature, arguments table and usage locations.
+const DirectivesTemplate = `{{.DirectivesTag}}
+
+// tag::DIRECTIVES[]
+
+{{ range .Directives }}// tag::directive-{{.Name}}[]
+
+[[{{.AnchorName}}]]
+=== @{{.Name}}
+
+{{ if .Description }}// tag::directive-description-{{.Name}}[]
+{{ .Description }}
+// end::directive-description-{{.Name}}[]
+
+{{ end }}{{ if .DefinedIn }}// tag::directive-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::directive-source-{{.Name}}[]
+
+{{ end }}// tag::directive-signature-{{.Name}}[]
+.Directive Signature
+[source, graphql]
+----
+{{ .Signature }}
+----
+// end::directive-signature-{{.Name}}[]
+
+{{ if .Arguments }}// tag::directive-arguments-{{.Name}}[]
+.@{{.Name}} Arguments
+[options="header",stripes="even"]
+|===
+| Argument | Type | Default | Description
+{{ range .Arguments }}| ` + "`" + `{{.Name}}` + "`" + ` | ` + "`" + `{{.Type}}` + "`" + ` | {{ if .DefaultValue }}` + "`" + `{{.DefaultValue}}` + "`" + `{{ else }}_none_{{ end }} | {{ if .Description }}{{ .Description }}{{ else }}_No description_{{ end }}
+{{ end }}|===
+// end::directive-arguments-{{.Name}}[]
+
+{{ end }}{{ if .Locations }}// tag::directive-locations-{{.Name}}[]
+.@{{.Name}} Usage Locations
+{{ range .Locations }}* ` + "`" + `{{.}}` + "`" + `
+{{ end }}// end::directive-locations-{{.Name}}[]
+
+{{ end }}{{ if .IsRepeatable }}// tag::directive-repeatable-{{.Name}}[]
+NOTE: This directive is repeatable and can be used multiple times on the same element.
+// end::directive-repeatable-{{.Name}}[]
+
+{{ end }}// end::directive-{{.Name}}[]
+
+{{ end }}// end::DIRECTIVES[]
 `
 
 const DirectiveSectionTemplate = `
@@ -225,7 +568,7 @@ No custom directives exist in this schema.
 {{- end }}
 `
 
-const InputSectionTemplate = `
+const InputSectionTemplate = `{{- if .Inputs }}
 {{.InputsTag}}
 {{range .Inputs}}
 // tag::input-{{.Name}}[]
@@ -244,6 +587,13 @@ const InputSectionTemplate = `
 // end::input-changelog-{{.Name}}[]
 {{- end }}
 
+{{- if .DefinedIn }}
+
+// tag::input-source-{{.Name}}[]
+{{ .DefinedIn }}
+// end::input-source-{{.Name}}[]
+{{- end }}
+
 // tag::input-def-{{.Name}}[]
 {{ .FieldsTable }}
 // end::input-def-{{.Name}}[]
@@ -251,27 +601,18 @@ const InputSectionTemplate = `
 // end::input-{{.Name}}[]
 
 {{end}}
+{{ else -}}
+{{.InputsTag}}
+
+[NOTE]
+====
+No input types exist in this schema.
+====
+
+{{ end -}}
 `
 
-const CatalogueTemplate = `{{- if .SubTitle -}}
-= GraphQL API Catalogue: {{.SubTitle}}
-{{- else -}}
-= GraphQL API Catalogue
-{{- end }}
-:toc: left
-:revdate: {{.RevDate}}
-:commandline: {{.CommandLine}}
-:reproducible:
-:page-partial:
-:sect-anchors:
-:table-caption!:
-:table-stripes: even
-:pdf-page-size: A4
-:tags: api, GraphQL, nodes, types, query
-
-include::_attributes.adoc[]
-
-GraphQL is a modern API query language and runtime that provides a more flexible and efficient way for clients (like web or mobile apps)
+const CatalogueTemplate = `{{.Header}}GraphQL is a modern API query language and runtime that provides a more flexible and efficient way for clients (like web or mobile apps)
 to request data from servers compared to traditional REST APIs.
 
 Instead of having multiple endpoints returning fixed data (like in REST).
@@ -347,3 +688,159 @@ No subscriptions exist in this schema.
 ====
 {{- end }}
 `
+
+// CatalogueQueriesTemplate renders the queries summary table at the top of
+// the full documentation output.
+const CatalogueQueriesTemplate = `== Queries
+
+*Queries* are how clients *read or fetch data* in GraphQL.
+They describe _what_ data the client wants, not _how_ to get it.
+
+The following table provides a quick reference to all available queries in the GraphQL API.
+
+{{- if .Queries }}
+
+[options="header",cols="2m,5a"]
+|===
+| Name | Description
+{{- range .Queries }}
+| {{.Name}} | {{.Description}}{{if .Changelog}}
+{{.Changelog}}{{end}}
+{{- end }}
+|===
+{{- else }}
+
+[NOTE]
+====
+No queries exist in this schema.
+====
+{{- end }}
+
+`
+
+// CatalogueMutationsTemplate renders the grouped mutations summary table at
+// the top of the full documentation output.
+const CatalogueMutationsTemplate = `
+== Mutations
+
+
+*Mutations* are how clients *write or modify data* for example, creating, updating, or deleting records.
+
+A mutation looks similar to a query, but it describes an action that changes data.
+
+The following table provides a quick reference to all available mutations in the GraphQL API.
+
+{{- if .MutationGroups }}
+
+[options="header",cols="2m,5a"]
+|===
+| Name | Description
+{{- range .MutationGroups }}
+2+^h| {{.GroupName}}
+{{- range .Mutations }}
+| {{.Name}} | {{.Description}}{{if .Changelog}}
+{{.Changelog}}{{end}}
+{{- end }}
+{{- end }}
+|===
+{{- else if .Mutations }}
+
+[options="header",cols="2m,5a"]
+|===
+| Name | Description
+{{- range .Mutations }}
+| {{.Name}} | {{.Description}}{{if .Changelog}}
+{{.Changelog}}{{end}}
+{{- end }}
+|===
+{{- else }}
+
+[NOTE]
+====
+No mutations exist in this schema.
+====
+{{- end }}
+
+`
+
+// CatalogueSubscriptionsTemplate renders the subscriptions summary table at
+// the top of the full documentation output.
+const CatalogueSubscriptionsTemplate = `== Subscriptions
+
+{{- if .Subscriptions }}
+
+The following table provides a quick reference to all available subscriptions in the GraphQL API.
+
+[options="header",cols="2m,5a"]
+|===
+| Name | Description
+{{- range .Subscriptions }}
+| {{.Name}} | {{.Description}}{{if .Changelog}}
+{{.Changelog}}{{end}}
+{{- end }}
+|===
+{{- else }}
+
+[NOTE]
+====
+No subscriptions exist in this schema.
+====
+{{- end }}
+
+`
+
+// DiagramTemplate renders the type relationship diagram as an
+// asciidoctor-diagram block
+const DiagramTemplate = `// tag::diagram[]
+[[type_diagram]]
+== Type Diagram
+{{- if .Root }}
+
+The types reachable from ` + "`" + `{{.Root}}` + "`" + `.
+{{- end }}
+{{- if .Source }}
+
+[{{.Style}}, type-diagram, svg]
+----
+{{.Source}}
+----
+{{- else }}
+
+[NOTE]
+====
+No types to draw.
+====
+{{- end }}
+// end::diagram[]
+
+`
+
+// GroupIndexTemplate renders the index of the --group-by groups, with the
+// operations and types documented in each
+const GroupIndexTemplate = `// tag::group-index[]
+[[group_index]]
+== Index
+
+[options="header",cols="2,3,3"]
+|===
+| {{.Heading}} | Operations | Types
+{{- range .Groups }}
+
+| <<{{.AnchorName}},{{.Name}}>>
+| {{.Operations}}
+| {{.Types}}
+{{- end }}
+|===
+// end::group-index[]
+
+`
+
+// GroupTemplate renders one --group-by group. Content holds the group's
+// sections, rendered by the section templates one level further down.
+const GroupTemplate = `// tag::{{.AnchorName}}[]
+[[{{.AnchorName}}]]
+== {{.Name}}
+{{.Content}}
+// end::{{.AnchorName}}[]
+
+`
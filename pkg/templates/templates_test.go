@@ -19,14 +19,25 @@ func TestTemplatesSyntax(t *testing.T) {
 		name         string
 		templateText string
 	}{
+		{"HeaderTemplate", HeaderTemplate},
 		{"FieldTemplate", FieldTemplate},
 		{"ScalarTemplate", ScalarTemplate},
+		{"QueryTemplate", QueryTemplate},
+		{"SubscriptionDetailsTemplate", SubscriptionDetailsTemplate},
 		{"SubscriptionTemplate", SubscriptionTemplate},
 		{"MutationTemplate", MutationTemplate},
 		{"TypeSectionTemplate", TypeSectionTemplate},
+		{"InterfaceSectionTemplate", InterfaceSectionTemplate},
+		{"UnionSectionTemplate", UnionSectionTemplate},
 		{"EnumSectionTemplate", EnumSectionTemplate},
 		{"DirectiveSectionTemplate", DirectiveSectionTemplate},
+		{"DirectivesTemplate", DirectivesTemplate},
 		{"InputSectionTemplate", InputSectionTemplate},
+		{"CatalogueTemplate", CatalogueTemplate},
+		{"CatalogueQueriesTemplate", CatalogueQueriesTemplate},
+		{"CatalogueMutationsTemplate", CatalogueMutationsTemplate},
+		{"CatalogueSubscriptionsTemplate", CatalogueSubscriptionsTemplate},
+		{"DiagramTemplate", DiagramTemplate},
 	}
 
 	for _, tc := range testCases {
@@ -69,13 +80,15 @@ func TestFieldTemplateExecution(t *testing.T) {
 				IsArray         bool
 				Directives      string
 				Changelog       string
+				Deprecation     string
+				Arguments       string
 			}{
 				Type:        "`String`",
 				Name:        "testField",
 				Description: "Test description",
 			},
 			contains: []string{"`String`", "testField", "Test description"},
-			excludes: []string{".Notes:", ".Required:", ".Array:", ".Directives:", ".Changelog"},
+			excludes: []string{".Notes:", ".Required:", ".Array:", ".Directives:", ".Changelog", "[WARNING]", ".Arguments"},
 		},
 		{
 			name: "field with required and array",
@@ -88,6 +101,8 @@ func TestFieldTemplateExecution(t *testing.T) {
 				IsArray         bool
 				Directives      string
 				Changelog       string
+				Deprecation     string
+				Arguments       string
 			}{
 				Type:            "`[String!]`",
 				Name:            "arrayField",
@@ -97,12 +112,16 @@ func TestFieldTemplateExecution(t *testing.T) {
 				IsArray:         true,
 				Directives:      "@deprecated",
 				Changelog:       "\n.Changelog\n* add: 1.0.0\n",
+				Deprecation:     "[WARNING]\n====\n*Deprecated:* Use `items`\n====",
+				Arguments:       "* `first` : `Int` = `10`",
 			},
 			contains: []string{
 				"`[String!]`", "arrayField", "Array field",
 				".Notes:", ".Required:", "This field is required",
 				".Array:", "True", ".Directives:", "@deprecated",
 				".Changelog", "* add: 1.0.0",
+				"Array field\n\n[WARNING]\n====\n*Deprecated:* Use `items`\n====",
+				"\n\n.Arguments\n* `first` : `Int` = `10`\n",
 			},
 		},
 	}
@@ -174,12 +193,12 @@ func TestScalarTemplateExecution(t *testing.T) {
 			data: struct {
 				ScalarTag    string
 				FoundScalars bool
-				Scalars      []struct{ Name, Description string }
+				Scalars      []struct{ Name, Description, DefinedIn string }
 			}{
 				ScalarTag:    "== Scalars",
 				FoundScalars: true,
-				Scalars: []struct{ Name, Description string }{
-					{Name: "DateTime", Description: "A date-time string"},
+				Scalars: []struct{ Name, Description, DefinedIn string }{
+					{Name: "DateTime", Description: "A date-time string", DefinedIn: "*Defined in:* `scalars.graphqls`"},
 					{Name: "JSON", Description: "A JSON scalar"},
 				},
 			},
@@ -188,8 +207,9 @@ func TestScalarTemplateExecution(t *testing.T) {
 				"The following custom scalar types",
 				"DateTime", "A date-time string",
 				"JSON", "A JSON scalar",
+				"// tag::scalar-source-DateTime[]\n*Defined in:* `scalars.graphqls`\n",
 			},
-			excludes: []string{"No custom scalars exist"},
+			excludes: []string{"No custom scalars exist", "scalar-source-JSON"},
 		},
 	}
 
@@ -351,6 +371,8 @@ func TestTemplateConstants(t *testing.T) {
 		"SubscriptionTemplate":     SubscriptionTemplate,
 		"MutationTemplate":         MutationTemplate,
 		"TypeSectionTemplate":      TypeSectionTemplate,
+		"InterfaceSectionTemplate": InterfaceSectionTemplate,
+		"UnionSectionTemplate":     UnionSectionTemplate,
 		"EnumSectionTemplate":      EnumSectionTemplate,
 		"DirectiveSectionTemplate": DirectiveSectionTemplate,
 		"InputSectionTemplate":     InputSectionTemplate,
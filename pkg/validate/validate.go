@@ -0,0 +1,223 @@
+// Package validate implements strict schema validation. Unlike
+// parser.BuildSchema, which accepts any schema that parses, it runs
+// gqlparser's schema validator with the GraphQL prelude, which checks type
+// references, interface implementations and directive usage.
+package validate
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"github.com/vektah/gqlparser/v2/parser"
+	"github.com/vektah/gqlparser/v2/validator"
+)
+
+// Sources validates a schema split across sources, one per file, together
+// with the GraphQL prelude (built-in scalars and directives). Errors carry
+// the source name and line of the offending definition and are sorted by
+// position. A syntax error is returned on its own, since nothing after it can
+// be checked.
+//
+// The validator stops at the first error. To report every broken definition,
+// the type or directive definition holding the error is left out and the
+// schema is validated again, until it passes. A left out type is replaced by
+// a valid stub of the same name and kind, so the types referring to it are
+// still checked; uses of a left out directive are dropped. Each definition
+// therefore gets the first error the validator finds in it.
+func Sources(sources []*ast.Source) gqlerror.List {
+	inputs := append([]*ast.Source{validator.Prelude}, sources...)
+	if _, err := parser.ParseSchemas(inputs...); err != nil {
+		return gqlerror.List{gqlerror.WrapIfUnwrapped(err)}
+	}
+
+	var errs gqlerror.List
+	left := leftOut{types: make(map[string]*ast.Definition), directives: make(map[string]bool)}
+	for {
+		// The validator modifies the document, so every pass needs a fresh
+		// parse
+		doc, _ := parser.ParseSchemas(inputs...)
+		left.apply(doc)
+		_, err := validator.ValidateSchemaDocument(doc)
+		if err == nil {
+			break
+		}
+		gqlErr := gqlerror.WrapIfUnwrapped(err)
+		errs = append(errs, gqlErr)
+		if !left.add(doc, gqlErr) {
+			break
+		}
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errorKey(errs[i]) < errorKey(errs[j])
+	})
+	return errs
+}
+
+// errorKey orders errors by file, then line, then column
+func errorKey(err *gqlerror.Error) string {
+	file, _ := err.Extensions["file"].(string)
+	line, column := 0, 0
+	if len(err.Locations) > 0 {
+		line, column = err.Locations[0].Line, err.Locations[0].Column
+	}
+	return file + "\x00" + padded(line) + padded(column)
+}
+
+func padded(n int) string {
+	s := strconv.Itoa(n)
+	for len(s) < 8 {
+		s = "0" + s
+	}
+	return s
+}
+
+// leftOut holds the type and directive definitions that have been reported
+// and are no longer validated
+type leftOut struct {
+	types      map[string]*ast.Definition
+	directives map[string]bool
+}
+
+// add leaves out the definition that holds an error: the last one in the
+// error's source starting at or before its location. It reports false when
+// the error lies outside every type and directive definition, such as in a
+// schema definition, so validation cannot go on.
+func (l *leftOut) add(doc *ast.SchemaDocument, err *gqlerror.Error) bool {
+	file, _ := err.Extensions["file"].(string)
+	if len(err.Locations) == 0 {
+		return false
+	}
+	at := err.Locations[0]
+
+	var best *ast.Position
+	var def *ast.Definition
+	var directive string
+	consider := func(pos *ast.Position, d *ast.Definition, dir string) {
+		if pos == nil || pos.Src == nil || pos.Src.Name != file || !before(pos, at) {
+			return
+		}
+		if best == nil || before(best, gqlerror.Location{Line: pos.Line, Column: pos.Column}) {
+			best, def, directive = pos, d, dir
+		}
+	}
+	for _, d := range append(append(ast.DefinitionList{}, doc.Definitions...), doc.Extensions...) {
+		consider(d.Position, d, "")
+	}
+	for _, d := range doc.Directives {
+		consider(d.Position, nil, d.Name)
+	}
+	for _, schema := range append(append(ast.SchemaDefinitionList{}, doc.Schema...), doc.SchemaExtension...) {
+		consider(schema.Position, nil, "")
+	}
+
+	switch {
+	case def != nil && l.types[def.Name] == nil:
+		l.types[def.Name] = def
+	case directive != "" && !l.directives[directive]:
+		l.directives[directive] = true
+	default:
+		return false
+	}
+	return true
+}
+
+// before reports whether pos is at or before the location
+func before(pos *ast.Position, at gqlerror.Location) bool {
+	return pos.Line < at.Line || pos.Line == at.Line && pos.Column <= at.Column
+}
+
+// apply replaces every left out type in the document with a stub of the same
+// name that passes validation, so references to it are still checked, and
+// removes left out directives together with their uses
+func (l *leftOut) apply(doc *ast.SchemaDocument) {
+	names := make([]string, 0, len(l.types))
+	for name := range l.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var defs ast.DefinitionList
+	for _, def := range doc.Definitions {
+		if l.types[def.Name] == nil {
+			defs = append(defs, def)
+		}
+	}
+	for _, name := range names {
+		defs = append(defs, stub(l.types[name]))
+	}
+	doc.Definitions = defs
+
+	var extensions ast.DefinitionList
+	for _, ext := range doc.Extensions {
+		if l.types[ext.Name] == nil {
+			extensions = append(extensions, ext)
+		}
+	}
+	doc.Extensions = extensions
+
+	var directives ast.DirectiveDefinitionList
+	for _, dir := range doc.Directives {
+		if !l.directives[dir.Name] {
+			directives = append(directives, dir)
+		}
+	}
+	doc.Directives = directives
+
+	for _, def := range append(append(ast.DefinitionList{}, doc.Definitions...), doc.Extensions...) {
+		def.Directives = l.pruneDirectives(def.Directives)
+		var interfaces []string
+		for _, name := range def.Interfaces {
+			// A stub interface has none of the fields its implementers need
+			if left := l.types[name]; left == nil || left.Kind != ast.Interface {
+				interfaces = append(interfaces, name)
+			}
+		}
+		def.Interfaces = interfaces
+		for _, field := range def.Fields {
+			field.Directives = l.pruneDirectives(field.Directives)
+			for _, arg := range field.Arguments {
+				arg.Directives = l.pruneDirectives(arg.Directives)
+			}
+		}
+		for _, value := range def.EnumValues {
+			value.Directives = l.pruneDirectives(value.Directives)
+		}
+	}
+	for _, dir := range doc.Directives {
+		for _, arg := range dir.Arguments {
+			arg.Directives = l.pruneDirectives(arg.Directives)
+		}
+	}
+	for _, schema := range append(append(ast.SchemaDefinitionList{}, doc.Schema...), doc.SchemaExtension...) {
+		schema.Directives = l.pruneDirectives(schema.Directives)
+	}
+}
+
+func (l *leftOut) pruneDirectives(dirs ast.DirectiveList) ast.DirectiveList {
+	var kept ast.DirectiveList
+	for _, dir := range dirs {
+		if !l.directives[dir.Name] {
+			kept = append(kept, dir)
+		}
+	}
+	return kept
+}
+
+// stub returns the smallest valid definition of the kind of def. A union
+// becomes a scalar, since its members would need valid object types.
+func stub(def *ast.Definition) *ast.Definition {
+	s := &ast.Definition{Kind: def.Kind, Name: def.Name, Position: def.Position}
+	field := &ast.FieldDefinition{Name: "_", Type: ast.NamedType("Boolean", nil)}
+	switch def.Kind {
+	case ast.Object, ast.Interface, ast.InputObject:
+		s.Fields = ast.FieldList{field}
+	case ast.Enum:
+		s.EnumValues = ast.EnumValueList{{Name: "_"}}
+	case ast.Union:
+		s.Kind = ast.Scalar
+	}
+	return s
+}
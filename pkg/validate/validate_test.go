@@ -0,0 +1,122 @@
+package validate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func validateSDL(files ...string) []string {
+	var sources []*ast.Source
+	for i := 0; i < len(files); i += 2 {
+		sources = append(sources, &ast.Source{Name: files[i], Input: files[i+1]})
+	}
+	var messages []string
+	for _, err := range Sources(sources) {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
+
+func TestSourcesValidSchema(t *testing.T) {
+	errs := validateSDL("schema.graphqls", `
+directive @auth(role: String!) on FIELD_DEFINITION
+interface Node { id: ID! }
+type User implements Node { id: ID! name: String @auth(role: "admin") }
+type Query { user(id: ID!): User node: Node }
+extend type User { email: String @deprecated(reason: "gone") }
+union Result = User
+enum Role { ADMIN }
+input Filter { role: Role = ADMIN }
+`)
+	if len(errs) != 0 {
+		t.Errorf("Expected no errors, got:\n%s", strings.Join(errs, "\n"))
+	}
+}
+
+func TestSourcesReportsEveryError(t *testing.T) {
+	errs := validateSDL(
+		"query.graphqls", "type Query {\n  user: Usr\n  posts(filter: PostFilter @auth): [Post]\n}\n",
+		"types.graphqls", "interface Node { id: ID! name: String }\n\ntype Post implements Node {\n  id: ID\n}\n"+
+			"input PostFilter { owner: Post }\nenum Status { OPEN @deprecated(why: \"x\") }\n",
+	)
+
+	// Each broken definition gets its first error, and PostFilter is still
+	// checked against the left out Post
+	expected := []string{
+		"query.graphqls:2:9: Undefined type Usr.",
+		"types.graphqls:4:3: For Post to implement Node the field id must have type ID!.",
+		"types.graphqls:6:20: OBJECT owner: field must be one of SCALAR, ENUM, INPUT_OBJECT.",
+		"types.graphqls:7:32: Undefined argument why for directive deprecated.",
+	}
+	if got := strings.Join(errs, "\n"); got != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected errors:\n%s\n\nexpected:\n%s", got, strings.Join(expected, "\n"))
+	}
+}
+
+func TestSourcesDirectiveUsage(t *testing.T) {
+	errs := validateSDL("schema.graphqls", `
+directive @auth(role: String!) on FIELD_DEFINITION
+type Query @auth(role: "x") { a: Int }
+type A { a: Int @auth }
+type B { b: Int @auth(role: "x", scope: 1) }
+`)
+	expected := []string{
+		"schema.graphqls:3:13: Directive auth is not applicable on OBJECT.",
+		"schema.graphqls:4:18: Argument role for directive auth cannot be null.",
+		"schema.graphqls:5:34: Undefined argument scope for directive auth.",
+	}
+	if got := strings.Join(errs, "\n"); got != strings.Join(expected, "\n") {
+		t.Errorf("Unexpected errors:\n%s\n\nexpected:\n%s", got, strings.Join(expected, "\n"))
+	}
+}
+
+func TestSourcesBrokenDirectiveDefinition(t *testing.T) {
+	// Uses of a left out directive are not reported again
+	errs := validateSDL("schema.graphqls", `
+directive @auth(role: Missing) on FIELD_DEFINITION
+type Query { a: Int @auth(role: 1) }
+`)
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "schema.graphqls:2:23: Undefined type Missing.") {
+		t.Errorf("Expected a single error for @auth, got %v", errs)
+	}
+}
+
+func TestSourcesUnionAndInterfaceKinds(t *testing.T) {
+	errs := validateSDL("schema.graphqls", `
+type Query { a: Int }
+type A implements Query { a: Int }
+union U = A | Missing
+`)
+	for _, expected := range []string{
+		`"Query" is a non interface type OBJECT.`,
+		`Undefined type "Missing".`,
+	} {
+		if !strings.Contains(strings.Join(errs, "\n"), expected) {
+			t.Errorf("Expected error %q, got:\n%s", expected, strings.Join(errs, "\n"))
+		}
+	}
+}
+
+func TestSourcesLeftOutUnion(t *testing.T) {
+	// The left out union still stands for an output type
+	errs := validateSDL("schema.graphqls", "type Query { a: U }\nunion U = Missing\n")
+	if len(errs) != 1 || !strings.Contains(errs[0], `Undefined type "Missing".`) {
+		t.Errorf("Expected a single error for U, got %v", errs)
+	}
+}
+
+func TestSourcesEmptyEnum(t *testing.T) {
+	errs := validateSDL("schema.graphqls", "type Query { a: Int }\nenum Empty\n")
+	if len(errs) != 1 || !strings.Contains(errs[0], "must define one or more unique enum values") {
+		t.Errorf("Expected a single error for Empty, got %v", errs)
+	}
+}
+
+func TestSourcesSyntaxError(t *testing.T) {
+	errs := validateSDL("broken.graphqls", "type Query {\n  a: Int\n")
+	if len(errs) != 1 || !strings.HasPrefix(errs[0], "broken.graphqls:3") {
+		t.Errorf("Expected a single syntax error in broken.graphqls, got %v", errs)
+	}
+}
@@ -0,0 +1,157 @@
+// Package watch polls schema files, and the files that shape the output, for
+// changes. It needs no platform
+// notification API: every interval it lists the files to watch and compares
+// their modification times and sizes with the previous listing, so files
+// that appear, change or disappear are all noticed, including new files
+// matching a pattern.
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/bovinemagnet/graphqls-to-asciidoc/pkg/parser"
+)
+
+// Default timings of a Watcher
+const (
+	DefaultInterval = 250 * time.Millisecond
+	DefaultDebounce = 300 * time.Millisecond
+)
+
+// Watcher reports changes to a single schema file or to the files matching
+// a pattern, and to any further files and directories it was given
+type Watcher struct {
+	// Interval is the time between two listings of the files
+	Interval time.Duration
+	// Debounce is how long the files must stay unchanged before a change is
+	// reported, so that a burst of saves causes a single report
+	Debounce time.Duration
+
+	file, pattern string
+	extra         []string
+	state         map[string]fileState
+}
+
+// fileState is what a listing records of a file
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// New returns a watcher for a schema file or, when pattern is set, for every
+// file matching the pattern as found by parser.FindSchemaFiles. extra names
+// further files to watch, such as a configuration file, and directories
+// whose files are all watched, such as a template directory. The files
+// present when New is called are the starting point for later changes.
+func New(file, pattern string, extra ...string) *Watcher {
+	w := &Watcher{Interval: DefaultInterval, Debounce: DefaultDebounce, file: file, pattern: pattern, extra: extra}
+	w.state = w.scan()
+	return w
+}
+
+// Files returns the watched files, sorted by name
+func (w *Watcher) Files() []string {
+	files := make([]string, 0, len(w.state))
+	for f := range w.state {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// scan lists the watched files. A pattern that matches nothing and a missing
+// file give an empty listing rather than an error: the files may be in the
+// middle of being renamed or recreated by an editor. Directories are listed
+// again every time, so files added to them are noticed.
+func (w *Watcher) scan() map[string]fileState {
+	files := []string{w.file}
+	if w.pattern != "" {
+		var err error
+		if files, err = parser.FindSchemaFiles(w.pattern); err != nil {
+			files = nil
+		}
+	}
+	for _, path := range w.extra {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			// Not a directory, or one that cannot be read just now
+			files = append(files, path)
+			continue
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	state := make(map[string]fileState, len(files))
+	for _, f := range files {
+		info, err := os.Stat(f)
+		if err != nil {
+			continue
+		}
+		state[f] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	return state
+}
+
+// Poll lists the files again and returns those created, changed or removed
+// since the previous listing, sorted by name
+func (w *Watcher) Poll() []string {
+	state := w.scan()
+	var changed []string
+	for f, s := range state {
+		if old, ok := w.state[f]; !ok || old != s {
+			changed = append(changed, f)
+		}
+	}
+	for f := range w.state {
+		if _, ok := state[f]; !ok {
+			changed = append(changed, f)
+		}
+	}
+	w.state = state
+	sort.Strings(changed)
+	return changed
+}
+
+// Run polls the files until ctx is cancelled. Once the files have been
+// quiet for the debounce period after one or more changes, it calls
+// onChange with every file changed since the last call.
+func (w *Watcher) Run(ctx context.Context, onChange func(changed []string)) {
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+
+	pending := make(map[string]bool)
+	var lastChange time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if changed := w.Poll(); len(changed) > 0 {
+				for _, f := range changed {
+					pending[f] = true
+				}
+				lastChange = now
+				continue
+			}
+			if len(pending) == 0 || now.Sub(lastChange) < w.Debounce {
+				continue
+			}
+
+			files := make([]string, 0, len(pending))
+			for f := range pending {
+				files = append(files, f)
+			}
+			sort.Strings(files)
+			pending = make(map[string]bool)
+			onChange(files)
+		}
+	}
+}
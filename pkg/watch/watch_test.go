@@ -0,0 +1,148 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func writeFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("Failed to write %s: %v", path, err)
+	}
+	// Set the time explicitly: file systems with coarse timestamps would
+	// otherwise hide quick successive writes
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Failed to set time of %s: %v", path, err)
+	}
+}
+
+func TestPollPattern(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	users := filepath.Join(dir, "users.graphqls")
+	posts := filepath.Join(dir, "posts.graphqls")
+	writeFile(t, users, "type User { id: ID! }", start)
+
+	w := New("", filepath.Join(dir, "*.graphqls"))
+	if !reflect.DeepEqual(w.Files(), []string{users}) {
+		t.Fatalf("Expected to watch %v, got %v", []string{users}, w.Files())
+	}
+	if changed := w.Poll(); changed != nil {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+
+	// A new file matching the pattern is picked up
+	writeFile(t, posts, "type Post { id: ID! }", start)
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{posts}) {
+		t.Errorf("Expected new file %v, got %v", posts, changed)
+	}
+
+	// Other files in the directory are ignored
+	writeFile(t, filepath.Join(dir, "notes.txt"), "todo", start)
+	if changed := w.Poll(); changed != nil {
+		t.Errorf("Expected no changes, got %v", changed)
+	}
+
+	writeFile(t, users, "type User { id: ID! name: String }", start.Add(time.Second))
+	if err := os.Remove(posts); err != nil {
+		t.Fatal(err)
+	}
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{posts, users}) {
+		t.Errorf("Expected changed and removed files, got %v", changed)
+	}
+	if !reflect.DeepEqual(w.Files(), []string{users}) {
+		t.Errorf("Expected to watch %v, got %v", []string{users}, w.Files())
+	}
+}
+
+func TestPollExtraFilesAndDirectories(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	schema := filepath.Join(dir, "schema.graphqls")
+	config := filepath.Join(dir, "config.yaml")
+	templates := filepath.Join(dir, "templates")
+	if err := os.Mkdir(templates, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, schema, "type Query { a: String }", start)
+	writeFile(t, config, "title: API", start)
+
+	w := New(schema, "", config, templates)
+	if !reflect.DeepEqual(w.Files(), []string{config, schema}) {
+		t.Fatalf("Expected to watch %v, got %v", []string{config, schema}, w.Files())
+	}
+
+	writeFile(t, config, "title: Orders API", start.Add(time.Second))
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{config}) {
+		t.Errorf("Expected %v to change, got %v", config, changed)
+	}
+
+	// A template added to the directory is picked up
+	query := filepath.Join(templates, "query.tmpl")
+	writeFile(t, query, "{{.Name}}", start)
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{query}) {
+		t.Errorf("Expected new template %v, got %v", query, changed)
+	}
+}
+
+func TestPollSingleFile(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	schema := filepath.Join(dir, "schema.graphqls")
+	writeFile(t, schema, "type Query { a: String }", start)
+
+	w := New(schema, "")
+	writeFile(t, filepath.Join(dir, "other.graphqls"), "type Other { a: String }", start)
+	if changed := w.Poll(); changed != nil {
+		t.Errorf("Only the schema file should be watched, got %v", changed)
+	}
+
+	writeFile(t, schema, "type Query { b: String }", start.Add(time.Second))
+	if changed := w.Poll(); !reflect.DeepEqual(changed, []string{schema}) {
+		t.Errorf("Expected %v to change, got %v", schema, changed)
+	}
+}
+
+func TestRunDebouncesBursts(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now().Add(-time.Hour)
+	schema := filepath.Join(dir, "schema.graphqls")
+	writeFile(t, schema, "type Query { a: String }", start)
+
+	w := New("", filepath.Join(dir, "*.graphqls"))
+	w.Interval = 10 * time.Millisecond
+	w.Debounce = 100 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reports := make(chan []string, 10)
+	go w.Run(ctx, func(changed []string) { reports <- changed })
+
+	// A burst of writes, each well within the debounce period
+	other := filepath.Join(dir, "other.graphqls")
+	for i := 1; i <= 3; i++ {
+		writeFile(t, schema, "type Query { a: String }"+string(rune('a'+i)), start.Add(time.Duration(i)*time.Second))
+		time.Sleep(20 * time.Millisecond)
+	}
+	writeFile(t, other, "type Other { a: String }", start)
+
+	select {
+	case changed := <-reports:
+		if !reflect.DeepEqual(changed, []string{other, schema}) {
+			t.Errorf("Expected one report of both files, got %v", changed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected a change to be reported")
+	}
+
+	select {
+	case changed := <-reports:
+		t.Errorf("Expected a single report for the burst, got another: %v", changed)
+	case <-time.After(200 * time.Millisecond):
+	}
+}